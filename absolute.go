@@ -0,0 +1,90 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+)
+
+var _ Scheme = &Absolute{}
+
+// Absolute is a Scheme that places each child at an explicit Rectangle, for canvas-like editors
+// and floating tool windows where no algorithm decides the layout.
+//
+// An Absolute must be used by pointer, because updates change its state.
+type Absolute struct {
+	// Rects are the initial areas of the children, relative to the top-left corner of the layout.
+	// There must be one Rectangle per child.
+	Rects []image.Rectangle
+
+	// Updates, if not nil, receives new Rectangles for the children while the layout runs.
+	// Each update re-partitions the layout and sends new Resize events to the children.
+	// Children without a Rectangle in an update get an empty one. It should be closed when it is
+	// no longer used.
+	Updates chan []image.Rectangle
+
+	// Background fills the space not covered by the children. Defaults to black.
+	Background color.Color
+
+	mu      sync.Mutex
+	rects   []image.Rectangle // last update
+	updated bool
+	bounds  image.Rectangle // last Resize
+}
+
+func (a *Absolute) Partition(bounds image.Rectangle) []image.Rectangle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	rects := a.Rects
+	if a.updated {
+		rects = a.rects
+	}
+	ret := make([]image.Rectangle, len(a.Rects))
+	for i := range ret {
+		if i < len(rects) {
+			ret[i] = rects[i].Add(bounds.Min)
+		}
+	}
+	return ret
+}
+
+func (a *Absolute) Intercept(parent Env) Env {
+	env, inject := NewInjector(parent)
+	if a.Updates == nil {
+		close(inject)
+	} else {
+		go func() {
+			defer close(inject)
+			for rects := range a.Updates {
+				a.mu.Lock()
+				a.rects, a.updated = rects, true
+				bounds := a.bounds
+				a.mu.Unlock()
+				if !bounds.Empty() {
+					inject <- Resize{bounds} // re-partition the children
+				}
+			}
+		}()
+	}
+
+	col := a.Background
+	if col == nil {
+		col = color.Black
+	}
+	return newEnv(env,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				a.mu.Lock()
+				a.bounds = resize.Rectangle
+				a.mu.Unlock()
+				env.Draw() <- func(drw draw.Image) image.Rectangle {
+					draw.Draw(drw, resize.Rectangle, image.NewUniform(col), image.Point{}, draw.Src)
+					return resize.Rectangle
+				}
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
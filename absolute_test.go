@@ -0,0 +1,46 @@
+package gui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestAbsolute(t *testing.T) {
+	a := &Absolute{Rects: []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(5, 5, 20, 20)}}
+	got := a.Partition(image.Rect(100, 100, 200, 200))
+	want := []image.Rectangle{image.Rect(100, 100, 110, 110), image.Rect(105, 105, 120, 120)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	root := newDummyEnv(image.Rect(0, 0, 50, 50))
+	defer func() {
+		root.kill <- true
+		<-root.dead
+	}()
+	go drain(root.drawOut)
+	a.Updates = make(chan []image.Rectangle)
+	defer close(a.Updates)
+	env := a.Intercept(root)
+	if _, ok := tryRecv(env.Events(), timeout); !ok {
+		t.Fatalf("no Resize event received after %v", timeout)
+	}
+
+	// An update re-emits the last Resize, which re-partitions the layout.
+	if !trySend(a.Updates, []image.Rectangle{image.Rect(1, 2, 3, 4)}, timeout) {
+		t.Fatalf("update not accepted after %v", timeout)
+	}
+	eventp, ok := tryRecv(env.Events(), timeout)
+	if !ok {
+		t.Fatalf("no Resize event received after %v", timeout)
+	}
+	if want := (Resize{image.Rect(0, 0, 50, 50)}); *eventp != want {
+		t.Errorf("received %v; wanted %v", *eventp, want)
+	}
+	got = a.Partition(image.Rect(0, 0, 50, 50))
+	want = []image.Rectangle{image.Rect(1, 2, 3, 4), {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
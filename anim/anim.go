@@ -0,0 +1,130 @@
+// Package anim animates values over time with easing curves.
+//
+// A Tween describes how a value changes. Play drives a Tween with a frame clock and delivers
+// its values as Frame Events through an Env, so an element handles animation frames in the same
+// loop as its other Events:
+//
+//	fade := anim.Tween[color.Color]{From: color.White, To: color.Black, Duration: time.Second, Lerp: anim.Color}
+//	env = anim.Play(env, fade, 60)
+//	for e := range env.Events() {
+//		switch e := e.(type) {
+//		case anim.Frame[color.Color]:
+//			env.Draw() <- fill(e.Value)
+//		...
+//		}
+//	}
+//
+// The animation stops when the Env returned by Play dies.
+package anim
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/faiface/gui"
+)
+
+// Easing maps the linear progress of an animation, from 0 to 1, to the progress of the value.
+type Easing func(t float64) float64
+
+// Easing curves.
+var (
+	Linear    Easing = func(t float64) float64 { return t }
+	EaseIn    Easing = func(t float64) float64 { return t * t * t }
+	EaseOut   Easing = func(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+	EaseInOut Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 3)/2
+	}
+)
+
+// Lerp interpolates between a and b. t is 0 at a and 1 at b, and may overshoot.
+type Lerp[T any] func(a, b T, t float64) T
+
+// Float interpolates float64 values.
+func Float(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// Point interpolates Points, rounding to the nearest pixel.
+func Point(a, b image.Point, t float64) image.Point {
+	return image.Pt(int(math.Round(Float(float64(a.X), float64(b.X), t))), int(math.Round(Float(float64(a.Y), float64(b.Y), t))))
+}
+
+// Color interpolates colors in premultiplied RGBA.
+func Color(a, b color.Color, t float64) color.Color {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	c := func(x, y uint32) uint16 {
+		return uint16(math.Max(0, math.Min(0xffff, math.Round(Float(float64(x), float64(y), t)))))
+	}
+	return color.RGBA64{c(ar, br), c(ag, bg), c(ab, bb), c(aa, ba)}
+}
+
+// Tween changes a value from From to To over Duration.
+type Tween[T any] struct {
+	From, To T
+	Duration time.Duration
+	// Easing defaults to EaseInOut.
+	Easing Easing
+	// Lerp interpolates between From and To, e.g. Float, Point, or Color. It must be set.
+	Lerp Lerp[T]
+}
+
+// At returns the value after elapsed time. It is From before the start and To after the end.
+func (tw Tween[T]) At(elapsed time.Duration) T {
+	if elapsed <= 0 {
+		return tw.From
+	}
+	if elapsed >= tw.Duration {
+		return tw.To
+	}
+	ease := tw.Easing
+	if ease == nil {
+		ease = EaseInOut
+	}
+	return tw.Lerp(tw.From, tw.To, ease(float64(elapsed)/float64(tw.Duration)))
+}
+
+// Frame is an Event carrying the value of a Tween at one frame. Done is true for the last Frame,
+// whose Value is the final value of the Tween.
+type Frame[T any] struct {
+	Value T
+	Done  bool
+}
+
+func (f Frame[T]) String() string { return fmt.Sprintf("anim/frame/%v/%t", f.Value, f.Done) }
+
+// Play makes an Env that passes along the Events of parent, along with a Frame for each frame of
+// tw, fps times per second. The last Frame is sent once the Duration has passed. If the element
+// falls behind, only the latest Frame waits for it.
+//
+// The animation stops when the returned Env dies, e.g. because it or the parent was killed.
+func Play[T any](parent gui.Env, tw Tween[T], fps int) gui.Env {
+	if fps <= 0 {
+		fps = 60
+	}
+	start := time.Now()
+	return gui.Merge(parent, func(emit func(gui.Event), done <-chan bool) {
+		ticker := time.NewTicker(time.Second / time.Duration(fps))
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				elapsed := now.Sub(start)
+				last := elapsed >= tw.Duration
+				emit(Frame[T]{tw.At(elapsed), last})
+				if last {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	})
+}
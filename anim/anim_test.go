@@ -0,0 +1,29 @@
+package anim
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestTween(t *testing.T) {
+	tw := Tween[image.Point]{From: image.Pt(0, 0), To: image.Pt(100, 10), Duration: time.Second, Easing: Linear, Lerp: Point}
+	for _, test := range []struct {
+		elapsed time.Duration
+		want    image.Point
+	}{
+		{-time.Second, image.Pt(0, 0)},
+		{time.Second / 2, image.Pt(50, 5)},
+		{2 * time.Second, image.Pt(100, 10)},
+	} {
+		if got := tw.At(test.elapsed); got != test.want {
+			t.Errorf("At(%v) = %v; wanted %v", test.elapsed, got, test.want)
+		}
+	}
+
+	for name, ease := range map[string]Easing{"Linear": Linear, "EaseIn": EaseIn, "EaseOut": EaseOut, "EaseInOut": EaseInOut} {
+		if ease(0) != 0 || ease(1) != 1 {
+			t.Errorf("%s does not go from 0 to 1", name)
+		}
+	}
+}
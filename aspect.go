@@ -0,0 +1,56 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var _ Scheme = Aspect{}
+
+// Aspect is a Scheme for a single child, which gets the largest Rectangle with a fixed aspect
+// ratio that fits in the layout, centered in it. The bars left on the sides are painted, like
+// letterboxing a video. It suits game viewports and video players.
+type Aspect struct {
+	// Ratio is the aspect ratio as width and height, e.g. image.Pt(16, 9).
+	Ratio image.Point
+
+	// Bars is the color of the bars. Defaults to black.
+	Bars color.Color
+}
+
+func (a Aspect) Partition(bounds image.Rectangle) []image.Rectangle {
+	return []image.Rectangle{FitRect(a.Ratio, bounds)}
+}
+
+func (a Aspect) Intercept(parent Env) Env {
+	col := a.Bars
+	if col == nil {
+		col = color.Black
+	}
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				outer, inner := resize.Rectangle, FitRect(a.Ratio, resize.Rectangle)
+				if inner.Empty() {
+					inner = image.Rectangle{outer.Min, outer.Min} // all bars
+				}
+				parent.Draw() <- func(drw draw.Image) image.Rectangle {
+					src := image.NewUniform(col)
+					// The bars are on two opposite sides, the other two are empty.
+					for _, bar := range []image.Rectangle{
+						image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, inner.Min.Y), // top
+						image.Rect(outer.Min.X, inner.Max.Y, outer.Max.X, outer.Max.Y), // bottom
+						image.Rect(outer.Min.X, outer.Min.Y, inner.Min.X, outer.Max.Y), // left
+						image.Rect(inner.Max.X, outer.Min.Y, outer.Max.X, outer.Max.Y), // right
+					} {
+						draw.Draw(drw, bar, src, image.Point{}, draw.Src)
+					}
+					return outer
+				}
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
@@ -0,0 +1,13 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestAspect(t *testing.T) {
+	got := Aspect{Ratio: image.Pt(16, 9)}.Partition(image.Rect(0, 0, 200, 90))
+	if want := image.Rect(20, 0, 180, 90); got[0] != want {
+		t.Errorf("received %v; wanted %v", got[0], want)
+	}
+}
@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// SpriteID identifies an image added to an Atlas.
+type SpriteID int
+
+// Atlas packs many small images, such as icons or the frames of a game character, into one
+// RGBA image. Drawing sprites from one image is cheaper than keeping many small images around.
+//
+// Sprites are packed in rows, left to right, with 1 pixel of padding so that scaling doesn't
+// bleed neighbors into each other. The atlas grows downwards as needed.
+type Atlas struct {
+	img     *image.RGBA
+	sprites []image.Rectangle
+
+	x, y, rowHeight int // packing position in the current row
+}
+
+// NewAtlas makes an empty Atlas that is width pixels wide. Sprites wider than that widen it.
+func NewAtlas(width int) *Atlas {
+	return &Atlas{img: image.NewRGBA(image.Rect(0, 0, width, 0))}
+}
+
+// Add copies img into the Atlas and returns its SpriteID.
+func (a *Atlas) Add(img image.Image) SpriteID {
+	const pad = 1
+	size := img.Bounds().Size()
+	width := a.img.Bounds().Dx()
+
+	if a.x > 0 && a.x+size.X > width {
+		a.x, a.y, a.rowHeight = 0, a.y+a.rowHeight+pad, 0
+	}
+	r := image.Rectangle{image.Pt(a.x, a.y), image.Pt(a.x, a.y).Add(size)}
+	a.grow(image.Pt(max(width, r.Max.X), r.Max.Y))
+	draw.Draw(a.img, r, img, img.Bounds().Min, draw.Src)
+
+	a.x += size.X + pad
+	a.rowHeight = max(a.rowHeight, size.Y)
+	a.sprites = append(a.sprites, r)
+	return SpriteID(len(a.sprites) - 1)
+}
+
+// grow makes the image of the Atlas at least size large.
+func (a *Atlas) grow(size image.Point) {
+	b := a.img.Bounds()
+	if size.X <= b.Dx() && size.Y <= b.Dy() {
+		return
+	}
+	// Grow by half again to avoid copying on every row.
+	h := max(size.Y, b.Dy()+b.Dy()/2)
+	img := image.NewRGBA(image.Rect(0, 0, max(size.X, b.Dx()), h))
+	draw.Draw(img, b, a.img, b.Min, draw.Src)
+	a.img = img
+}
+
+// Image returns the image holding all sprites. It is replaced when the Atlas grows.
+func (a *Atlas) Image() *image.RGBA {
+	return a.img
+}
+
+// Sprite returns the area of a sprite in Image.
+func (a *Atlas) Sprite(id SpriteID) image.Rectangle {
+	return a.sprites[id]
+}
+
+// SubImage returns a sprite as an image sharing pixels with the Atlas, with bounds starting at
+// 0, 0, e.g. to pass it to DrawScaled or NinePatch.
+func (a *Atlas) SubImage(id SpriteID) image.Image {
+	r := a.sprites[id]
+	sub := a.img.SubImage(r).(*image.RGBA)
+	return &image.RGBA{Pix: sub.Pix, Stride: sub.Stride, Rect: image.Rectangle{Max: r.Size()}}
+}
+
+// Draw draws a sprite over dst with its top-left corner at at, and returns the changed area.
+func (a *Atlas) Draw(dst draw.Image, id SpriteID, at image.Point) image.Rectangle {
+	r := a.sprites[id]
+	target := r.Sub(r.Min).Add(at).Intersect(dst.Bounds())
+	draw.Draw(dst, target, a.img, r.Min.Add(target.Min.Sub(at)), draw.Over)
+	return target
+}
@@ -0,0 +1,42 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestAtlas(t *testing.T) {
+	atlas := NewAtlas(32)
+	cols := []color.RGBA{{0xff, 0, 0, 0xff}, {0, 0xff, 0, 0xff}, {0, 0, 0xff, 0xff}}
+	var ids []SpriteID
+	for i, col := range cols {
+		img := image.NewRGBA(image.Rect(0, 0, 12, 8+i))
+		draw.Draw(img, img.Bounds(), image.NewUniform(col), image.Point{}, draw.Src)
+		ids = append(ids, atlas.Add(img))
+	}
+
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			if atlas.Sprite(ids[i]).Overlaps(atlas.Sprite(ids[j])) {
+				t.Errorf("sprites %d and %d overlap", i, j)
+			}
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, 50, 50))
+	for i, id := range ids {
+		at := image.Pt(10, 10)
+		r := atlas.Draw(dst, id, at)
+		if want := image.Rect(10, 10, 22, 18+i); r != want {
+			t.Errorf("sprite %d changed %v; wanted %v", i, r, want)
+		}
+		if got := dst.RGBAAt(15, 15); got != cols[i] {
+			t.Errorf("sprite %d drew %v; wanted %v", i, got, cols[i])
+		}
+		if got := atlas.SubImage(id).At(0, 0); got != cols[i] {
+			t.Errorf("SubImage(%d) = %v; wanted %v", i, got, cols[i])
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Batch combines draw functions into one, which runs them in order and returns the union of
+// their Rectangles.
+//
+// Sending a Batch to a Draw() channel costs a single send, and the draw functions can't be split
+// across frames, because a window only flushes between draw functions:
+//
+//	env.Draw() <- gui.Batch(drawBackground, drawContent, drawBorder)
+func Batch(ds ...func(draw.Image) image.Rectangle) func(draw.Image) image.Rectangle {
+	return func(drw draw.Image) image.Rectangle {
+		var r image.Rectangle
+		for _, d := range ds {
+			r = r.Union(d(drw))
+		}
+		return r
+	}
+}
@@ -0,0 +1,39 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// The draw functions of a Batch run in order, and report the union of their Rectangles.
+func TestBatch(t *testing.T) {
+	fill := func(r image.Rectangle, c color.Color) func(draw.Image) image.Rectangle {
+		return func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, r, image.NewUniform(c), image.Point{}, draw.Src)
+			return r
+		}
+	}
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	b := Batch(
+		fill(image.Rect(0, 0, 10, 10), red),
+		fill(image.Rect(5, 5, 15, 15), blue),
+	)
+	if r, want := b(img), image.Rect(0, 0, 15, 15); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	for pt, want := range map[image.Point]color.RGBA{
+		image.Pt(2, 2):   red,
+		image.Pt(7, 7):   blue, // drawn over red
+		image.Pt(12, 12): blue,
+		image.Pt(17, 17): {},
+	} {
+		if got := img.RGBAAt(pt.X, pt.Y); got != want {
+			t.Errorf("pixel %v = %v; wanted %v", pt, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// blit copies the pixels of src inside r to the same place in dst.
+//
+// When both images have the same pixel layout, each row is copied with a single copy, which
+// compiles to a vectorized memmove. Otherwise it falls back to draw.Draw, which goes pixel by pixel
+// for most formats other than RGBA.
+func blit(dst draw.Image, src image.Image, r image.Rectangle) {
+	r = r.Intersect(dst.Bounds()).Intersect(src.Bounds())
+	if r.Empty() {
+		return
+	}
+	switch dst := dst.(type) {
+	case *image.RGBA:
+		if src, ok := src.(*image.RGBA); ok {
+			copyRows(dst.Pix, dst.PixOffset(r.Min.X, r.Min.Y), dst.Stride,
+				src.Pix, src.PixOffset(r.Min.X, r.Min.Y), src.Stride, 4*r.Dx(), r.Dy())
+			return
+		}
+	case *image.RGBA64:
+		if src, ok := src.(*image.RGBA64); ok {
+			copyRows(dst.Pix, dst.PixOffset(r.Min.X, r.Min.Y), dst.Stride,
+				src.Pix, src.PixOffset(r.Min.X, r.Min.Y), src.Stride, 8*r.Dx(), r.Dy())
+			return
+		}
+	}
+	draw.Draw(dst, r, src, r.Min, draw.Src)
+}
+
+// copyRows copies rows of n bytes from src to dst. Rows that are stored contiguously in both
+// images are copied at once.
+func copyRows(dst []uint8, di, dstride int, src []uint8, si, sstride int, n, rows int) {
+	if n == dstride && n == sstride {
+		copy(dst[di:di+n*rows], src[si:si+n*rows])
+		return
+	}
+	for y := 0; y < rows; y++ {
+		copy(dst[di:di+n], src[si:si+n])
+		di += dstride
+		si += sstride
+	}
+}
@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestBlit(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i)
+		if i%4 == 3 {
+			src.Pix[i] = 0xff
+		}
+	}
+	for _, test := range []struct {
+		name string
+		dst  draw.Image
+	}{
+		{"RGBA", image.NewRGBA(image.Rect(2, 2, 12, 12))},
+		{"NRGBA", image.NewNRGBA(image.Rect(2, 2, 12, 12))},
+	} {
+		r := image.Rect(3, 4, 8, 9)
+		blit(test.dst, src, r)
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 12; x++ {
+				p := image.Pt(x, y)
+				if !p.In(test.dst.Bounds()) {
+					continue
+				}
+				want := color.RGBAModel.Convert(color.Transparent)
+				if p.In(r) {
+					want = src.At(x, y)
+				}
+				if got := color.RGBAModel.Convert(test.dst.At(x, y)); got != color.RGBAModel.Convert(want) {
+					t.Errorf("%s: pixel %v = %v; wanted %v", test.name, p, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Buffered makes an Env whose draw functions draw onto an offscreen image. Draw functions sent
+// back to back make up a frame, and only complete frames are copied to the parent, so a child
+// that draws its background and its content in separate calls never shows the background alone.
+//
+// The offscreen image covers the Rectangle of the last Resize Event. Drawing outside of it has
+// no effect.
+func Buffered(parent Env) Env {
+	frames := make(chan func(draw.Image) image.Rectangle)
+	resizes := make(chan image.Rectangle)
+	done := make(chan bool)
+
+	go func() {
+		buf := image.NewRGBA(image.Rectangle{})
+		for {
+			select {
+			case r := <-resizes:
+				old := buf
+				buf = image.NewRGBA(r)
+				draw.Draw(buf, r, old, r.Min, draw.Src)
+			case d := <-frames:
+				damage := d(buf)
+			more:
+				for {
+					select {
+					case d := <-frames:
+						damage = damage.Union(d(buf))
+					default:
+						break more
+					}
+				}
+				damage = damage.Intersect(buf.Bounds())
+				if damage.Empty() {
+					continue
+				}
+				frame := image.NewRGBA(damage)
+				draw.Draw(frame, damage, buf, damage.Min, draw.Src)
+				select {
+				case parent.Draw() <- func(drw draw.Image) image.Rectangle {
+					draw.Draw(drw, damage, frame, damage.Min, draw.Src)
+					return damage
+				}:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return newEnv(redirectedEnv{parent, frames},
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				resizes <- resize.Rectangle
+			}
+			c <- e
+		},
+		send, // draw functions go to the frame buffer
+		func() {
+			close(done)
+		})
+}
+
+// redirectedEnv is an Env whose draw functions go to a different channel than the Env's own.
+type redirectedEnv struct {
+	Env
+	draw chan<- func(draw.Image) image.Rectangle
+}
+
+func (re redirectedEnv) Draw() chan<- func(draw.Image) image.Rectangle {
+	return re.draw
+}
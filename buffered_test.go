@@ -0,0 +1,40 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// Draw functions reach the parent through the offscreen image.
+func TestBuffered(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env := Buffered(root)
+	if _, ok := tryRecv(env.Events(), timeout); !ok {
+		t.Fatalf("no Resize received after %v", timeout)
+	}
+
+	go func() {
+		env.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, rect, image.White, image.Point{}, draw.Src)
+			return rect
+		}
+	}()
+	d, ok := tryRecv(root.drawOut, timeout)
+	if !ok {
+		t.Fatalf("no draw function received after %v", timeout)
+	}
+	img := image.NewRGBA(rect)
+	if r := (*d)(img); r != rect {
+		t.Errorf("changed %v; wanted %v", r, rect)
+	}
+	if got := img.RGBAAt(5, 5); got != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("pixel = %v; wanted white", got)
+	}
+}
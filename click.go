@@ -0,0 +1,96 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"time"
+)
+
+type (
+	// MoClick is an event that happens when a mouse button gets pressed and released
+	// without moving the mouse in between. Emitted by ClickDetector.
+	MoClick struct {
+		image.Point
+		Button Button
+	}
+
+	// MoDoubleClick is an event that happens on the second of two quick clicks on the same spot.
+	// It follows the MoClick of the second click. Emitted by ClickDetector.
+	MoDoubleClick struct {
+		image.Point
+		Button Button
+	}
+)
+
+func (mc MoClick) String() string { return fmt.Sprintf("mo/click/%d/%d/%s", mc.X, mc.Y, mc.Button) }
+func (md MoDoubleClick) String() string {
+	return fmt.Sprintf("mo/doubleclick/%d/%d/%s", md.X, md.Y, md.Button)
+}
+
+var _ Intercepter = ClickDetector{}
+
+// ClickDetector is an Intercepter that emits MoClick and MoDoubleClick events after the MoUp
+// events that complete them. All events are passed along.
+type ClickDetector struct {
+	// MaxDistance is how far, in pixels along each axis, the mouse may move between pressing
+	// and releasing a button, and between two clicks of a double-click. Defaults to 4.
+	MaxDistance int
+
+	// Interval is the maximum time between two clicks of a double-click. Defaults to 500ms.
+	Interval time.Duration
+}
+
+func (cd ClickDetector) Intercept(parent Env) Env {
+	if cd.MaxDistance <= 0 {
+		cd.MaxDistance = 4
+	}
+	if cd.Interval <= 0 {
+		cd.Interval = 500 * time.Millisecond
+	}
+
+	downs := make(map[Button]image.Point)
+	var (
+		lastClick     MoClick
+		lastClickTime time.Time
+	)
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			c <- e
+			switch e := e.(type) {
+			case MoDown:
+				downs[e.Button] = e.Point
+			case MoUp:
+				down, ok := downs[e.Button]
+				delete(downs, e.Button)
+				if !ok || !cd.near(down, e.Point) {
+					return
+				}
+				click := MoClick{e.Point, e.Button}
+				c <- click
+
+				now := time.Now()
+				if lastClick.Button == click.Button && cd.near(lastClick.Point, click.Point) &&
+					now.Sub(lastClickTime) <= cd.Interval {
+					c <- MoDoubleClick{click.Point, click.Button}
+					lastClick = MoClick{} // a third click starts over
+					return
+				}
+				lastClick, lastClickTime = click, now
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
+
+func (cd ClickDetector) near(a, b image.Point) bool {
+	d := a.Sub(b)
+	return abs(d.X) <= cd.MaxDistance && abs(d.Y) <= cd.MaxDistance
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
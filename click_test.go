@@ -0,0 +1,41 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestClickDetector(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 100))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env := ClickDetector{}.Intercept(root)
+
+	p, far := image.Pt(10, 10), image.Pt(50, 50)
+	input := []Event{
+		MoDown{p, ButtonLeft}, MoUp{p, ButtonLeft}, // click
+		MoDown{p, ButtonLeft}, MoUp{p, ButtonLeft}, // double-click
+		MoDown{p, ButtonLeft}, MoUp{far, ButtonLeft}, // no click: moved too far
+	}
+	expect := []Event{
+		Resize{image.Rect(0, 0, 100, 100)},
+		MoDown{p, ButtonLeft}, MoUp{p, ButtonLeft}, MoClick{p, ButtonLeft},
+		MoDown{p, ButtonLeft}, MoUp{p, ButtonLeft}, MoClick{p, ButtonLeft}, MoDoubleClick{p, ButtonLeft},
+		MoDown{p, ButtonLeft}, MoUp{far, ButtonLeft},
+	}
+
+	for _, e := range input {
+		root.events.Enqueue <- e
+	}
+	for _, want := range expect {
+		got, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Clip makes an Env whose draw functions can only change the pixels inside r, so an element
+// can't draw outside of e.g. its cell in a layout. The draw.Image passed to the draw functions
+// has r as its bounds (or the part of r inside the parent's image), and the Rectangles they
+// return are clipped to r as well.
+func Clip(parent Env, r image.Rectangle) Env {
+	return newEnv(parent,
+		send, // forward events un-modified
+		func(d func(draw.Image) image.Rectangle, c chan<- func(draw.Image) image.Rectangle) {
+			c <- func(drw draw.Image) image.Rectangle {
+				return d(clipImage(drw, r)).Intersect(r)
+			}
+		},
+		func() {})
+}
+
+// clipImage returns a view of the part of img inside r.
+// Images with a SubImage method, such as *image.RGBA, share their pixels with the view.
+func clipImage(img draw.Image, r image.Rectangle) draw.Image {
+	r = r.Intersect(img.Bounds())
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		if clipped, ok := sub.SubImage(r).(draw.Image); ok {
+			return clipped
+		}
+	}
+	return clippedImage{img, r}
+}
+
+// clippedImage is a view of the part of an image inside a Rectangle.
+type clippedImage struct {
+	draw.Image
+	r image.Rectangle
+}
+
+func (ci clippedImage) Bounds() image.Rectangle { return ci.r }
+
+func (ci clippedImage) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(ci.r) {
+		return color.Transparent
+	}
+	return ci.Image.At(x, y)
+}
+
+func (ci clippedImage) Set(x, y int, c color.Color) {
+	if image.Pt(x, y).In(ci.r) {
+		ci.Image.Set(x, y, c)
+	}
+}
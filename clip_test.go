@@ -0,0 +1,56 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// Draws outside the clip are discarded, and so is the damage reported outside of it.
+func TestClip(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 20, 20))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	clip := image.Rect(5, 5, 10, 10)
+	env := Clip(root, clip)
+
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	everywhere := func(drw draw.Image) image.Rectangle {
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 20; x++ {
+				drw.Set(x, y, red)
+			}
+		}
+		return image.Rect(0, 0, 20, 20)
+	}
+
+	for name, img := range map[string]draw.Image{
+		"RGBA":   image.NewRGBA(image.Rect(0, 0, 20, 20)),
+		"opaque": struct{ draw.Image }{image.NewRGBA(image.Rect(0, 0, 20, 20))}, // no SubImage
+	} {
+		if !trySend(env.Draw(), everywhere, timeout) {
+			t.Fatalf("%s: draw function not accepted after %v", name, timeout)
+		}
+		d, ok := tryRecv(root.drawOut, timeout)
+		if !ok {
+			t.Fatalf("%s: no draw function received after %v", name, timeout)
+		}
+		if r := (*d)(img); r != clip {
+			t.Errorf("%s: changed %v; wanted %v", name, r, clip)
+		}
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 20; x++ {
+				var want color.Color = color.RGBA{}
+				if image.Pt(x, y).In(clip) {
+					want = red
+				}
+				if got := img.At(x, y); got != want {
+					t.Fatalf("%s: pixel %d,%d = %v; wanted %v", name, x, y, got, want)
+				}
+			}
+		}
+	}
+}
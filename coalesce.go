@@ -0,0 +1,23 @@
+package gui
+
+// coalesce merges the motion Event next into prev, if they are of the same kind, for the merge
+// function of a lane queue. A MoMove replaces a pending MoMove right before it, and a MoScroll or
+// MoRelMove is added to a pending Event of the same kind and unit. Other Events are never merged
+// across, so the order of Events is kept.
+func coalesce(prev, next Event) (Event, bool) {
+	switch next := next.(type) {
+	case MoMove:
+		if _, ok := prev.(MoMove); ok {
+			return next, true
+		}
+	case MoScroll:
+		if prev, ok := prev.(MoScroll); ok && prev.Unit == next.Unit {
+			return MoScroll{prev.Add(next.Point), prev.DX + next.DX, prev.DY + next.DY, next.Unit}, true
+		}
+	case MoRelMove:
+		if prev, ok := prev.(MoRelMove); ok {
+			return MoRelMove{prev.DX + next.DX, prev.DY + next.DY}, true
+		}
+	}
+	return nil, false
+}
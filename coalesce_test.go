@@ -0,0 +1,42 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+// Consecutive motion Events are merged, others are not.
+func TestCoalesce(t *testing.T) {
+	enqueue, dequeue := newLaneQueue(func(Event) bool { return false }, coalesce)
+	defer close(enqueue)
+
+	events := []Event{
+		MoMove{image.Pt(1, 1)},
+		MoMove{image.Pt(2, 2)},
+		MoScroll{image.Pt(0, 1), 0, 1, ScrollLines},
+		MoScroll{image.Pt(0, 2), 0, 2.5, ScrollLines},
+		MoDown{image.Pt(2, 2), ButtonLeft},
+		MoMove{image.Pt(3, 3)},
+	}
+	for _, e := range events {
+		if !trySend(enqueue, e, timeout) {
+			t.Fatalf("queue did not accept %v after %v", e, timeout)
+		}
+	}
+
+	expect := []Event{
+		MoMove{image.Pt(2, 2)},
+		MoScroll{image.Pt(0, 3), 0, 3.5, ScrollLines},
+		MoDown{image.Pt(2, 2), ButtonLeft},
+		MoMove{image.Pt(3, 3)},
+	}
+	for _, want := range expect {
+		got, ok := tryRecv(dequeue, timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v", timeout)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+}
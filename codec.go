@@ -0,0 +1,118 @@
+package gui
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Registered Event types by name, and names by type.
+var (
+	eventTypes = make(map[string]reflect.Type)
+	eventNames = make(map[reflect.Type]string)
+)
+
+func init() {
+	for name, e := range map[string]Event{
+		"Resize":        Resize{},
+		"WiClose":       WiClose{},
+		"WiFocus":       WiFocus{},
+		"WiIconify":     WiIconify{},
+		"WiMaximize":    WiMaximize{},
+		"WiMove":        WiMove{},
+		"WiScale":       WiScale{},
+		"WiStall":       WiStall{},
+		"MoMove":        MoMove{},
+		"MoRelMove":     MoRelMove{},
+		"MoDown":        MoDown{},
+		"MoUp":          MoUp{},
+		"MoScroll":      MoScroll{},
+		"MoClick":       MoClick{},
+		"MoDoubleClick": MoDoubleClick{},
+		"KbType":        KbType{},
+		"KbPreedit":     KbPreedit{},
+		"KbDown":        KbDown{},
+		"KbUp":          KbUp{},
+		"KbRepeat":      KbRepeat{},
+		"TouchDown":     TouchDown{},
+		"TouchMove":     TouchMove{},
+		"TouchUp":       TouchUp{},
+		"GamepadButton": GamepadButton{},
+		"GamepadAxis":   GamepadAxis{},
+		"DragStart":     DragStart{},
+		"DragMove":      DragMove{},
+		"DragEnd":       DragEnd{},
+		"HoverEnter":    HoverEnter{},
+		"HoverLeave":    HoverLeave{},
+		"FocusGained":   FocusGained{},
+		"FocusLost":     FocusLost{},
+		"Tick":          Tick{},
+	} {
+		RegisterEvent("gui."+name, e)
+	}
+}
+
+// RegisterEvent makes the type of e known to EncodeEvent and DecodeEvent under the given name,
+// and registers it with encoding/gob, so that Events can be sent between processes, recorded,
+// and replayed. All Events of this package are registered already.
+//
+// Packages that define their own Events should register them in an init function, with
+// names prefixed by the package name. RegisterEvent panics if the name or type is registered
+// twice.
+func RegisterEvent(name string, e Event) {
+	t := reflect.TypeOf(e)
+	if _, dup := eventTypes[name]; dup {
+		panic(fmt.Sprintf("RegisterEvent: name %q registered twice", name))
+	}
+	if _, dup := eventNames[t]; dup {
+		panic(fmt.Sprintf("RegisterEvent: type %v registered twice", t))
+	}
+	eventTypes[name] = t
+	eventNames[t] = name
+	gob.RegisterName(name, e)
+}
+
+// wireEvent is the JSON encoding of an Event.
+type wireEvent struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// EncodeEvent encodes e as a JSON object holding its registered name and its fields, e.g.
+//
+//	{"type":"gui.MoDown","event":{"X":10,"Y":20,"Button":"left"}}
+func EncodeEvent(e Event) ([]byte, error) {
+	name, ok := eventNames[reflect.TypeOf(e)]
+	if !ok {
+		return nil, fmt.Errorf("EncodeEvent: unregistered Event type %T", e)
+	}
+	fields, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("EncodeEvent: %v", err)
+	}
+	return json.Marshal(wireEvent{name, fields})
+}
+
+// DecodeEvent decodes an Event encoded by EncodeEvent.
+func DecodeEvent(data []byte) (Event, error) {
+	var w wireEvent
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("DecodeEvent: %v", err)
+	}
+	t, ok := eventTypes[w.Type]
+	if !ok {
+		return nil, fmt.Errorf("DecodeEvent: unknown Event type %q", w.Type)
+	}
+	p := reflect.New(t)
+	if len(w.Event) > 0 {
+		if err := json.Unmarshal(w.Event, p.Interface()); err != nil {
+			return nil, fmt.Errorf("DecodeEvent: %s: %v", w.Type, err)
+		}
+	}
+	e, ok := p.Elem().Interface().(Event)
+	if !ok {
+		return nil, fmt.Errorf("DecodeEvent: %s is not an Event", w.Type)
+	}
+	return e, nil
+}
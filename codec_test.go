@@ -0,0 +1,62 @@
+package gui
+
+import (
+	"bytes"
+	"encoding/gob"
+	"image"
+	"testing"
+	"time"
+)
+
+var codecEvents = []Event{
+	Resize{image.Rect(1, 2, 3, 4)},
+	WiClose{},
+	WiStall{250 * time.Millisecond},
+	MoDown{image.Pt(10, 20), ButtonLeft},
+	MoScroll{image.Pt(0, 1), 0, 15, ScrollPixels},
+	KbDown{KeyA, "q", 38},
+	KbUp{KeyA, 38},
+	KbType{'é'},
+	DragMove{image.Pt(5, 6), image.Pt(1, 2), ButtonRight},
+	FocusGained{},
+}
+
+func TestEventJSON(t *testing.T) {
+	for _, want := range codecEvents {
+		data, err := EncodeEvent(want)
+		if err != nil {
+			t.Errorf("EncodeEvent(%v): %v", want, err)
+			continue
+		}
+		got, err := DecodeEvent(data)
+		if err != nil {
+			t.Errorf("DecodeEvent(%s): %v", data, err)
+		} else if got != want {
+			t.Errorf("decoded %v; wanted %v", got, want)
+		}
+	}
+
+	if _, err := EncodeEvent(dummyEvent{"foo"}); err == nil {
+		t.Errorf("EncodeEvent accepted an unregistered Event")
+	}
+	if _, err := DecodeEvent([]byte(`{"type":"gui.Nope","event":{}}`)); err == nil {
+		t.Errorf("DecodeEvent accepted an unknown type")
+	}
+}
+
+func TestEventGob(t *testing.T) {
+	var buf bytes.Buffer
+	enc, dec := gob.NewEncoder(&buf), gob.NewDecoder(&buf)
+	for _, want := range codecEvents {
+		if err := enc.Encode(&want); err != nil {
+			t.Fatalf("encoding %v: %v", want, err)
+		}
+		var got Event
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("decoding %v: %v", want, err)
+		}
+		if got != want {
+			t.Errorf("decoded %v; wanted %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package gui
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// ColorProfile describes an RGB color space, such as the one of a display, by the matrix
+// and tone reproduction curves of an ICC profile.
+type ColorProfile struct {
+	// Matrix converts linear RGB to CIE XYZ relative to the D50 white point.
+	// Its columns are the XYZ values of the red, green, and blue primaries.
+	Matrix [3][3]float64
+
+	// Curves convert encoded red, green, and blue values in [0, 1] to linear light.
+	// They must be monotonically increasing.
+	Curves [3]func(float64) float64
+}
+
+// SRGB is the sRGB color space. All drawing is assumed to be in sRGB.
+var SRGB = &ColorProfile{
+	Matrix: [3][3]float64{
+		{0.4360747, 0.3850649, 0.1430804},
+		{0.2225045, 0.7168786, 0.0606169},
+		{0.0139322, 0.0971045, 0.7141733},
+	},
+	Curves: [3]func(float64) float64{srgbToLinear, srgbToLinear, srgbToLinear},
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// ParseICC parses an ICC profile of the matrix/TRC kind, which is what displays are usually
+// described by. LUT-based profiles are not supported.
+func ParseICC(data []byte) (*ColorProfile, error) {
+	if len(data) < 132 || string(data[36:40]) != "acsp" {
+		return nil, fmt.Errorf("ParseICC: not an ICC profile")
+	}
+	if cs := string(data[16:20]); cs != "RGB " {
+		return nil, fmt.Errorf("ParseICC: unsupported color space %q", cs)
+	}
+
+	tags := make(map[string][]byte)
+	n := int(binary.BigEndian.Uint32(data[128:]))
+	for i := 0; i < n; i++ {
+		entry := 132 + 12*i
+		if entry+12 > len(data) {
+			return nil, fmt.Errorf("ParseICC: truncated tag table")
+		}
+		sig := string(data[entry : entry+4])
+		off := int(binary.BigEndian.Uint32(data[entry+4:]))
+		size := int(binary.BigEndian.Uint32(data[entry+8:]))
+		if off < 0 || size < 0 || off+size > len(data) {
+			return nil, fmt.Errorf("ParseICC: tag %q out of bounds", sig)
+		}
+		tags[sig] = data[off : off+size]
+	}
+
+	p := new(ColorProfile)
+	for i, sig := range []string{"rXYZ", "gXYZ", "bXYZ"} {
+		tag, ok := tags[sig]
+		if !ok || len(tag) < 20 || string(tag[:4]) != "XYZ " {
+			return nil, fmt.Errorf("ParseICC: missing or invalid %q tag", sig)
+		}
+		for j := 0; j < 3; j++ {
+			p.Matrix[j][i] = s15Fixed16(tag[8+4*j:])
+		}
+	}
+	for i, sig := range []string{"rTRC", "gTRC", "bTRC"} {
+		tag, ok := tags[sig]
+		if !ok {
+			return nil, fmt.Errorf("ParseICC: missing %q tag", sig)
+		}
+		curve, err := parseCurve(tag)
+		if err != nil {
+			return nil, fmt.Errorf("ParseICC: %q: %v", sig, err)
+		}
+		p.Curves[i] = curve
+	}
+	return p, nil
+}
+
+func s15Fixed16(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536
+}
+
+// parseCurve parses a 'curv' or 'para' tag.
+func parseCurve(tag []byte) (func(float64) float64, error) {
+	if len(tag) < 12 {
+		return nil, fmt.Errorf("truncated curve")
+	}
+	switch string(tag[:4]) {
+	case "curv":
+		n := int(binary.BigEndian.Uint32(tag[8:]))
+		if len(tag) < 12+2*n {
+			return nil, fmt.Errorf("truncated curve")
+		}
+		switch n {
+		case 0:
+			return func(v float64) float64 { return v }, nil
+		case 1:
+			g := float64(binary.BigEndian.Uint16(tag[12:])) / 256
+			return func(v float64) float64 { return math.Pow(v, g) }, nil
+		}
+		table := make([]float64, n)
+		for i := range table {
+			table[i] = float64(binary.BigEndian.Uint16(tag[12+2*i:])) / 65535
+		}
+		return func(v float64) float64 {
+			x := clampFloat(v, 0, 1) * float64(n-1)
+			i := int(x)
+			if i >= n-1 {
+				return table[n-1]
+			}
+			f := x - float64(i)
+			return table[i]*(1-f) + table[i+1]*f
+		}, nil
+
+	case "para":
+		kind := binary.BigEndian.Uint16(tag[8:])
+		nparams := map[uint16]int{0: 1, 1: 3, 2: 4, 3: 5, 4: 7}[kind]
+		if nparams == 0 {
+			return nil, fmt.Errorf("unknown parametric curve type %d", kind)
+		}
+		if len(tag) < 12+4*nparams {
+			return nil, fmt.Errorf("truncated curve")
+		}
+		var p [7]float64
+		for i := 0; i < nparams; i++ {
+			p[i] = s15Fixed16(tag[12+4*i:])
+		}
+		g, a, b, c, d, e, f := p[0], p[1], p[2], p[3], p[4], p[5], p[6]
+		switch kind {
+		case 0:
+			return func(x float64) float64 { return math.Pow(x, g) }, nil
+		case 1:
+			return func(x float64) float64 {
+				if x >= -b/a {
+					return math.Pow(a*x+b, g)
+				}
+				return 0
+			}, nil
+		case 2:
+			return func(x float64) float64 {
+				if x >= -b/a {
+					return math.Pow(a*x+b, g) + c
+				}
+				return c
+			}, nil
+		case 3:
+			return func(x float64) float64 {
+				if x >= d {
+					return math.Pow(a*x+b, g)
+				}
+				return c * x
+			}, nil
+		default:
+			return func(x float64) float64 {
+				if x >= d {
+					return math.Pow(a*x+b, g) + e
+				}
+				return c*x + f
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported curve type %q", tag[:4])
+}
+
+func clampFloat(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+// colorTransform converts sRGB pixels to another ColorProfile using lookup tables.
+type colorTransform struct {
+	decode8  [256]float32
+	decode16 []float32 // only allocated for 16-bit images
+	matrix   [3][3]float32
+	encode   [3][colorEncodeSize]uint16
+}
+
+const colorEncodeSize = 4096
+
+// newColorTransform returns a transform from sRGB to dst.
+func newColorTransform(dst *ColorProfile) (*colorTransform, error) {
+	inv, ok := invert3(dst.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("color profile matrix is not invertible")
+	}
+	t := new(colorTransform)
+	for i := range t.decode8 {
+		t.decode8[i] = float32(SRGB.Curves[0](float64(i) / 0xff))
+	}
+	m := mul3(inv, SRGB.Matrix)
+	for i := range m {
+		for j := range m[i] {
+			t.matrix[i][j] = float32(m[i][j])
+		}
+	}
+	for c := 0; c < 3; c++ {
+		curve := dst.Curves[c]
+		// Invert the monotonic curve by walking both axes at once.
+		v := 0
+		for i := 0; i < colorEncodeSize; i++ {
+			lin := float64(i) / (colorEncodeSize - 1)
+			for v < 0xffff && curve((float64(v)+0.5)/0xffff) < lin {
+				v++
+			}
+			t.encode[c][i] = uint16(v)
+		}
+	}
+	return t, nil
+}
+
+// apply converts the pixels of img in place. Alpha is ignored, the pixels are assumed to be opaque.
+func (t *colorTransform) apply(img draw.Image) {
+	switch img := img.(type) {
+	case *image.RGBA:
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			row := img.Pix[img.PixOffset(b.Min.X, y):img.PixOffset(b.Max.X, y)]
+			for i := 0; i+3 < len(row); i += 4 {
+				r, g, b := t.decode8[row[i]], t.decode8[row[i+1]], t.decode8[row[i+2]]
+				for c := 0; c < 3; c++ {
+					row[i+c] = uint8(t.convert(c, r, g, b) >> 8)
+				}
+			}
+		}
+	case *image.RGBA64:
+		if t.decode16 == nil {
+			t.decode16 = make([]float32, 0x10000)
+			for i := range t.decode16 {
+				t.decode16[i] = float32(SRGB.Curves[0](float64(i) / 0xffff))
+			}
+		}
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			row := img.Pix[img.PixOffset(b.Min.X, y):img.PixOffset(b.Max.X, y)]
+			for i := 0; i+7 < len(row); i += 8 {
+				r := t.decode16[binary.BigEndian.Uint16(row[i:])]
+				g := t.decode16[binary.BigEndian.Uint16(row[i+2:])]
+				b := t.decode16[binary.BigEndian.Uint16(row[i+4:])]
+				for c := 0; c < 3; c++ {
+					binary.BigEndian.PutUint16(row[i+2*c:], t.convert(c, r, g, b))
+				}
+			}
+		}
+	}
+}
+
+// convert returns the 16-bit value of channel c of the linear sRGB color r, g, b.
+func (t *colorTransform) convert(c int, r, g, b float32) uint16 {
+	v := t.matrix[c][0]*r + t.matrix[c][1]*g + t.matrix[c][2]*b
+	idx := int(v*(colorEncodeSize-1) + 0.5)
+	if idx < 0 {
+		idx = 0
+	} else if idx >= colorEncodeSize {
+		idx = colorEncodeSize - 1
+	}
+	return t.encode[c][idx]
+}
+
+func mul3(a, b [3][3]float64) (m [3][3]float64) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return m
+}
+
+func invert3(a [3][3]float64) (m [3][3]float64, ok bool) {
+	det := a[0][0]*(a[1][1]*a[2][2]-a[1][2]*a[2][1]) -
+		a[0][1]*(a[1][0]*a[2][2]-a[1][2]*a[2][0]) +
+		a[0][2]*(a[1][0]*a[2][1]-a[1][1]*a[2][0])
+	if det == 0 {
+		return m, false
+	}
+	m[0][0] = (a[1][1]*a[2][2] - a[1][2]*a[2][1]) / det
+	m[0][1] = (a[0][2]*a[2][1] - a[0][1]*a[2][2]) / det
+	m[0][2] = (a[0][1]*a[1][2] - a[0][2]*a[1][1]) / det
+	m[1][0] = (a[1][2]*a[2][0] - a[1][0]*a[2][2]) / det
+	m[1][1] = (a[0][0]*a[2][2] - a[0][2]*a[2][0]) / det
+	m[1][2] = (a[0][2]*a[1][0] - a[0][0]*a[1][2]) / det
+	m[2][0] = (a[1][0]*a[2][1] - a[1][1]*a[2][0]) / det
+	m[2][1] = (a[0][1]*a[2][0] - a[0][0]*a[2][1]) / det
+	m[2][2] = (a[0][0]*a[1][1] - a[0][1]*a[1][0]) / det
+	return m, true
+}
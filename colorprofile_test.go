@@ -0,0 +1,95 @@
+package gui
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// Converting sRGB to sRGB should not change the colors.
+func TestColorTransformIdentity(t *testing.T) {
+	xform, err := newColorTransform(SRGB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 256, 1))
+	for x := 0; x < 256; x++ {
+		img.SetRGBA(x, 0, color.RGBA{uint8(x), uint8(255 - x), uint8(x / 2), 0xff})
+	}
+	xform.apply(img)
+	for x := 0; x < 256; x++ {
+		got := img.RGBAAt(x, 0)
+		want := color.RGBA{uint8(x), uint8(255 - x), uint8(x / 2), 0xff}
+		if absDiff(got.R, want.R) > 1 || absDiff(got.G, want.G) > 1 || absDiff(got.B, want.B) > 1 {
+			t.Errorf("got %v; wanted %v", got, want)
+		}
+	}
+}
+
+func TestParseICC(t *testing.T) {
+	p, err := ParseICC(makeICC(SRGB.Matrix, 2.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range p.Matrix {
+		for j := range p.Matrix[i] {
+			if math.Abs(p.Matrix[i][j]-SRGB.Matrix[i][j]) > 1e-4 {
+				t.Errorf("Matrix[%d][%d] = %v; wanted %v", i, j, p.Matrix[i][j], SRGB.Matrix[i][j])
+			}
+		}
+	}
+	for c, curve := range p.Curves {
+		if got, want := curve(0.5), math.Pow(0.5, 2.2); math.Abs(got-want) > 1e-3 {
+			t.Errorf("Curves[%d](0.5) = %v; wanted %v", c, got, want)
+		}
+	}
+
+	if _, err := ParseICC([]byte("definitely not a profile")); err == nil {
+		t.Errorf("ParseICC accepted garbage")
+	}
+}
+
+// makeICC encodes a minimal matrix/TRC profile with a simple gamma curve.
+func makeICC(m [3][3]float64, gamma float64) []byte {
+	var tags [][]byte
+	var sigs []string
+	for i, sig := range []string{"rXYZ", "gXYZ", "bXYZ"} {
+		tag := append([]byte("XYZ "), 0, 0, 0, 0)
+		for j := 0; j < 3; j++ {
+			tag = binary.BigEndian.AppendUint32(tag, uint32(int32(math.Round(m[j][i]*65536))))
+		}
+		tags, sigs = append(tags, tag), append(sigs, sig)
+	}
+	for _, sig := range []string{"rTRC", "gTRC", "bTRC"} {
+		tag := append([]byte("curv"), 0, 0, 0, 0)
+		tag = binary.BigEndian.AppendUint32(tag, 1)
+		tag = binary.BigEndian.AppendUint16(tag, uint16(math.Round(gamma*256)))
+		tag = append(tag, 0, 0)
+		tags, sigs = append(tags, tag), append(sigs, sig)
+	}
+
+	data := make([]byte, 128)
+	copy(data[16:], "RGB ")
+	copy(data[36:], "acsp")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(tags)))
+	off := len(data) + 12*len(tags)
+	for i, tag := range tags {
+		data = append(data, sigs[i]...)
+		data = binary.BigEndian.AppendUint32(data, uint32(off))
+		data = binary.BigEndian.AppendUint32(data, uint32(len(tag)))
+		off += len(tag)
+	}
+	for _, tag := range tags {
+		data = append(data, tag...)
+	}
+	return data
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,105 @@
+package gui
+
+// Command is a reversible action, such as inserting text into a document.
+//
+// Do applies the action and Undo reverts it. Do may be called again after Undo to redo the action.
+type Command interface {
+	Do()
+	Undo()
+}
+
+// Coalescer can be implemented by a Command that is able to absorb a following Command,
+// e.g. typing consecutive characters can be merged into a single insertion so that one Undo
+// reverts the whole word.
+//
+// Coalesce is called on the most recent Command of a CommandStack with the Command that is about
+// to be pushed. The next Command has already been done when Coalesce is called. If Coalesce
+// returns true, the receiver has merged next into itself and next is discarded.
+type Coalescer interface {
+	Coalesce(next Command) bool
+}
+
+// CommandStack records done Commands so that they can be undone and redone.
+//
+// A CommandStack is not safe for concurrent use. It is meant to be owned by the goroutine that
+// handles the Events of an Env, like any other state of an element.
+type CommandStack struct {
+	done   []Command
+	undone []Command
+
+	// Limit is the maximum number of Commands that can be undone. Zero means unlimited.
+	Limit int
+
+	sealed bool
+}
+
+// Do does cmd and pushes it onto the stack, discarding all Commands that could have been redone.
+//
+// If the most recent Command implements Coalescer and accepts cmd, the two are merged.
+func (cs *CommandStack) Do(cmd Command) {
+	cmd.Do()
+	cs.undone = cs.undone[:0]
+
+	if n := len(cs.done); n > 0 && !cs.sealed {
+		if c, ok := cs.done[n-1].(Coalescer); ok && c.Coalesce(cmd) {
+			return
+		}
+	}
+	cs.sealed = false
+
+	cs.done = append(cs.done, cmd)
+	if cs.Limit > 0 && len(cs.done) > cs.Limit {
+		cs.done = append(cs.done[:0], cs.done[len(cs.done)-cs.Limit:]...)
+	}
+}
+
+// Undo reverts the most recent Command. It returns false if there is nothing to undo.
+func (cs *CommandStack) Undo() bool {
+	n := len(cs.done)
+	if n == 0 {
+		return false
+	}
+	cmd := cs.done[n-1]
+	cs.done = cs.done[:n-1]
+	cmd.Undo()
+	cs.undone = append(cs.undone, cmd)
+	cs.sealed = true
+	return true
+}
+
+// Redo does the most recently undone Command again. It returns false if there is nothing to redo.
+func (cs *CommandStack) Redo() bool {
+	n := len(cs.undone)
+	if n == 0 {
+		return false
+	}
+	cmd := cs.undone[n-1]
+	cs.undone = cs.undone[:n-1]
+	cmd.Do()
+	cs.done = append(cs.done, cmd)
+	cs.sealed = true
+	return true
+}
+
+// Seal stops the next Command from being coalesced with the most recent one,
+// e.g. when the caret of a text field is moved between two insertions.
+func (cs *CommandStack) Seal() {
+	cs.sealed = true
+}
+
+// CanUndo reports whether there is a Command to undo.
+func (cs *CommandStack) CanUndo() bool {
+	return len(cs.done) > 0
+}
+
+// CanRedo reports whether there is a Command to redo.
+func (cs *CommandStack) CanRedo() bool {
+	return len(cs.undone) > 0
+}
+
+// Clear forgets all Commands, e.g. after a document is saved and reloaded.
+func (cs *CommandStack) Clear() {
+	cs.done = nil
+	cs.undone = nil
+	cs.sealed = false
+}
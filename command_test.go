@@ -0,0 +1,90 @@
+package gui
+
+import "testing"
+
+// appendCmd appends s to *text. Consecutive appends coalesce.
+type appendCmd struct {
+	text *string
+	s    string
+}
+
+func (c *appendCmd) Do()   { *c.text += c.s }
+func (c *appendCmd) Undo() { *c.text = (*c.text)[:len(*c.text)-len(c.s)] }
+
+func (c *appendCmd) Coalesce(next Command) bool {
+	n, ok := next.(*appendCmd)
+	if !ok || n.text != c.text {
+		return false
+	}
+	c.s += n.s
+	return true
+}
+
+func TestCommandStackUndoRedo(t *testing.T) {
+	var text string
+	var cs CommandStack
+
+	for _, s := range []string{"foo", "bar"} {
+		cs.Do(&appendCmd{&text, s})
+		cs.Seal()
+	}
+	if text != "foobar" {
+		t.Fatalf("got %q; wanted %q", text, "foobar")
+	}
+
+	if !cs.Undo() || text != "foo" {
+		t.Errorf("got %q after undo; wanted %q", text, "foo")
+	}
+	if !cs.Redo() || text != "foobar" {
+		t.Errorf("got %q after redo; wanted %q", text, "foobar")
+	}
+	cs.Undo()
+	cs.Undo()
+	if text != "" {
+		t.Errorf("got %q after undoing everything; wanted %q", text, "")
+	}
+	if cs.Undo() {
+		t.Errorf("Undo succeeded on empty stack")
+	}
+
+	// Doing a new command discards the redo history.
+	cs.Do(&appendCmd{&text, "baz"})
+	if cs.CanRedo() {
+		t.Errorf("CanRedo after Do; wanted false")
+	}
+}
+
+func TestCommandStackCoalesce(t *testing.T) {
+	var text string
+	var cs CommandStack
+
+	for _, r := range "hello" {
+		cs.Do(&appendCmd{&text, string(r)})
+	}
+	cs.Seal()
+	cs.Do(&appendCmd{&text, " world"})
+
+	cs.Undo()
+	if text != "hello" {
+		t.Errorf("got %q after first undo; wanted %q", text, "hello")
+	}
+	cs.Undo()
+	if text != "" {
+		t.Errorf("got %q after second undo; wanted %q", text, "")
+	}
+}
+
+func TestCommandStackLimit(t *testing.T) {
+	var text string
+	cs := CommandStack{Limit: 2}
+
+	for _, s := range []string{"a", "b", "c"} {
+		cs.Do(&appendCmd{&text, s})
+		cs.Seal()
+	}
+	for cs.Undo() {
+	}
+	if text != "a" {
+		t.Errorf("got %q after undoing everything; wanted %q", text, "a")
+	}
+}
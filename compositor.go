@@ -0,0 +1,139 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"sync"
+)
+
+// Compositor multiplexes an Env into layers, like Mux, but each layer draws onto its own
+// offscreen image. The layers are composited with alpha into the parent Env in z-order, so
+// popups, tooltips, and drag previews can draw over their siblings without erasing them.
+//
+// Pixels not covered by any layer become transparent, so the bottom layer should usually
+// be opaque.
+type Compositor struct {
+	Mux
+	stack *layerStack
+}
+
+// NewCompositor makes a Compositor of the parent Env.
+func NewCompositor(parent Env) Compositor {
+	return Compositor{NewMux(parent), new(layerStack)}
+}
+
+// MakeLayer makes a layer drawn above all layers with a lower z, and above the layers with
+// the same z made before it. The whole layer is drawn with the given opacity, from 0 to 1, on
+// top of the alpha of its pixels.
+func (c Compositor) MakeLayer(z int, opacity float64) Env {
+	env, _ := c.makeLayer(z, opacity)
+	return env
+}
+
+func (c Compositor) makeLayer(z int, opacity float64) (Env, *layer) {
+	l := &layer{z: z}
+	if opacity < 1 {
+		l.mask = image.NewUniform(color.Alpha16{uint16(clampFloat(opacity, 0, 1) * 0xffff)})
+	}
+	c.stack.add(l)
+
+	muxEnv := c.MakeEnv()
+	env := newEnv(muxEnv,
+		send, // forward events un-modified
+		func(d func(draw.Image) image.Rectangle, ch chan<- func(draw.Image) image.Rectangle) {
+			ch <- func(drw draw.Image) image.Rectangle {
+				if l.img == nil || l.img.Bounds() != drw.Bounds() {
+					l.img = image.NewRGBA(drw.Bounds())
+					l.drawn = image.Rectangle{}
+				}
+				r := d(l.img)
+				l.drawn = l.drawn.Union(r)
+				return c.stack.composite(drw, r)
+			}
+		},
+		func() {
+			c.stack.remove(l)
+			// Uncover what is under the pixels of the layer.
+			muxEnv.Draw() <- func(drw draw.Image) image.Rectangle {
+				return c.stack.composite(drw, l.drawn)
+			}
+		})
+	return env, l
+}
+
+// layerStack is the list of layers of a Compositor, sorted by z.
+type layerStack struct {
+	mu     sync.Mutex
+	layers []*layer
+}
+
+// layer is only accessed from draw functions, which are executed one at a time.
+type layer struct {
+	z     int
+	mask  image.Image     // opacity of the layer; nil if opaque
+	img   *image.RGBA     // nil until the layer draws
+	drawn image.Rectangle // the area of img drawn since it was made
+}
+
+func (s *layerStack) add(l *layer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := sort.Search(len(s.layers), func(i int) bool { return s.layers[i].z > l.z })
+	s.layers = append(s.layers, nil)
+	copy(s.layers[i+1:], s.layers[i:])
+	s.layers[i] = l
+}
+
+func (s *layerStack) remove(l *layer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layers, _ = remove(l, s.layers)
+}
+
+// raise moves l above all other layers, taking the z of the top layer.
+func (s *layerStack) raise(l *layer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.layers, err = remove(l, s.layers); err != nil {
+		return
+	}
+	if len(s.layers) > 0 {
+		l.z = s.layers[len(s.layers)-1].z
+	}
+	s.layers = append(s.layers, l)
+}
+
+// lower moves l below all other layers, taking the z of the bottom layer.
+func (s *layerStack) lower(l *layer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.layers, err = remove(l, s.layers); err != nil {
+		return
+	}
+	if len(s.layers) > 0 {
+		l.z = s.layers[0].z
+	}
+	s.layers = append([]*layer{l}, s.layers...)
+}
+
+// composite redraws the area r of drw from all layers and returns the changed area.
+func (s *layerStack) composite(drw draw.Image, r image.Rectangle) image.Rectangle {
+	r = r.Intersect(drw.Bounds())
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	draw.Draw(drw, r, image.Transparent, image.Point{}, draw.Src)
+	for _, l := range s.layers {
+		if l.img == nil || l.img.Bounds() != drw.Bounds() {
+			continue // not drawn since the last resize
+		}
+		draw.DrawMask(drw, r, l.img, r.Min, l.mask, image.Point{}, draw.Over)
+	}
+	return r
+}
@@ -0,0 +1,58 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// The top layer is drawn over the bottom one, regardless of the order they draw in.
+func TestCompositor(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	comp := NewCompositor(root)
+	top := comp.MakeLayer(1, 1)
+	bottom := comp.MakeLayer(0, 1)
+
+	red, blue := color.RGBA{0xff, 0, 0, 0xff}, color.RGBA{0, 0, 0xff, 0xff}
+	fill := func(env Env, r image.Rectangle, col color.Color) {
+		env.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, r, image.NewUniform(col), image.Point{}, draw.Src)
+			return r
+		}
+	}
+
+	img := image.NewRGBA(rect)
+	apply := func() {
+		d, ok := tryRecv(root.drawOut, timeout)
+		if !ok {
+			t.Fatalf("no draw function received after %v", timeout)
+		}
+		(*d)(img)
+	}
+
+	go fill(top, image.Rect(0, 0, 5, 5), red)
+	apply()
+	go fill(bottom, rect, blue)
+	apply()
+
+	if got := img.RGBAAt(2, 2); got != red {
+		t.Errorf("top layer pixel = %v; wanted %v", got, red)
+	}
+	if got := img.RGBAAt(7, 7); got != blue {
+		t.Errorf("bottom layer pixel = %v; wanted %v", got, blue)
+	}
+
+	// Killing the top layer uncovers the bottom one.
+	top.Kill() <- true
+	apply()
+	<-top.Dead()
+	if got := img.RGBAAt(2, 2); got != blue {
+		t.Errorf("pixel under the killed layer = %v; wanted %v", got, blue)
+	}
+}
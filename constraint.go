@@ -0,0 +1,453 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"math"
+)
+
+var _ Scheme = Constraints{}
+
+// Attr is an attribute of the Rectangle of a child in a Constraints layout.
+type Attr int
+
+const (
+	AttrLeft Attr = iota
+	AttrTop
+	AttrRight
+	AttrBottom
+	AttrWidth
+	AttrHeight
+	AttrCenterX
+	AttrCenterY
+)
+
+// Expr is a linear expression of attributes of the children and the layout itself.
+// The zero value is the constant 0.
+type Expr struct {
+	terms    []exprTerm
+	constant float64
+}
+
+type exprTerm struct {
+	coef  float64
+	child int // -1 for the layout
+	attr  Attr
+}
+
+// Anchor returns the expression of an attribute of the i-th child.
+func Anchor(child int, attr Attr) Expr {
+	return Expr{terms: []exprTerm{{1, child, attr}}}
+}
+
+// ParentAnchor returns the expression of an attribute of the Rectangle of the whole layout.
+func ParentAnchor(attr Attr) Expr {
+	return Anchor(-1, attr)
+}
+
+// Const returns a constant expression.
+func Const(v float64) Expr {
+	return Expr{constant: v}
+}
+
+// Plus returns e + o.
+func (e Expr) Plus(o Expr) Expr {
+	terms := make([]exprTerm, 0, len(e.terms)+len(o.terms))
+	terms = append(append(terms, e.terms...), o.terms...)
+	return Expr{terms, e.constant + o.constant}
+}
+
+// Minus returns e - o.
+func (e Expr) Minus(o Expr) Expr {
+	return e.Plus(o.Times(-1))
+}
+
+// Times returns e multiplied by k.
+func (e Expr) Times(k float64) Expr {
+	terms := make([]exprTerm, len(e.terms))
+	for i, t := range e.terms {
+		terms[i] = exprTerm{t.coef * k, t.child, t.attr}
+	}
+	return Expr{terms, e.constant * k}
+}
+
+// Eq returns the constraint e == o.
+func (e Expr) Eq(o Expr) Constraint { return Constraint{e.Minus(o), relEq, Required} }
+
+// Le returns the constraint e <= o.
+func (e Expr) Le(o Expr) Constraint { return Constraint{e.Minus(o), relLe, Required} }
+
+// Ge returns the constraint e >= o.
+func (e Expr) Ge(o Expr) Constraint { return Constraint{e.Minus(o), relGe, Required} }
+
+type relation int
+
+const (
+	relEq relation = iota
+	relLe
+	relGe
+)
+
+// Strength is the priority of a Constraint. When constraints conflict, the ones with a higher
+// Strength win. Required constraints must always hold.
+type Strength float64
+
+const (
+	Required Strength = 0
+	Weak     Strength = 1
+	Medium   Strength = 1e3
+	Strong   Strength = 1e6
+)
+
+// hint is the strength of the implicit constraints that make the children fill the layout when
+// nothing else says where they go.
+const hint Strength = 1e-3
+
+// Constraint is a linear equation or inequality between attributes, such as
+//
+//	gui.Anchor(0, gui.AttrRight).Plus(gui.Const(8)).Eq(gui.Anchor(1, gui.AttrLeft))
+//	gui.Anchor(0, gui.AttrWidth).Ge(gui.Const(120))
+type Constraint struct {
+	expr     Expr // expr rel 0
+	rel      relation
+	strength Strength
+}
+
+// WithStrength returns c with the given Strength. Constraints are Required by default.
+func (c Constraint) WithStrength(s Strength) Constraint {
+	c.strength = s
+	return c
+}
+
+// Constraints is a Scheme where the Rectangles of the children are given by Constraints between
+// their attributes and those of the layout, solved on each Resize like in Cassowary. It expresses
+// layouts the fixed Schemes can't.
+//
+// Children are stretched over the layout as far as the constraints allow. If the Required
+// constraints can't be satisfied, all children get empty Rectangles.
+type Constraints struct {
+	// Children is the number of children.
+	Children int
+	Rules    []Constraint
+
+	// Background is the color of the space not covered by the children. Defaults to black.
+	Background color.Color
+}
+
+func (cs Constraints) redraw(drw draw.Image, bounds image.Rectangle) {
+	col := cs.Background
+	if col == nil {
+		col = color.Black
+	}
+	draw.Draw(drw, bounds, image.NewUniform(col), image.ZP, draw.Src)
+}
+
+func (cs Constraints) Intercept(env Env) Env {
+	return RedrawIntercepter{cs.redraw}.Intercept(env)
+}
+
+func (cs Constraints) Partition(bounds image.Rectangle) []image.Rectangle {
+	rules := append([]Constraint(nil), cs.Rules...)
+	for i := 0; i < cs.Children; i++ {
+		rules = append(rules,
+			Anchor(i, AttrWidth).Ge(Const(0)),
+			Anchor(i, AttrHeight).Ge(Const(0)),
+		)
+		for _, attr := range []Attr{AttrLeft, AttrTop, AttrRight, AttrBottom} {
+			rules = append(rules, Anchor(i, attr).Eq(ParentAnchor(attr)).WithStrength(hint))
+		}
+	}
+
+	// Each child has 4 variables: left, top, right, and bottom. They may be negative, so each is
+	// the difference of two non-negative LP variables. Non-required constraints get error
+	// variables, whose weighted sum is minimized.
+	nvars := 8 * cs.Children
+	for _, r := range rules {
+		if r.strength != Required {
+			nvars++
+			if r.rel == relEq {
+				nvars++
+			}
+		}
+	}
+	cost := make([]float64, nvars)
+	var rows []lpRow
+	nextErr := 8 * cs.Children
+	for _, r := range rules {
+		row := lpRow{a: make([]float64, nvars), rel: r.rel, b: -r.expr.constant}
+		for _, t := range r.expr.terms {
+			if t.child < 0 {
+				row.b -= t.coef * parentAttr(bounds, t.attr)
+				continue
+			}
+			if t.child >= cs.Children {
+				log.Println("Constraints: child index out of range")
+				continue
+			}
+			for _, v := range attrVars(t.attr) {
+				j := 2 * (4*t.child + v.side)
+				row.a[j] += t.coef * v.coef
+				row.a[j+1] -= t.coef * v.coef
+			}
+		}
+		if r.strength != Required {
+			w := float64(r.strength)
+			switch r.rel {
+			case relEq:
+				row.a[nextErr], row.a[nextErr+1] = -1, 1
+				cost[nextErr], cost[nextErr+1] = w, w
+				nextErr += 2
+			case relLe:
+				row.a[nextErr] = -1
+				cost[nextErr] = w
+				nextErr++
+			case relGe:
+				row.a[nextErr] = 1
+				cost[nextErr] = w
+				nextErr++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	ret := make([]image.Rectangle, cs.Children)
+	x, ok := simplex(cost, rows)
+	if !ok {
+		log.Println("Constraints: required constraints can't be satisfied")
+		return ret
+	}
+	val := func(child, side int) int {
+		j := 2 * (4*child + side)
+		return int(math.Round(x[j] - x[j+1]))
+	}
+	for i := range ret {
+		ret[i] = image.Rectangle{
+			image.Pt(val(i, 0), val(i, 1)),
+			image.Pt(val(i, 2), val(i, 3)),
+		}
+	}
+	return ret
+}
+
+// attrVars expresses an attribute in terms of the sides of a Rectangle:
+// 0 left, 1 top, 2 right, 3 bottom.
+func attrVars(attr Attr) []struct {
+	side int
+	coef float64
+} {
+	type v = struct {
+		side int
+		coef float64
+	}
+	switch attr {
+	case AttrLeft:
+		return []v{{0, 1}}
+	case AttrTop:
+		return []v{{1, 1}}
+	case AttrRight:
+		return []v{{2, 1}}
+	case AttrBottom:
+		return []v{{3, 1}}
+	case AttrWidth:
+		return []v{{2, 1}, {0, -1}}
+	case AttrHeight:
+		return []v{{3, 1}, {1, -1}}
+	case AttrCenterX:
+		return []v{{0, 0.5}, {2, 0.5}}
+	case AttrCenterY:
+		return []v{{1, 0.5}, {3, 0.5}}
+	}
+	return nil
+}
+
+func parentAttr(r image.Rectangle, attr Attr) float64 {
+	switch attr {
+	case AttrLeft:
+		return float64(r.Min.X)
+	case AttrTop:
+		return float64(r.Min.Y)
+	case AttrRight:
+		return float64(r.Max.X)
+	case AttrBottom:
+		return float64(r.Max.Y)
+	case AttrWidth:
+		return float64(r.Dx())
+	case AttrHeight:
+		return float64(r.Dy())
+	case AttrCenterX:
+		return float64(r.Min.X+r.Max.X) / 2
+	case AttrCenterY:
+		return float64(r.Min.Y+r.Max.Y) / 2
+	}
+	return 0
+}
+
+// lpRow is the linear constraint a·x rel b of a linear program.
+type lpRow struct {
+	a   []float64
+	rel relation
+	b   float64
+}
+
+const lpEpsilon = 1e-9
+
+// simplex minimizes cost·x subject to rows and x >= 0 with the two-phase simplex method, using
+// Bland's rule against cycling. It returns false if the rows can't be satisfied or the cost is
+// unbounded.
+func simplex(cost []float64, rows []lpRow) ([]float64, bool) {
+	n, m := len(cost), len(rows)
+
+	// Make all right-hand sides non-negative.
+	rows = append([]lpRow(nil), rows...)
+	for i, r := range rows {
+		if r.b < 0 {
+			a := make([]float64, n)
+			for j := range a {
+				a[j] = -r.a[j]
+			}
+			rel := r.rel
+			switch rel {
+			case relLe:
+				rel = relGe
+			case relGe:
+				rel = relLe
+			}
+			rows[i] = lpRow{a, rel, -r.b}
+		}
+	}
+
+	// Columns: variables, slacks, artificials, right-hand side.
+	nslack, nart := 0, 0
+	for _, r := range rows {
+		if r.rel != relEq {
+			nslack++
+		}
+		if r.rel != relLe {
+			nart++
+		}
+	}
+	cols := n + nslack + nart
+	rhs := cols
+	tab := make([][]float64, m)
+	basis := make([]int, m)
+	slack, art := n, n+nslack
+	for i, r := range rows {
+		tab[i] = make([]float64, cols+1)
+		copy(tab[i], r.a)
+		tab[i][rhs] = r.b
+		switch r.rel {
+		case relLe:
+			tab[i][slack] = 1
+			basis[i] = slack
+			slack++
+		case relGe:
+			tab[i][slack] = -1
+			slack++
+			fallthrough
+		case relEq:
+			tab[i][art] = 1
+			basis[i] = art
+			art++
+		}
+	}
+
+	pivot := func(obj []float64, row, col int) {
+		p := tab[row][col]
+		for j := range tab[row] {
+			tab[row][j] /= p
+		}
+		eliminate := func(r []float64) {
+			if f := r[col]; f != 0 {
+				for j := range r {
+					r[j] -= f * tab[row][j]
+				}
+			}
+		}
+		for i := range tab {
+			if i != row {
+				eliminate(tab[i])
+			}
+		}
+		if obj != nil {
+			eliminate(obj)
+		}
+		basis[row] = col
+	}
+
+	// optimize minimizes the objective with the given costs over the allowed columns.
+	optimize := func(c []float64, allowed int) ([]float64, bool) {
+		obj := make([]float64, cols+1)
+		copy(obj, c)
+		for i, b := range basis {
+			if f := obj[b]; f != 0 {
+				for j := range obj {
+					obj[j] -= f * tab[i][j]
+				}
+			}
+		}
+		for {
+			col := -1
+			for j := 0; j < allowed; j++ {
+				if obj[j] < -lpEpsilon {
+					col = j
+					break
+				}
+			}
+			if col < 0 {
+				return obj, true
+			}
+			row := -1
+			var best float64
+			for i := range tab {
+				if tab[i][col] <= lpEpsilon {
+					continue
+				}
+				ratio := tab[i][rhs] / tab[i][col]
+				if row < 0 || ratio < best-lpEpsilon || (ratio < best+lpEpsilon && basis[i] < basis[row]) {
+					row, best = i, ratio
+				}
+			}
+			if row < 0 {
+				return nil, false // unbounded
+			}
+			pivot(obj, row, col)
+		}
+	}
+
+	// Phase 1: find a feasible solution by driving the artificials to zero.
+	c := make([]float64, cols)
+	for j := n + nslack; j < cols; j++ {
+		c[j] = 1
+	}
+	obj, _ := optimize(c, cols)
+	if -obj[rhs] > 1e-6 {
+		return nil, false
+	}
+	for i, b := range basis {
+		if b < n+nslack {
+			continue
+		}
+		for j := 0; j < n+nslack; j++ {
+			if math.Abs(tab[i][j]) > lpEpsilon {
+				pivot(nil, i, j)
+				break
+			}
+		}
+	}
+
+	// Phase 2: optimize the real cost without the artificials.
+	c = make([]float64, cols)
+	copy(c, cost)
+	if _, ok := optimize(c, n+nslack); !ok {
+		return nil, false
+	}
+	x := make([]float64, n)
+	for i, b := range basis {
+		if b < n {
+			x[b] = tab[i][rhs]
+		}
+	}
+	return x, true
+}
@@ -0,0 +1,55 @@
+package gui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestConstraintsPartition(t *testing.T) {
+	cs := Constraints{
+		Children: 2,
+		Rules: []Constraint{
+			// A sidebar at least 30 pixels wide, preferably 20, and a content area to its right.
+			Anchor(0, AttrWidth).Ge(Const(30)),
+			Anchor(0, AttrWidth).Eq(Const(20)).WithStrength(Strong),
+			Anchor(0, AttrRight).Plus(Const(8)).Eq(Anchor(1, AttrLeft)),
+			// The content is centered vertically and 40 pixels high.
+			Anchor(1, AttrHeight).Eq(Const(40)),
+			Anchor(1, AttrCenterY).Eq(ParentAnchor(AttrCenterY)),
+		},
+	}
+	got := cs.Partition(image.Rect(10, 0, 210, 100))
+	want := []image.Rectangle{image.Rect(10, 0, 40, 100), image.Rect(48, 30, 210, 70)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// Conflicting required constraints can't be solved.
+	cs.Rules = append(cs.Rules, Anchor(0, AttrWidth).Le(Const(10)))
+	if got := cs.Partition(image.Rect(0, 0, 100, 100)); !got[0].Empty() {
+		t.Errorf("received %v; wanted empty Rectangles", got)
+	}
+}
+
+func TestSimplex(t *testing.T) {
+	// Minimize -x - y subject to x + 2y <= 4, 3x + y <= 6, x >= 0.5.
+	x, ok := simplex([]float64{-1, -1}, []lpRow{
+		{[]float64{1, 2}, relLe, 4},
+		{[]float64{3, 1}, relLe, 6},
+		{[]float64{1, 0}, relGe, 0.5},
+	})
+	if !ok {
+		t.Fatalf("no solution found")
+	}
+	if absDiff64(x[0], 1.6) > 1e-6 || absDiff64(x[1], 1.2) > 1e-6 {
+		t.Errorf("received %v; wanted [1.6 1.2]", x)
+	}
+}
+
+func absDiff64(a, b float64) float64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
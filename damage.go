@@ -0,0 +1,55 @@
+package gui
+
+import "image"
+
+// maxDamage is the most Rectangles a damageList keeps before merging ones that don't overlap.
+const maxDamage = 16
+
+// damageList is a list of changed areas, so that small changes far apart can be flushed
+// separately instead of as one large Rectangle covering both.
+type damageList []image.Rectangle
+
+// add adds r to the list, merging it with the Rectangles it overlaps. If the list is full,
+// the two Rectangles whose union is the smallest are merged.
+func (dl *damageList) add(r image.Rectangle) {
+	if r.Empty() {
+		return
+	}
+	rects := *dl
+	for merged := true; merged; {
+		merged = false
+		for i, other := range rects {
+			if other.Overlaps(r) {
+				r = r.Union(other)
+				rects = append(rects[:i], rects[i+1:]...)
+				merged = true
+				break
+			}
+		}
+	}
+	rects = append(rects, r)
+
+	if len(rects) > maxDamage {
+		bi, bj, best := 0, 1, -1
+		for i := range rects {
+			for j := i + 1; j < len(rects); j++ {
+				u := rects[i].Union(rects[j])
+				if waste := area(u) - area(rects[i]) - area(rects[j]); best < 0 || waste < best {
+					bi, bj, best = i, j, waste
+				}
+			}
+		}
+		u := rects[bi].Union(rects[bj])
+		rects = append(rects[:bj], rects[bj+1:]...)
+		rects[bi] = image.Rectangle{}
+		rects = append(rects[:bi], rects[bi+1:]...)
+		*dl = rects
+		dl.add(u) // the union may overlap others now
+		return
+	}
+	*dl = rects
+}
+
+func area(r image.Rectangle) int {
+	return r.Dx() * r.Dy()
+}
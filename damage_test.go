@@ -0,0 +1,29 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDamageList(t *testing.T) {
+	var dl damageList
+	dl.add(image.Rect(0, 0, 10, 10))
+	dl.add(image.Rect(990, 990, 1000, 1000))
+	if len(dl) != 2 {
+		t.Fatalf("far apart Rectangles merged into %v", dl)
+	}
+
+	// Bridging both merges all three.
+	dl.add(image.Rect(5, 5, 995, 995))
+	if len(dl) != 1 || dl[0] != image.Rect(0, 0, 1000, 1000) {
+		t.Errorf("got %v; wanted one Rectangle covering everything", dl)
+	}
+
+	dl = nil
+	for i := 0; i < 2*maxDamage; i++ {
+		dl.add(image.Rect(100*i, 0, 100*i+10, 10))
+	}
+	if len(dl) > maxDamage {
+		t.Errorf("list grew to %d Rectangles; limit is %d", len(dl), maxDamage)
+	}
+}
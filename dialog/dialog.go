@@ -0,0 +1,21 @@
+// Package dialog shows native message boxes.
+//
+// The dialogs don't need a window, so they can be used to report errors that prevent
+// the window from being created. They block until the user dismisses them.
+package dialog
+
+import "errors"
+
+// ErrUnsupported is returned when native dialogs are not available on the system.
+var ErrUnsupported = errors.New("dialog: native dialogs not available")
+
+// Message shows a message box with an OK button.
+func Message(title, text string) error {
+	return message(title, text)
+}
+
+// Confirm shows a message box asking the user to confirm or cancel.
+// It returns true if the user confirmed.
+func Confirm(title, text string) (bool, error) {
+	return confirm(title, text)
+}
@@ -0,0 +1,35 @@
+package dialog
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func message(title, text string) error {
+	_, err := display(title, text, `buttons {"OK"} default button "OK" with icon note`)
+	return err
+}
+
+func confirm(title, text string) (bool, error) {
+	return display(title, text, `buttons {"Cancel", "OK"} default button "OK" cancel button "Cancel" with icon caution`)
+}
+
+// display shows an AppleScript dialog. It returns false if the dialog was cancelled.
+func display(title, text, buttons string) (bool, error) {
+	script := fmt.Sprintf("display dialog %s with title %s %s", quote(text), quote(title), buttons)
+	err := exec.Command("osascript", "-e", script).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil // user cancelled
+	}
+	return err == nil, err
+}
+
+// quote makes an AppleScript string literal.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,52 @@
+package dialog
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// Linux has no native dialogs, so one of the common dialog programs is used,
+// whichever is installed.
+
+func message(title, text string) error {
+	switch {
+	case installed("zenity"):
+		_, err := run("zenity", "--info", "--title", title, "--text", text)
+		return err
+	case installed("kdialog"):
+		_, err := run("kdialog", "--title", title, "--msgbox", text)
+		return err
+	case installed("xmessage"):
+		_, err := run("xmessage", "-center", "-title", title, text)
+		return err
+	}
+	return ErrUnsupported
+}
+
+func confirm(title, text string) (bool, error) {
+	switch {
+	case installed("zenity"):
+		return run("zenity", "--question", "--title", title, "--text", text)
+	case installed("kdialog"):
+		return run("kdialog", "--title", title, "--yesno", text)
+	case installed("xmessage"):
+		return run("xmessage", "-center", "-title", title, "-buttons", "OK:0,Cancel:1", text)
+	}
+	return false, ErrUnsupported
+}
+
+func installed(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// run runs the dialog program, returning true if it exited with status 0
+// and false if it exited with status 1, which means the dialog was cancelled.
+func run(name string, args ...string) (bool, error) {
+	err := exec.Command(name, args...).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package dialog
+
+func message(title, text string) error {
+	return ErrUnsupported
+}
+
+func confirm(title, text string) (bool, error) {
+	return false, ErrUnsupported
+}
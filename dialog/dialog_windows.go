@@ -0,0 +1,45 @@
+package dialog
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var messageBoxW = syscall.NewLazyDLL("user32.dll").NewProc("MessageBoxW")
+
+const (
+	mbOK              = 0x00000000
+	mbOKCancel        = 0x00000001
+	mbIconQuestion    = 0x00000020
+	mbIconInformation = 0x00000040
+	idOK              = 1
+)
+
+func message(title, text string) error {
+	_, err := messageBox(title, text, mbOK|mbIconInformation)
+	return err
+}
+
+func confirm(title, text string) (bool, error) {
+	ret, err := messageBox(title, text, mbOKCancel|mbIconQuestion)
+	return ret == idOK, err
+}
+
+func messageBox(title, text string, flags uintptr) (uintptr, error) {
+	if err := messageBoxW.Find(); err != nil {
+		return 0, ErrUnsupported
+	}
+	t, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return 0, err
+	}
+	m, err := syscall.UTF16PtrFromString(text)
+	if err != nil {
+		return 0, err
+	}
+	ret, _, err := messageBoxW.Call(0, uintptr(unsafe.Pointer(m)), uintptr(unsafe.Pointer(t)), flags)
+	if ret == 0 {
+		return 0, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,102 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+)
+
+type (
+	// DragStart is an event that happens when the mouse moves far enough with a button held down.
+	// Origin is where the button was pressed. Emitted by DragIntercepter.
+	DragStart struct {
+		image.Point
+		Origin image.Point
+		Button Button
+	}
+
+	// DragMove is an event that happens when the mouse moves during a drag.
+	DragMove struct {
+		image.Point
+		Origin image.Point
+		Button Button
+	}
+
+	// DragEnd is an event that happens when the button that started a drag is released.
+	DragEnd struct {
+		image.Point
+		Origin image.Point
+		Button Button
+	}
+)
+
+func (ds DragStart) String() string {
+	return fmt.Sprintf("drag/start/%d/%d/%d/%d/%s", ds.Origin.X, ds.Origin.Y, ds.X, ds.Y, ds.Button)
+}
+
+func (dm DragMove) String() string {
+	return fmt.Sprintf("drag/move/%d/%d/%d/%d/%s", dm.Origin.X, dm.Origin.Y, dm.X, dm.Y, dm.Button)
+}
+
+func (de DragEnd) String() string {
+	return fmt.Sprintf("drag/end/%d/%d/%d/%d/%s", de.Origin.X, de.Origin.Y, de.X, de.Y, de.Button)
+}
+
+var _ Intercepter = DragIntercepter{}
+
+// DragIntercepter is an Intercepter that turns sequences of MoDown, MoMove, and MoUp events into
+// DragStart, DragMove, and DragEnd events. Only one drag happens at a time, started by the first
+// pressed button. All events are passed along.
+type DragIntercepter struct {
+	// Threshold is how far, in pixels along either axis, the mouse must move from the origin
+	// before a drag starts, so that clicks don't become drags. Defaults to 4.
+	Threshold int
+}
+
+func (di DragIntercepter) Intercept(parent Env) Env {
+	if di.Threshold <= 0 {
+		di.Threshold = 4
+	}
+
+	var (
+		pressed  bool
+		dragging bool
+		origin   image.Point
+		button   Button
+	)
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			c <- e
+			switch e := e.(type) {
+			case MoDown:
+				if !pressed {
+					pressed, dragging = true, false
+					origin, button = e.Point, e.Button
+				}
+			case MoMove:
+				if !pressed {
+					return
+				}
+				if !dragging {
+					d := e.Point.Sub(origin)
+					if abs(d.X) < di.Threshold && abs(d.Y) < di.Threshold {
+						return
+					}
+					dragging = true
+					c <- DragStart{e.Point, origin, button}
+					return
+				}
+				c <- DragMove{e.Point, origin, button}
+			case MoUp:
+				if !pressed || e.Button != button {
+					return
+				}
+				if dragging {
+					c <- DragEnd{e.Point, origin, button}
+				}
+				pressed, dragging = false, false
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
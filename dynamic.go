@@ -0,0 +1,201 @@
+package gui
+
+import (
+	"image"
+	"sync"
+)
+
+// DynamicLayout is a layout whose children are added and removed while it runs, so lists and
+// dashboards don't have to rebuild the whole layout tree when their contents change.
+type DynamicLayout struct {
+	Killable
+
+	scheme func(n int) Scheme
+
+	add    chan chan Env
+	remove chan removal
+	done   chan struct{} // closed when the layout dies
+
+	mu     sync.Mutex
+	bounds image.Rectangle // last Resize of the layout
+	inject chan<- Event
+	closed bool
+}
+
+type removal struct {
+	child Env
+	found chan Env // receives the mux Env of the child, or nil
+}
+
+// dynamicChild is a child of a DynamicLayout.
+type dynamicChild struct {
+	env    Env // given to the user
+	muxEnv Env
+	inject chan<- Event // delivers the Resize events of the child
+}
+
+// newDynamicChild makes a child of mux that only gets the Resize Events sent to its inject channel,
+// not those of the Mux.
+func newDynamicChild(mux Mux) dynamicChild {
+	muxEnv := mux.MakeEnv()
+	noResize := newEnv(muxEnv,
+		func(e Event, c chan<- Event) {
+			if _, ok := e.(Resize); !ok {
+				c <- e
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+	env, inject := NewInjector(noResize)
+	return dynamicChild{env, muxEnv, inject}
+}
+
+// NewDynamicLayout makes a layout of the parent Env without children. Children are added with Add
+// and removed with Remove, and each time the layout is re-partitioned and the children get fresh
+// Resize events.
+//
+// The scheme function returns the Scheme for n children, e.g.
+//
+//	func(n int) gui.Scheme { return gui.Grid{Rows: []int{n}} }
+//
+// The Intercepter of the Scheme for 0 children is used for the whole life of the layout, and
+// Partition is never called with 0 children. If the Scheme is Routed, the children only get the
+// mouse Events that happen over them.
+//
+// Killing the returned layout kills all of the children.
+func NewDynamicLayout(parent Env, scheme func(n int) Scheme) *DynamicLayout {
+	env, inject := NewInjector(parent)
+	dl := &DynamicLayout{
+		Killable: env,
+		scheme:   scheme,
+		add:      make(chan chan Env),
+		remove:   make(chan removal),
+		done:     make(chan struct{}),
+		inject:   inject,
+	}
+
+	top := newEnv(env,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				dl.mu.Lock()
+				dl.bounds = resize.Rectangle
+				dl.mu.Unlock()
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+
+	intercepter := scheme(0).Intercept(top)
+	resizeSniffer, resizes := newSniffer(intercepter, func(e Event) (r image.Rectangle, ok bool) {
+		if resize, ok := e.(Resize); ok {
+			return resize.Rectangle, true
+		}
+		return image.Rectangle{}, false
+	})
+	mux := NewMux(resizeSniffer)
+
+	go dl.run(mux, resizes)
+	return dl
+}
+
+func (dl *DynamicLayout) run(mux Mux, resizes <-chan image.Rectangle) {
+	var (
+		children []dynamicChild
+		bounds   image.Rectangle
+		sized    bool
+	)
+	defer func() {
+		for _, child := range children {
+			close(child.inject)
+		}
+		dl.mu.Lock()
+		dl.closed = true
+		close(dl.inject)
+		dl.mu.Unlock()
+		close(dl.done)
+	}()
+
+	for {
+		select {
+		case rect, ok := <-resizes:
+			if !ok {
+				return
+			}
+			bounds, sized = rect, true
+			if len(children) == 0 {
+				continue
+			}
+			rects := dl.scheme(len(children)).Partition(bounds)
+			for i, child := range children {
+				var r image.Rectangle
+				if i < len(rects) {
+					r = rects[i]
+				}
+				child.inject <- Resize{r}
+			}
+
+		case reply := <-dl.add:
+			child := newDynamicChild(mux)
+			if _, ok := dl.scheme(0).(Routed); ok {
+				child.env = PointerRouter{}.Intercept(child.env)
+			}
+			children = append(children, child)
+			reply <- child.env
+			if sized {
+				dl.repartition()
+			}
+
+		case rm := <-dl.remove:
+			var found Env
+			for i, child := range children {
+				if child.env == rm.child {
+					found = child.muxEnv
+					close(child.inject)
+					children = append(children[:i], children[i+1:]...)
+					break
+				}
+			}
+			rm.found <- found
+			if found != nil && sized {
+				dl.repartition()
+			}
+		}
+	}
+}
+
+// repartition sends the last Resize through the layout again, so that the Intercepter repaints
+// and the children are partitioned anew.
+func (dl *DynamicLayout) repartition() {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	if !dl.closed {
+		dl.inject <- Resize{dl.bounds}
+	}
+}
+
+// Add makes a new child Env after all the others. It returns nil if the layout is dead.
+func (dl *DynamicLayout) Add() Env {
+	reply := make(chan Env, 1)
+	select {
+	case dl.add <- reply:
+		return <-reply
+	case <-dl.done:
+		return nil
+	}
+}
+
+// Remove kills a child Env made by Add and waits until it dies.
+// It does nothing if child is not a child of the layout.
+func (dl *DynamicLayout) Remove(child Env) {
+	rm := removal{child, make(chan Env, 1)}
+	select {
+	case dl.remove <- rm:
+	case <-dl.done:
+		return
+	}
+	if muxEnv := <-rm.found; muxEnv != nil {
+		muxEnv.Kill() <- true
+		<-muxEnv.Dead()
+	}
+}
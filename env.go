@@ -56,6 +56,30 @@ func newEnv(parent Env,
 	shutdown func(),
 ) Env {
 	events := share.NewQueue[Event]()
+	return newQueuedEnv(parent, events.Enqueue, events.Dequeue, filterEvents, filterDraws, shutdown)
+}
+
+// newQueuedEnv is like newEnv, but the Events() channel of the Env is the dequeue end of
+// a custom queue of Events. Closing enqueue must close dequeue.
+func newQueuedEnv(parent Env,
+	enqueue chan<- Event, dequeue <-chan Event,
+	filterEvents func(Event, chan<- Event),
+	filterDraws func(func(draw.Image) image.Rectangle, chan<- func(draw.Image) image.Rectangle),
+	shutdown func(),
+) Env {
+	return newStoppingEnv(parent, enqueue, dequeue, filterEvents, filterDraws, nil, shutdown)
+}
+
+// newStoppingEnv is like newQueuedEnv, but stop() is called as soon as the Env is killed, before
+// its children are. Draw functions are still passed to filterDraws() until stop() returns, so stop()
+// can wait for a goroutine that makes or draws to children of the Env. stop may be nil.
+func newStoppingEnv(parent Env,
+	enqueue chan<- Event, dequeue <-chan Event,
+	filterEvents func(Event, chan<- Event),
+	filterDraws func(func(draw.Image) image.Rectangle, chan<- func(draw.Image) image.Rectangle),
+	stop func(),
+	shutdown func(),
+) Env {
 	drawChan := make(chan func(draw.Image) image.Rectangle)
 	child := newKiller()
 	kill := make(chan bool)
@@ -72,7 +96,7 @@ func newEnv(parent Env,
 			close(detachFromParent)
 		}()
 		defer shutdown()
-		defer close(events.Enqueue)
+		defer close(enqueue)
 		defer close(drawChan)
 		defer close(kill)
 		defer func() {
@@ -84,17 +108,32 @@ func newEnv(parent Env,
 		for {
 			select {
 			case e := <-parent.Events():
-				filterEvents(e, events.Enqueue)
+				filterEvents(e, enqueue)
 			case d := <-drawChan:
 				filterDraws(d, parent.Draw())
 			case <-kill:
+				if stop != nil {
+					stopped := make(chan bool)
+					go func() {
+						stop()
+						close(stopped)
+					}()
+					for {
+						select {
+						case d := <-drawChan:
+							filterDraws(d, parent.Draw())
+						case <-stopped:
+							return
+						}
+					}
+				}
 				return
 			}
 		}
 	}()
 
 	e := env{
-		events:     events.Dequeue,
+		events:     dequeue,
 		draw:       drawChan,
 		attachChan: child.attach(),
 		kill:       kill,
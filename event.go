@@ -3,6 +3,7 @@ package gui
 import (
 	"fmt"
 	"image"
+	"time"
 )
 
 // Event is something that can happen in an environment.
@@ -33,6 +34,22 @@ const (
 	ButtonMiddle Button = "middle"
 )
 
+// ScrollUnit indicates the unit of the amounts in a MoScroll event.
+type ScrollUnit int
+
+// List of all scroll units.
+const (
+	ScrollLines ScrollUnit = iota
+	ScrollPixels
+)
+
+func (u ScrollUnit) String() string {
+	if u == ScrollPixels {
+		return "pixels"
+	}
+	return "lines"
+}
+
 // Key indicates a keyboard key in an event.
 type Key string
 
@@ -55,15 +72,105 @@ const (
 	KeyShift     Key = "shift"
 	KeyCtrl      Key = "ctrl"
 	KeyAlt       Key = "alt"
+
+	KeyA            Key = "a"
+	KeyB            Key = "b"
+	KeyC            Key = "c"
+	KeyD            Key = "d"
+	KeyE            Key = "e"
+	KeyF            Key = "f"
+	KeyG            Key = "g"
+	KeyH            Key = "h"
+	KeyI            Key = "i"
+	KeyJ            Key = "j"
+	KeyK            Key = "k"
+	KeyL            Key = "l"
+	KeyM            Key = "m"
+	KeyN            Key = "n"
+	KeyO            Key = "o"
+	KeyP            Key = "p"
+	KeyQ            Key = "q"
+	KeyR            Key = "r"
+	KeyS            Key = "s"
+	KeyT            Key = "t"
+	KeyU            Key = "u"
+	KeyV            Key = "v"
+	KeyW            Key = "w"
+	KeyX            Key = "x"
+	KeyY            Key = "y"
+	KeyZ            Key = "z"
+	Key0            Key = "0"
+	Key1            Key = "1"
+	Key2            Key = "2"
+	Key3            Key = "3"
+	Key4            Key = "4"
+	Key5            Key = "5"
+	Key6            Key = "6"
+	Key7            Key = "7"
+	Key8            Key = "8"
+	Key9            Key = "9"
+	KeyApostrophe   Key = "apostrophe"
+	KeyComma        Key = "comma"
+	KeyMinus        Key = "minus"
+	KeyPeriod       Key = "period"
+	KeySlash        Key = "slash"
+	KeySemicolon    Key = "semicolon"
+	KeyEqual        Key = "equal"
+	KeyLeftBracket  Key = "leftbracket"
+	KeyBackslash    Key = "backslash"
+	KeyRightBracket Key = "rightbracket"
+	KeyGraveAccent  Key = "graveaccent"
+	KeyF1           Key = "f1"
+	KeyF2           Key = "f2"
+	KeyF3           Key = "f3"
+	KeyF4           Key = "f4"
+	KeyF5           Key = "f5"
+	KeyF6           Key = "f6"
+	KeyF7           Key = "f7"
+	KeyF8           Key = "f8"
+	KeyF9           Key = "f9"
+	KeyF10          Key = "f10"
+	KeyF11          Key = "f11"
+	KeyF12          Key = "f12"
+	KeyInsert       Key = "insert"
 )
 
 type (
 	// WiClose is an event that happens when the user presses the close button on the window.
 	WiClose struct{}
 
+	// WiFocus is an event that happens when the window gains or loses the keyboard focus.
+	WiFocus struct{ Focused bool }
+
+	// WiIconify is an event that happens when the window gets iconified (minimized) or restored.
+	WiIconify struct{ Iconified bool }
+
+	// WiMaximize is an event that happens when the window gets maximized or restored.
+	WiMaximize struct{ Maximized bool }
+
+	// WiMove is an event that happens when the window gets moved on the screen.
+	//
+	// The Point field is the new position of the upper-left corner of the window's drawing area
+	// in screen coordinates.
+	WiMove struct{ image.Point }
+
+	// WiScale is an event that happens when the ratio between the pixels of the window and
+	// screen coordinates changes, e.g. when the window is moved to a monitor with a different DPI.
+	//
+	// Elements should scale their sizes, such as the size of fonts, by the Scale field.
+	WiScale struct{ Scale float64 }
+
+	// WiStall is an event that happens when a draw function blocks the window for longer than
+	// the threshold of the Watchdog option.
+	WiStall struct{ Threshold time.Duration }
+
 	// MoMove is an event that happens when the mouse gets moved across the window.
 	MoMove struct{ image.Point }
 
+	// MoRelMove is an event that happens when the mouse gets moved while the cursor is disabled
+	// by Win.SetCursorMode. DX and DY tell the relative motion in pixels.
+	MoRelMove struct{ DX, DY float64 }
+
 	// MoDown is an event that happens when a mouse button gets pressed.
 	MoDown struct {
 		image.Point
@@ -78,30 +185,79 @@ type (
 
 	// MoScroll is an event that happens on scrolling the mouse.
 	//
-	// The Point field tells the amount scrolled in each direction.
-	MoScroll struct{ image.Point }
+	// The DX and DY fields tell the precise amount scrolled in each direction, in the given
+	// Unit. Mouse wheels scroll by whole lines, which elements may translate to any distance.
+	// Trackpads scroll by pixels, which elements should follow exactly to scroll smoothly.
+	//
+	// The Point field tells the whole amount scrolled in each direction, in lines. Fractional
+	// amounts are accumulated over consecutive events, so the sum of the Points follows the sum
+	// of the precise amounts.
+	MoScroll struct {
+		image.Point
+		DX, DY float64
+		Unit   ScrollUnit
+	}
 
 	// KbType is an event that happens when a Unicode character gets typed on the keyboard.
 	KbType struct{ Rune rune }
 
+	// KbPreedit is an event that happens when the text being composed by an input method changes,
+	// e.g. while typing CJK text. Text widgets should display Text at the caret, with the caret
+	// of the composition at the rune offset Cursor. An empty Text ends the composition.
+	// The composed text is committed by KbType events.
+	//
+	// Win does not produce KbPreedit events, because GLFW 3.2 doesn't report compositions.
+	KbPreedit struct {
+		Text   string
+		Cursor int
+	}
+
 	// KbDown is an event that happens when a key on the keyboard gets pressed.
-	KbDown struct{ Key Key }
+	//
+	// Key identifies the physical key by its meaning on the US layout, e.g. KeyQ is the key
+	// labelled A on the French AZERTY layout. Name is the name of the key under the current
+	// keyboard layout, e.g. "a" for that key, which is what shortcut hints should show.
+	//
+	// Scancode is the platform-specific code of the physical key. It is unique for each key and
+	// stays the same across layouts, so games can use it to remember user-defined bindings.
+	KbDown struct {
+		Key      Key
+		Name     string
+		Scancode int
+	}
 
-	// KbUp is an event that happens when a key on the keyboard gets released.
-	KbUp struct{ Key Key }
+	// KbUp is an event that happens when a key on the keyboard gets released. Scancode is the
+	// same as in the KbDown of the key.
+	KbUp struct {
+		Key      Key
+		Scancode int
+	}
 
 	// KbRepeat is an event that happens when a key on the keyboard gets repeated.
 	//
-	// This happens when its held down for some time.
-	KbRepeat struct{ Key Key }
+	// This happens when its held down for some time. Count is 1 for the first repeat after
+	// the key is pressed and goes up by 1 with each one, e.g. to speed up scrolling.
+	KbRepeat struct {
+		Key      Key
+		Scancode int
+		Count    int
+	}
 )
 
-func (wc WiClose) String() string  { return "wi/close" }
-func (mm MoMove) String() string   { return fmt.Sprintf("mo/move/%d/%d", mm.X, mm.Y) }
-func (md MoDown) String() string   { return fmt.Sprintf("mo/down/%d/%d/%s", md.X, md.Y, md.Button) }
-func (mu MoUp) String() string     { return fmt.Sprintf("mo/up/%d/%d/%s", mu.X, mu.Y, mu.Button) }
-func (ms MoScroll) String() string { return fmt.Sprintf("mo/scroll/%d/%d", ms.X, ms.Y) }
-func (kt KbType) String() string   { return fmt.Sprintf("kb/type/%d", kt.Rune) }
-func (kd KbDown) String() string   { return fmt.Sprintf("kb/down/%s", kd.Key) }
-func (ku KbUp) String() string     { return fmt.Sprintf("kb/up/%s", ku.Key) }
-func (kr KbRepeat) String() string { return fmt.Sprintf("kb/repeat/%s", kr.Key) }
+func (wc WiClose) String() string    { return "wi/close" }
+func (wf WiFocus) String() string    { return fmt.Sprintf("wi/focus/%t", wf.Focused) }
+func (wi WiIconify) String() string  { return fmt.Sprintf("wi/iconify/%t", wi.Iconified) }
+func (wm WiMaximize) String() string { return fmt.Sprintf("wi/maximize/%t", wm.Maximized) }
+func (wm WiMove) String() string     { return fmt.Sprintf("wi/move/%d/%d", wm.X, wm.Y) }
+func (ws WiScale) String() string    { return fmt.Sprintf("wi/scale/%g", ws.Scale) }
+func (ws WiStall) String() string    { return fmt.Sprintf("wi/stall/%d", ws.Threshold.Milliseconds()) }
+func (mm MoMove) String() string     { return fmt.Sprintf("mo/move/%d/%d", mm.X, mm.Y) }
+func (mr MoRelMove) String() string  { return fmt.Sprintf("mo/relmove/%g/%g", mr.DX, mr.DY) }
+func (md MoDown) String() string     { return fmt.Sprintf("mo/down/%d/%d/%s", md.X, md.Y, md.Button) }
+func (mu MoUp) String() string       { return fmt.Sprintf("mo/up/%d/%d/%s", mu.X, mu.Y, mu.Button) }
+func (ms MoScroll) String() string   { return fmt.Sprintf("mo/scroll/%d/%d", ms.X, ms.Y) }
+func (kt KbType) String() string     { return fmt.Sprintf("kb/type/%d", kt.Rune) }
+func (kp KbPreedit) String() string  { return fmt.Sprintf("kb/preedit/%d/%q", kp.Cursor, kp.Text) }
+func (kd KbDown) String() string     { return fmt.Sprintf("kb/down/%s", kd.Key) }
+func (ku KbUp) String() string       { return fmt.Sprintf("kb/up/%s", ku.Key) }
+func (kr KbRepeat) String() string   { return fmt.Sprintf("kb/repeat/%s", kr.Key) }
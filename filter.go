@@ -0,0 +1,15 @@
+package gui
+
+// Filter makes an Env that only passes along the Events of parent accepted by keep, e.g. to make
+// an Env that only receives keyboard Events. Resize Events are always passed along, because
+// every Env must produce one as its first Event. Draw functions are forwarded un-modified.
+func Filter(parent Env, keep func(Event) bool) Env {
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if _, ok := e.(Resize); ok || keep(e) {
+				c <- e
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
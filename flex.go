@@ -0,0 +1,274 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+var _ Scheme = Flex{}
+
+// FlexDirection is the axis along which a Flex places its items.
+type FlexDirection int
+
+const (
+	FlexRow    FlexDirection = iota // left to right
+	FlexColumn                      // top to bottom
+)
+
+// FlexJustify determines how a Flex distributes free space along the main axis of a line.
+// It only matters when no item of the line grows.
+type FlexJustify int
+
+const (
+	JustifyStart FlexJustify = iota
+	JustifyCenter
+	JustifyEnd
+	JustifySpaceBetween // equal space between items, none at the ends
+	JustifySpaceAround  // equal space around each item, half of it at the ends
+	JustifySpaceEvenly  // equal space between items and at the ends
+)
+
+// FlexAlign determines where a Flex places an item across the main axis, within its line.
+type FlexAlign int
+
+const (
+	AlignStretch FlexAlign = iota // fill the line
+	AlignStart
+	AlignCenter
+	AlignEnd
+)
+
+// FlexItem describes the size of one child of a Flex.
+type FlexItem struct {
+	// Basis is the size of the item along the main axis before free space is distributed.
+	Basis int
+	// Grow is the share of the free space of its line the item takes when there is some.
+	Grow float64
+	// Shrink is the share of the missing space the item gives up when the line overflows,
+	// weighted by its Basis. Items with Shrink 0 keep their Basis.
+	Shrink float64
+	// Cross is the size of the item across the main axis. 0 stretches the item to its line,
+	// as does AlignStretch.
+	Cross int
+}
+
+// Flex is a Scheme that places its children in lines, like a CSS flexbox. The children can grow
+// into free space and shrink when there is not enough of it, so toolbars and responsive panels
+// can be expressed without a custom Partitioner.
+type Flex struct {
+	// Items describes each child, in order. There must be one item per child.
+	Items []FlexItem
+
+	Direction FlexDirection
+	// Wrap lets the items continue on a new line when they don't fit on the current one.
+	// Otherwise all of the items are on one line and shrink to fit.
+	Wrap bool
+
+	Justify FlexJustify
+	Align   FlexAlign
+
+	// Gap is the space between neighboring items and lines.
+	Gap int
+
+	// Background is the color of the space not covered by the items. Defaults to black.
+	Background color.Color
+}
+
+func (f Flex) redraw(drw draw.Image, bounds image.Rectangle) {
+	col := f.Background
+	if col == nil {
+		col = color.Black
+	}
+	draw.Draw(drw, bounds, image.NewUniform(col), image.ZP, draw.Src)
+}
+
+func (f Flex) Intercept(env Env) Env {
+	return RedrawIntercepter{f.redraw}.Intercept(env)
+}
+
+func (f Flex) Partition(bounds image.Rectangle) []image.Rectangle {
+	return f.partition(bounds, nil)
+}
+
+// partition lays out the items, which don't shrink below their mins along the main axis, if any.
+func (f Flex) partition(bounds image.Rectangle, mins []int) []image.Rectangle {
+	// Work in main/cross coordinates and flip back at the end.
+	mainSize, crossSize := bounds.Dx(), bounds.Dy()
+	if f.Direction == FlexColumn {
+		mainSize, crossSize = crossSize, mainSize
+	}
+
+	lines := f.lines(mainSize)
+
+	// Lines take the cross size of their largest item. Lines of stretched items only share
+	// the space left by the others.
+	crosses := make([]int, len(lines))
+	fixed, stretched := f.Gap*(len(lines)-1), 0
+	for i, line := range lines {
+		for _, idx := range line {
+			crosses[i] = max(crosses[i], f.Items[idx].Cross)
+		}
+		if crosses[i] == 0 || !f.Wrap {
+			stretched++
+		} else {
+			fixed += crosses[i]
+		}
+	}
+	if !f.Wrap {
+		crosses[0] = crossSize
+	} else if stretched > 0 {
+		split := EvenSplit(stretched, max(crossSize-fixed, 0))
+		for i := range crosses {
+			if crosses[i] == 0 {
+				crosses[i], split = split[0], split[1:]
+			}
+		}
+	}
+
+	ret := make([]image.Rectangle, len(f.Items))
+	crossPos := 0
+	for i, line := range lines {
+		sizes := f.mainSizes(line, mainSize, mins)
+		pos, spacing := f.justify(line, sizes, mainSize)
+		for j, idx := range line {
+			item := f.Items[idx]
+			c0, c1 := crossPos, crossPos+crosses[i]
+			if item.Cross > 0 && f.Align != AlignStretch {
+				switch f.Align {
+				case AlignStart:
+					c1 = c0 + item.Cross
+				case AlignCenter:
+					c0 += (crosses[i] - item.Cross) / 2
+					c1 = c0 + item.Cross
+				case AlignEnd:
+					c0 = c1 - item.Cross
+				}
+			}
+			m0 := int(math.Round(pos))
+			m1 := m0 + sizes[j]
+			if f.Direction == FlexColumn {
+				ret[idx] = image.Rect(bounds.Min.X+c0, bounds.Min.Y+m0, bounds.Min.X+c1, bounds.Min.Y+m1)
+			} else {
+				ret[idx] = image.Rect(bounds.Min.X+m0, bounds.Min.Y+c0, bounds.Min.X+m1, bounds.Min.Y+c1)
+			}
+			pos += float64(sizes[j]) + spacing
+		}
+		crossPos += crosses[i] + f.Gap
+	}
+	return ret
+}
+
+var _ SizedPartitioner = Flex{}
+
+// PartitionSized is like Partition, but items without a Basis or Cross size take them from the
+// preferred sizes of their children, and items don't shrink below the minimum sizes.
+func (f Flex) PartitionSized(bounds image.Rectangle, hints []SizeHint) []image.Rectangle {
+	items := make([]FlexItem, len(f.Items))
+	copy(items, f.Items)
+	mins := make([]int, len(items))
+	for i := range items {
+		if i >= len(hints) {
+			break
+		}
+		pref, min := hints[i].Preferred, hints[i].Min
+		if f.Direction == FlexColumn {
+			pref, min = image.Pt(pref.Y, pref.X), image.Pt(min.Y, min.X)
+		}
+		if items[i].Basis == 0 {
+			items[i].Basis = pref.X
+		}
+		if items[i].Cross == 0 {
+			items[i].Cross = pref.Y
+		}
+		mins[i] = min.X
+	}
+	f.Items = items
+	return f.partition(bounds, mins)
+}
+
+// lines returns the indices of the items on each line.
+func (f Flex) lines(mainSize int) [][]int {
+	if len(f.Items) == 0 {
+		return [][]int{nil}
+	}
+	var (
+		lines [][]int
+		line  []int
+		used  int
+	)
+	for i, item := range f.Items {
+		if f.Wrap && len(line) > 0 && used+f.Gap+item.Basis > mainSize {
+			lines = append(lines, line)
+			line, used = nil, 0
+		}
+		if len(line) > 0 {
+			used += f.Gap
+		}
+		line = append(line, i)
+		used += item.Basis
+	}
+	return append(lines, line)
+}
+
+// mainSizes returns the sizes of the items of a line along the main axis, after growing or
+// shrinking them to fill the line.
+func (f Flex) mainSizes(line []int, mainSize int, mins []int) []int {
+	free := float64(mainSize - f.Gap*(len(line)-1))
+	var grow, shrink float64
+	for _, idx := range line {
+		item := f.Items[idx]
+		free -= float64(item.Basis)
+		grow += item.Grow
+		shrink += item.Shrink * float64(item.Basis)
+	}
+
+	sizes := make([]int, len(line))
+	carry := 0.0 // rounding error carried over to the next item, so the sizes add up
+	for j, idx := range line {
+		item := f.Items[idx]
+		size := float64(item.Basis)
+		switch {
+		case free > 0 && grow > 0:
+			size += free * item.Grow / grow
+		case free < 0 && shrink > 0:
+			size += free * item.Shrink * float64(item.Basis) / shrink
+		}
+		if idx < len(mins) {
+			size = math.Max(size, float64(mins[idx]))
+		}
+		size += carry
+		sizes[j] = max(int(math.Round(size)), 0)
+		carry = size - float64(sizes[j])
+	}
+	return sizes
+}
+
+// justify returns the position of the first item of a line and the space between the items.
+func (f Flex) justify(line []int, sizes []int, mainSize int) (start, spacing float64) {
+	free := float64(mainSize - f.Gap*(len(line)-1))
+	for _, size := range sizes {
+		free -= float64(size)
+	}
+	spacing = float64(f.Gap)
+	if free <= 0 {
+		return 0, spacing
+	}
+	n := float64(len(line))
+	switch f.Justify {
+	case JustifyCenter:
+		return free / 2, spacing
+	case JustifyEnd:
+		return free, spacing
+	case JustifySpaceBetween:
+		if len(line) > 1 {
+			return 0, spacing + free/(n-1)
+		}
+	case JustifySpaceAround:
+		return free / n / 2, spacing + free/n
+	case JustifySpaceEvenly:
+		return free / (n + 1), spacing + free/(n+1)
+	}
+	return 0, spacing
+}
@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestFlexPartition(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		flex Flex
+		want []image.Rectangle
+	}{
+		{
+			"grow",
+			Flex{Items: []FlexItem{{Basis: 20}, {Basis: 20, Grow: 1}, {Basis: 20, Grow: 3}}, Gap: 10},
+			[]image.Rectangle{image.Rect(0, 0, 20, 50), image.Rect(30, 0, 70, 50), image.Rect(80, 0, 160, 50)},
+		},
+		{
+			"shrink",
+			Flex{Items: []FlexItem{{Basis: 100, Shrink: 1}, {Basis: 100, Shrink: 1}, {Basis: 60}}},
+			[]image.Rectangle{image.Rect(0, 0, 50, 50), image.Rect(50, 0, 100, 50), image.Rect(100, 0, 160, 50)},
+		},
+		{
+			"wrap and align",
+			Flex{
+				Items: []FlexItem{{Basis: 100, Cross: 10}, {Basis: 100, Cross: 20}, {Basis: 40, Cross: 10}},
+				Wrap:  true, Align: AlignCenter, Justify: JustifyEnd,
+			},
+			[]image.Rectangle{image.Rect(60, 0, 160, 10), image.Rect(20, 10, 120, 30), image.Rect(120, 15, 160, 25)},
+		},
+		{
+			"column space between",
+			Flex{Direction: FlexColumn, Justify: JustifySpaceBetween, Items: []FlexItem{{Basis: 10}, {Basis: 10}}},
+			[]image.Rectangle{image.Rect(0, 0, 160, 10), image.Rect(0, 40, 160, 50)},
+		},
+	} {
+		got := test.flex.Partition(image.Rect(0, 0, 160, 50))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: received %v; wanted %v", test.name, got, test.want)
+		}
+	}
+}
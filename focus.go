@@ -0,0 +1,186 @@
+package gui
+
+import (
+	"image"
+	"slices"
+	"sync"
+)
+
+type (
+	// FocusGained is an event that happens when an Env gains keyboard focus from a FocusManager.
+	FocusGained struct{}
+
+	// FocusLost is an event that happens when an Env loses keyboard focus to another Env.
+	FocusLost struct{}
+)
+
+func (FocusGained) String() string { return "focus/gained" }
+func (FocusLost) String() string   { return "focus/lost" }
+
+var _ Intercepter = &FocusManager{}
+
+// FocusManager keeps track of which one of a group of Envs has keyboard focus.
+//
+// The Envs are made from the Env returned by NewFocusManager, such as by a Mux or a Layout, and
+// each of them is wrapped by passing it to Intercept. The keyboard Events (KbType, KbPreedit,
+// KbDown, KbUp, and KbRepeat) are only passed to the focused Env. Pressing a mouse button inside
+// an Env focuses it, and Tab and Shift+Tab move the focus to the next or previous Env in the order
+// they were wrapped. Tab and Shift+Tab are not passed along. The Envs receive FocusGained and
+// FocusLost Events when their focus changes.
+//
+// Nothing is focused at first. Pressing Tab then focuses the first Env, and Shift+Tab the last.
+type FocusManager struct {
+	mu      sync.Mutex
+	members []*focusMember // in Tab order
+	focused *focusMember
+}
+
+// focusMember is an Env wrapped by a FocusManager.
+type focusMember struct {
+	bounds image.Rectangle // of the last Resize, guarded by the FocusManager's mu
+}
+
+// NewFocusManager makes a FocusManager of the parent Env, and returns the Env to make the Envs it
+// manages from.
+func NewFocusManager(parent Env) (*FocusManager, Env) {
+	fm := new(FocusManager)
+	var shift bool
+	// Focus is moved and keyboard Events are routed here, where each Event is seen once, so that
+	// every Env agrees on which one is focused at each Event.
+	env := newEnv(parent,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case MoDown:
+				fm.notify(fm.focusAt(e.Point), c)
+			case KbDown:
+				switch e.Key {
+				case KeyShift:
+					shift = true
+				case KeyTab:
+					fm.notify(fm.tab(shift), c)
+					return
+				}
+			case KbUp:
+				if e.Key == KeyShift {
+					shift = false
+				}
+			}
+			switch e.(type) {
+			case KbType, KbPreedit, KbDown, KbUp, KbRepeat:
+				c <- focusRouted{e, fm.current()}
+			default:
+				c <- e
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+	return fm, env
+}
+
+// focusRouted is a keyboard Event for the focused member to, or for none if to is nil, or
+// FocusGained or FocusLost for the member whose focus changed. It is never passed along.
+type focusRouted struct {
+	Event
+	to *focusMember
+}
+
+// focusChange is a move of the focus from one member to another, either of which may be nil.
+type focusChange struct {
+	from, to *focusMember
+}
+
+// Intercept wraps an Env made from the Env of the FocusManager.
+func (fm *FocusManager) Intercept(parent Env) Env {
+	m := new(focusMember)
+	fm.mu.Lock()
+	fm.members = append(fm.members, m)
+	fm.mu.Unlock()
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case focusRouted:
+				if e.to == m {
+					c <- e.Event
+				}
+				return
+			case Resize:
+				fm.mu.Lock()
+				m.bounds = e.Rectangle
+				fm.mu.Unlock()
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {
+			fm.remove(m)
+		})
+}
+
+// notify tells the members of a focus change about it.
+func (fm *FocusManager) notify(change focusChange, c chan<- Event) {
+	if change.from == change.to {
+		return
+	}
+	if change.from != nil {
+		c <- focusRouted{FocusLost{}, change.from}
+	}
+	if change.to != nil {
+		c <- focusRouted{FocusGained{}, change.to}
+	}
+}
+
+func (fm *FocusManager) current() *focusMember {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return fm.focused
+}
+
+// focusAt moves the focus to the last member wrapped whose Rectangle contains pt, if any.
+func (fm *FocusManager) focusAt(pt image.Point) focusChange {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	for i := len(fm.members) - 1; i >= 0; i-- {
+		if m := fm.members[i]; pt.In(m.bounds) {
+			return fm.setFocus(m)
+		}
+	}
+	return focusChange{fm.focused, fm.focused}
+}
+
+// tab moves the focus to the member after, or before if backwards, the focused one.
+func (fm *FocusManager) tab(backwards bool) focusChange {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if len(fm.members) == 0 {
+		return focusChange{}
+	}
+	next := 0
+	if backwards {
+		next = len(fm.members) - 1
+	}
+	if i := slices.Index(fm.members, fm.focused); i >= 0 {
+		step := 1
+		if backwards {
+			step = len(fm.members) - 1
+		}
+		next = (i + step) % len(fm.members)
+	}
+	return fm.setFocus(fm.members[next])
+}
+
+// setFocus moves the focus to m. fm.mu must be held.
+func (fm *FocusManager) setFocus(m *focusMember) focusChange {
+	change := focusChange{fm.focused, m}
+	fm.focused = m
+	return change
+}
+
+func (fm *FocusManager) remove(m *focusMember) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.focused == m {
+		fm.focused = nil
+	}
+	fm.members, _ = remove(m, fm.members)
+}
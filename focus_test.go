@@ -0,0 +1,105 @@
+package gui
+
+import (
+	"image"
+	"slices"
+	"testing"
+)
+
+func TestFocusManagerTab(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	fm, env := NewFocusManager(root)
+	mux := NewMux(env)
+	a, b := fm.Intercept(mux.MakeEnv()), fm.Intercept(mux.MakeEnv())
+
+	// The Envs may receive the initial Resize more than once.
+	expect := func(env Env, name string, want Event) {
+		t.Helper()
+		for {
+			got, ok := tryRecv(env.Events(), timeout)
+			if !ok {
+				t.Fatalf("%s: no Event received after %v; wanted %v", name, timeout, want)
+			}
+			if _, resize := (*got).(Resize); resize && want != (Resize{rect}) {
+				continue
+			}
+			if *got != want {
+				t.Errorf("%s: received %v; wanted %v", name, *got, want)
+			}
+			return
+		}
+	}
+	expect(a, "a", Resize{rect})
+	expect(b, "b", Resize{rect})
+
+	root.events.Enqueue <- KbDown{KeyTab, "tab", 0}
+	expect(a, "a", FocusGained{})
+	root.events.Enqueue <- KbType{'x'}
+	expect(a, "a", KbType{'x'})
+
+	root.events.Enqueue <- KbDown{KeyTab, "tab", 0}
+	expect(a, "a", FocusLost{})
+	expect(b, "b", FocusGained{})
+	root.events.Enqueue <- KbType{'y'}
+	expect(b, "b", KbType{'y'})
+
+	// a must not have received anything else in between.
+	root.events.Enqueue <- MoMove{image.Pt(1, 1)}
+	expect(a, "a", MoMove{image.Pt(1, 1)})
+}
+
+func TestFocusManagerTabOrder(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	fm, env := NewFocusManager(root)
+	mux := NewMux(env)
+	envs := []Env{fm.Intercept(mux.MakeEnv()), fm.Intercept(mux.MakeEnv()), fm.Intercept(mux.MakeEnv())}
+
+	for i, step := range []struct {
+		keys []Key
+		want int
+	}{
+		{[]Key{KeyTab}, 0},
+		{[]Key{KeyTab, KeyTab}, 2},
+		{[]Key{KeyTab}, 0}, // wraps around
+		{[]Key{KeyShift, KeyTab}, 2},
+		{[]Key{KeyTab}, 0},
+	} {
+		for _, key := range step.keys {
+			root.events.Enqueue <- KbDown{key, "", 0}
+		}
+		if step.keys[0] == KeyShift {
+			root.events.Enqueue <- KbUp{KeyShift, 0}
+		}
+		// Once every Env has received the marker, all of them have seen the keys.
+		marker := MoMove{image.Pt(i, i)}
+		root.events.Enqueue <- marker
+		for _, env := range envs {
+			for {
+				e, ok := tryRecv(env.Events(), timeout)
+				if !ok {
+					t.Fatalf("no Event received after %v; wanted %v", timeout, marker)
+				}
+				if *e == marker {
+					break
+				}
+			}
+		}
+
+		fm.mu.Lock()
+		focused := slices.Index(fm.members, fm.focused)
+		fm.mu.Unlock()
+		if focused != step.want {
+			t.Errorf("received focus on %d; wanted %d", focused, step.want)
+		}
+	}
+}
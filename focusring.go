@@ -0,0 +1,132 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// NewFocusRing makes an Env that draws a focus indicator on top of everything drawn through it.
+//
+// The ring is drawn around the last Rectangle sent to the returned channel, width pixels thick,
+// outside of the Rectangle. Sending an empty Rectangle hides the ring. The pixels covered by the
+// ring are restored when it moves, so the ring acts as an overlay and elements don't need to
+// know about it.
+//
+// To be consistent across widgets, the focus ring should wrap an Env high up in the tree, such as
+// the window, and every element should send its Rectangle to the channel when it gains focus.
+//
+// The focus channel should be closed when it is no longer used.
+func NewFocusRing(parent Env, col color.Color, width int) (Env, chan<- image.Rectangle) {
+	if col == nil {
+		col = color.RGBA{0x30, 0x80, 0xf0, 0xff}
+	}
+	focus := make(chan image.Rectangle)
+	done := make(chan bool)
+
+	// ring is only accessed from draw functions, which are executed one at a time.
+	ring := &focusRing{col: image.NewUniform(col), width: width}
+
+	env := newEnv(parent,
+		send, // forward events un-modified
+		func(d func(draw.Image) image.Rectangle, c chan<- func(draw.Image) image.Rectangle) {
+			c <- func(drw draw.Image) image.Rectangle {
+				ring.erase(drw)
+				r := d(drw)
+				if ring.paint(drw, ring.target) {
+					r = r.Union(ring.bounds)
+				}
+				return r
+			}
+		},
+		func() {
+			close(done)
+		})
+
+	go func() {
+		defer drain(focus)
+		for {
+			select {
+			case r, ok := <-focus:
+				if !ok {
+					return
+				}
+				select {
+				case parent.Draw() <- ring.move(r):
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return env, focus
+}
+
+type focusRing struct {
+	col    image.Image
+	width  int
+	target image.Rectangle
+
+	bounds image.Rectangle // area currently covered by the ring
+	under  *image.RGBA     // pixels covered by the ring
+}
+
+func (fr *focusRing) move(r image.Rectangle) func(draw.Image) image.Rectangle {
+	return func(drw draw.Image) image.Rectangle {
+		changed := fr.erase(drw)
+		fr.target = r
+		if fr.paint(drw, r) {
+			changed = changed.Union(fr.bounds)
+		}
+		return changed
+	}
+}
+
+// erase restores the pixels under the ring and returns the restored area.
+func (fr *focusRing) erase(drw draw.Image) image.Rectangle {
+	r := fr.bounds
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+	draw.Draw(drw, r, fr.under, r.Min, draw.Src)
+	fr.bounds = image.Rectangle{}
+	return r
+}
+
+// paint saves the pixels under the ring around target and draws the ring.
+// It returns false if nothing was drawn.
+func (fr *focusRing) paint(drw draw.Image, target image.Rectangle) bool {
+	if target.Empty() || fr.width <= 0 {
+		return false
+	}
+	r := target.Inset(-fr.width).Intersect(drw.Bounds())
+	if r.Empty() {
+		return false
+	}
+	if fr.under == nil || !r.In(fr.under.Bounds()) {
+		fr.under = image.NewRGBA(r)
+	}
+	draw.Draw(fr.under, r, drw, r.Min, draw.Src)
+	drawFrame(drw, target.Inset(-fr.width), fr.width, fr.col)
+	fr.bounds = r
+	return true
+}
+
+// drawFrame draws a frame of the given width along the inside edges of r.
+func drawFrame(drw draw.Image, r image.Rectangle, width int, src image.Image) {
+	if width <= 0 {
+		return
+	}
+	inner := r.Inset(width)
+	for _, side := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, inner.Min.Y),         // top
+		image.Rect(r.Min.X, inner.Max.Y, r.Max.X, r.Max.Y),         // bottom
+		image.Rect(r.Min.X, inner.Min.Y, inner.Min.X, inner.Max.Y), // left
+		image.Rect(inner.Max.X, inner.Min.Y, r.Max.X, inner.Max.Y), // right
+	} {
+		draw.Draw(drw, side, src, image.ZP, draw.Over)
+	}
+}
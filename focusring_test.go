@@ -0,0 +1,61 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestFocusRing(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 20, 20))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	env, focus := NewFocusRing(root, red, 2)
+	defer close(focus)
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	draw.Draw(img, img.Bounds(), image.NewUniform(white), image.Point{}, draw.Src)
+	flush := func(wantChanged image.Rectangle, pixels map[image.Point]color.RGBA) {
+		t.Helper()
+		d, ok := tryRecv(root.drawOut, timeout)
+		if !ok {
+			t.Fatalf("no draw function received after %v", timeout)
+		}
+		if r := (*d)(img); r != wantChanged {
+			t.Errorf("changed %v; wanted %v", r, wantChanged)
+		}
+		for pt, want := range pixels {
+			if got := img.RGBAAt(pt.X, pt.Y); got != want {
+				t.Errorf("pixel %v = %v; wanted %v", pt, got, want)
+			}
+		}
+	}
+
+	// The ring is drawn outside of the focused Rectangle.
+	focus <- image.Rect(5, 5, 10, 10)
+	ring := image.Rect(3, 3, 12, 12)
+	flush(ring, map[image.Point]color.RGBA{
+		image.Pt(3, 3): red, image.Pt(4, 11): red, image.Pt(2, 2): white, image.Pt(7, 7): white,
+	})
+
+	// It stays on top of what is drawn through the Env.
+	env.Draw() <- func(drw draw.Image) image.Rectangle {
+		draw.Draw(drw, drw.Bounds(), image.NewUniform(blue), image.Point{}, draw.Src)
+		return drw.Bounds()
+	}
+	flush(img.Bounds(), map[image.Point]color.RGBA{
+		image.Pt(3, 3): red, image.Pt(2, 2): blue, image.Pt(7, 7): blue,
+	})
+
+	// Hiding it restores the pixels it covered.
+	focus <- image.Rectangle{}
+	flush(ring, map[image.Point]color.RGBA{
+		image.Pt(3, 3): blue, image.Pt(4, 11): blue, image.Pt(7, 7): blue,
+	})
+}
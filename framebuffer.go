@@ -0,0 +1,73 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// framebuffer holds the pixels of the drawing area of a window and keeps them across resizes.
+//
+// Its memory is allocated with some headroom, so that while the user drags the border of
+// the window, most resizes only change the bounds of the image and copy nothing at all.
+// Memory is only reallocated when the drawing area outgrows it.
+type framebuffer struct {
+	deep   bool // RGBA64 instead of RGBA
+	pix    []uint8
+	stride int
+}
+
+// resize returns the drawing area resized to r, keeping the pixels of old that are still inside.
+// The newly uncovered pixels are transparent. old may be nil.
+func (fb *framebuffer) resize(old draw.Image, r image.Rectangle) draw.Image {
+	bpp := 4
+	if fb.deep {
+		bpp = 8
+	}
+
+	var kept image.Rectangle
+	if old != nil {
+		kept = old.Bounds().Intersect(r)
+	}
+	fits := old != nil && old.Bounds().Min == r.Min &&
+		r.Dx()*bpp <= fb.stride && r.Dy()*fb.stride <= len(fb.pix)
+	if !fits {
+		stride := grow(r.Dx()) * bpp
+		pix := make([]uint8, grow(r.Dy())*stride)
+		img := fb.image(pix, stride, r)
+		if !kept.Empty() {
+			blit(img, old, kept)
+		}
+		fb.pix, fb.stride = pix, stride
+		return img
+	}
+
+	// The pixels stay where they are, only those that come into view must be cleared.
+	img := fb.image(fb.pix, fb.stride, r)
+	fb.clear(r, image.Rect(kept.Max.X, r.Min.Y, r.Max.X, r.Max.Y), bpp)    // right
+	fb.clear(r, image.Rect(r.Min.X, kept.Max.Y, kept.Max.X, r.Max.Y), bpp) // bottom
+	return img
+}
+
+func (fb *framebuffer) image(pix []uint8, stride int, r image.Rectangle) draw.Image {
+	if fb.deep {
+		return &image.RGBA64{Pix: pix, Stride: stride, Rect: r}
+	}
+	return &image.RGBA{Pix: pix, Stride: stride, Rect: r}
+}
+
+// clear zeroes the pixels inside area of an image with the given bounds stored in fb.
+func (fb *framebuffer) clear(bounds, area image.Rectangle, bpp int) {
+	area = area.Intersect(bounds)
+	if area.Empty() {
+		return
+	}
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		i := (y-bounds.Min.Y)*fb.stride + (area.Min.X-bounds.Min.X)*bpp
+		clear(fb.pix[i : i+area.Dx()*bpp])
+	}
+}
+
+// grow adds headroom to a size.
+func grow(n int) int {
+	return n + n/4
+}
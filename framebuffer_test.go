@@ -0,0 +1,36 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFramebufferResize(t *testing.T) {
+	var fb framebuffer
+	img := fb.resize(nil, image.Rect(0, 0, 100, 100)).(*image.RGBA)
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	img.SetRGBA(10, 10, red)
+	img.SetRGBA(90, 90, red)
+
+	shrunk := fb.resize(img, image.Rect(0, 0, 50, 50)).(*image.RGBA)
+	if &shrunk.Pix[0] != &img.Pix[0] {
+		t.Errorf("shrinking reallocated the pixels")
+	}
+	if got := shrunk.RGBAAt(10, 10); got != red {
+		t.Errorf("kept pixel = %v; wanted %v", got, red)
+	}
+
+	regrown := fb.resize(shrunk, image.Rect(0, 0, 110, 110)).(*image.RGBA)
+	if &regrown.Pix[0] != &img.Pix[0] {
+		t.Errorf("growing within the headroom reallocated the pixels")
+	}
+	if got := regrown.RGBAAt(90, 90); got != (color.RGBA{}) {
+		t.Errorf("uncovered pixel = %v; wanted transparent", got)
+	}
+
+	big := fb.resize(regrown, image.Rect(0, 0, 400, 300)).(*image.RGBA)
+	if got := big.RGBAAt(10, 10); got != red {
+		t.Errorf("pixel after reallocation = %v; wanted %v", got, red)
+	}
+}
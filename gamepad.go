@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+type (
+	// GamepadButton is an event that happens when a button of a gamepad or joystick gets pressed
+	// or released. Enabled by the Gamepads option.
+	GamepadButton struct {
+		Joystick int
+		Button   int
+		Pressed  bool
+	}
+
+	// GamepadAxis is an event that happens when an axis of a gamepad or joystick moves.
+	// Value is in [-1, 1]. Enabled by the Gamepads option.
+	GamepadAxis struct {
+		Joystick int
+		Axis     int
+		Value    float64
+	}
+)
+
+func (gb GamepadButton) String() string {
+	return fmt.Sprintf("gp/button/%d/%d/%t", gb.Joystick, gb.Button, gb.Pressed)
+}
+
+func (ga GamepadAxis) String() string {
+	return fmt.Sprintf("gp/axis/%d/%d/%g", ga.Joystick, ga.Axis, ga.Value)
+}
+
+// gamepadAxisThreshold is the smallest change of an axis that produces an event.
+const gamepadAxisThreshold = 0.01
+
+// gamepadState is the last known state of a joystick.
+type gamepadState struct {
+	axes    []float32
+	buttons []byte
+}
+
+// pollGamepads compares the state of all joysticks with their state at the previous call,
+// emitting events for the differences. It must be called on the main thread.
+func (w *Win) pollGamepads() {
+	for joy := glfw.Joystick1; joy <= glfw.JoystickLast; joy++ {
+		id := int(joy - glfw.Joystick1)
+		if !glfw.JoystickPresent(joy) {
+			delete(w.gamepads, id)
+			continue
+		}
+		prev := w.gamepads[id]
+		axes := glfw.GetJoystickAxes(joy)
+		buttons := glfw.GetJoystickButtons(joy)
+
+		for i, v := range axes {
+			var old float32
+			if i < len(prev.axes) {
+				old = prev.axes[i]
+			}
+			if math.Abs(float64(v-old)) >= gamepadAxisThreshold {
+				w.events.Enqueue <- GamepadAxis{id, i, float64(v)}
+			} else {
+				axes[i] = old // accumulate small changes
+			}
+		}
+		for i, b := range buttons {
+			var old byte
+			if i < len(prev.buttons) {
+				old = prev.buttons[i]
+			}
+			if b != old {
+				w.events.Enqueue <- GamepadButton{id, i, glfw.Action(b) == glfw.Press}
+			}
+		}
+
+		w.gamepads[id] = gamepadState{
+			axes:    append([]float32(nil), axes...),
+			buttons: append([]byte(nil), buttons...),
+		}
+	}
+}
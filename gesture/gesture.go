@@ -0,0 +1,231 @@
+// Package gesture recognizes taps, long presses, swipes, and pinches in touch Events.
+//
+// The Recognizer consumes the TouchDown, TouchMove, and TouchUp Events of an Env. Wrap the Env in
+// gui.MouseTouch to recognize gestures made with the left mouse button as well.
+package gesture
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"git.samanthony.xyz/share"
+
+	"github.com/faiface/gui"
+)
+
+type (
+	// Tap is an event that happens when a finger touches the screen and is lifted without moving.
+	Tap struct {
+		image.Point
+	}
+
+	// LongPress is an event that happens when a finger stays on the screen without moving for
+	// a while. It is not followed by a Tap when the finger is lifted.
+	LongPress struct {
+		image.Point
+	}
+
+	// Swipe is an event that happens when a finger moves quickly across the screen and is lifted.
+	Swipe struct {
+		From, To image.Point
+	}
+
+	// Pinch is an event that happens each time one of two fingers on the screen moves.
+	// Scale is the distance between the fingers relative to when the second one touched down,
+	// and Center is the point halfway between them.
+	Pinch struct {
+		Center image.Point
+		Scale  float64
+	}
+)
+
+func (t Tap) String() string        { return fmt.Sprintf("gesture/tap/%d/%d", t.X, t.Y) }
+func (lp LongPress) String() string { return fmt.Sprintf("gesture/longpress/%d/%d", lp.X, lp.Y) }
+
+func (s Swipe) String() string {
+	return fmt.Sprintf("gesture/swipe/%d/%d/%d/%d", s.From.X, s.From.Y, s.To.X, s.To.Y)
+}
+
+func (p Pinch) String() string {
+	return fmt.Sprintf("gesture/pinch/%d/%d/%g", p.Center.X, p.Center.Y, p.Scale)
+}
+
+// Recognizer holds the thresholds that tell gestures apart. The zero value uses the defaults.
+type Recognizer struct {
+	// Slop is how far, in pixels, a finger may move and still make a Tap or LongPress.
+	// Defaults to 8.
+	Slop int
+	// LongPressDelay is how long a finger must stay still to make a LongPress. Defaults to 500ms.
+	LongPressDelay time.Duration
+	// SwipeDistance is how far, in pixels, a finger must move to make a Swipe. Defaults to 48.
+	SwipeDistance int
+	// SwipeTime is the longest a Swipe may take. Defaults to 500ms.
+	SwipeTime time.Duration
+}
+
+// Recognize returns a channel that produces the Events received from events, along with the
+// gestures recognized in them. Each gesture follows the Event that completed it.
+//
+// The returned channel has unlimited capacity and is closed after events is closed, so it can
+// stand in for the Events() channel of an Env:
+//
+//	for e := range (gesture.Recognizer{}).Recognize(env.Events()) {
+//		switch e := e.(type) {
+//		case gesture.Tap:
+//			...
+//		}
+//	}
+func (r Recognizer) Recognize(events <-chan gui.Event) <-chan gui.Event {
+	if r.Slop <= 0 {
+		r.Slop = 8
+	}
+	if r.LongPressDelay <= 0 {
+		r.LongPressDelay = 500 * time.Millisecond
+	}
+	if r.SwipeDistance <= 0 {
+		r.SwipeDistance = 48
+	}
+	if r.SwipeTime <= 0 {
+		r.SwipeTime = 500 * time.Millisecond
+	}
+
+	out := share.NewQueue[gui.Event]()
+	go func() {
+		defer close(out.Enqueue)
+		s := &state{Recognizer: r, touches: make(map[int]*touch)}
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				out.Enqueue <- e
+				for _, g := range s.event(e, time.Now()) {
+					out.Enqueue <- g
+				}
+			case <-s.longPress:
+				s.longPress = nil
+				if g, ok := s.fireLongPress(); ok {
+					out.Enqueue <- g
+				}
+			}
+		}
+	}()
+	return out.Dequeue
+}
+
+type touch struct {
+	start, pos image.Point
+	time       time.Time
+	moved      bool // moved further than Slop
+}
+
+// state is the gesture state machine, owned by the goroutine of Recognize.
+type state struct {
+	Recognizer
+	touches map[int]*touch
+	order   []int // IDs of the touches in the order they touched down
+
+	multi      bool    // more than one finger has touched down since the screen was last clear
+	pressed    bool    // a LongPress was emitted for the current touch
+	pinchStart float64 // distance between the first two fingers when the second touched down
+	longPress  <-chan time.Time
+}
+
+// event updates the state and returns the gestures completed by e.
+func (s *state) event(e gui.Event, now time.Time) []gui.Event {
+	switch e := e.(type) {
+	case gui.TouchDown:
+		s.touches[e.ID] = &touch{start: e.Point, pos: e.Point, time: now}
+		s.order = append(s.order, e.ID)
+		switch len(s.touches) {
+		case 1:
+			s.multi, s.pressed = false, false
+			s.longPress = time.After(s.LongPressDelay)
+		case 2:
+			s.multi = true
+			s.longPress = nil
+			a, b := s.pair()
+			s.pinchStart = dist(a.pos, b.pos)
+		}
+
+	case gui.TouchMove:
+		t, ok := s.touches[e.ID]
+		if !ok {
+			return nil
+		}
+		t.pos = e.Point
+		if d := e.Point.Sub(t.start); d.X*d.X+d.Y*d.Y > s.Slop*s.Slop {
+			t.moved = true
+			if !s.multi {
+				s.longPress = nil
+			}
+		}
+		if len(s.touches) == 2 && s.pinchStart > 0 {
+			a, b := s.pair()
+			center := a.pos.Add(b.pos).Div(2)
+			return []gui.Event{Pinch{center, dist(a.pos, b.pos) / s.pinchStart}}
+		}
+
+	case gui.TouchUp:
+		t, ok := s.touches[e.ID]
+		if !ok {
+			return nil
+		}
+		t.pos = e.Point
+		delete(s.touches, e.ID)
+		for i, id := range s.order {
+			if id == e.ID {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+		if len(s.touches) < 2 {
+			s.pinchStart = 0
+		}
+		if s.multi || len(s.touches) > 0 {
+			return nil
+		}
+		s.longPress = nil
+		switch {
+		case s.pressed:
+		case !t.moved:
+			return []gui.Event{Tap{e.Point}}
+		case dist(t.start, e.Point) >= float64(s.SwipeDistance) && now.Sub(t.time) <= s.SwipeTime:
+			return []gui.Event{Swipe{t.start, e.Point}}
+		}
+	}
+	return nil
+}
+
+// fireLongPress is called when the long press delay has passed.
+func (s *state) fireLongPress() (gui.Event, bool) {
+	if len(s.touches) != 1 || s.multi {
+		return nil, false
+	}
+	t := s.touches[s.order[0]]
+	if t.moved {
+		return nil, false
+	}
+	s.pressed = true
+	return LongPress{t.pos}, true
+}
+
+// pair returns the first two touches on the screen.
+func (s *state) pair() (a, b *touch) {
+	return s.touches[s.order[0]], s.touches[s.order[1]]
+}
+
+func dist(a, b image.Point) float64 {
+	d := a.Sub(b)
+	return math.Hypot(float64(d.X), float64(d.Y))
+}
+
+func init() {
+	gui.RegisterEvent("gesture.Tap", Tap{})
+	gui.RegisterEvent("gesture.LongPress", LongPress{})
+	gui.RegisterEvent("gesture.Swipe", Swipe{})
+	gui.RegisterEvent("gesture.Pinch", Pinch{})
+}
@@ -0,0 +1,69 @@
+package gesture
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/faiface/gui"
+)
+
+func TestRecognize(t *testing.T) {
+	p := image.Pt(10, 10)
+	tests := []struct {
+		name  string
+		input []gui.Event
+		want  gui.Event
+	}{
+		{"tap", []gui.Event{gui.TouchDown{Point: p, ID: 0}, gui.TouchMove{Point: p.Add(image.Pt(2, 2)), ID: 0}, gui.TouchUp{Point: p, ID: 0}}, Tap{p}},
+		{"swipe", []gui.Event{gui.TouchDown{Point: p, ID: 0}, gui.TouchMove{Point: image.Pt(60, 10), ID: 0}, gui.TouchUp{Point: image.Pt(110, 10), ID: 0}}, Swipe{p, image.Pt(110, 10)}},
+		{"pinch", []gui.Event{gui.TouchDown{Point: image.Pt(0, 0), ID: 0}, gui.TouchDown{Point: image.Pt(10, 0), ID: 1}, gui.TouchMove{Point: image.Pt(20, 0), ID: 1}}, Pinch{image.Pt(10, 0), 2}},
+	}
+
+	for _, test := range tests {
+		in := make(chan gui.Event)
+		out := Recognizer{}.Recognize(in)
+		for _, e := range test.input {
+			in <- e
+		}
+
+		var got []gui.Event
+		for range len(test.input) + 1 {
+			select {
+			case e := <-out:
+				got = append(got, e)
+			case <-time.After(time.Second):
+			}
+		}
+		close(in)
+		if len(got) != len(test.input)+1 || got[len(got)-1] != test.want {
+			t.Errorf("%s: received %v; wanted %v after the input", test.name, got, test.want)
+		}
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	in := make(chan gui.Event)
+	out := Recognizer{LongPressDelay: 10 * time.Millisecond}.Recognize(in)
+	p := image.Pt(10, 10)
+
+	in <- gui.TouchDown{Point: p, ID: 0}
+	<-out
+	select {
+	case e := <-out:
+		if e != (LongPress{p}) {
+			t.Errorf("received %v; wanted %v", e, LongPress{p})
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no LongPress after %v", time.Second)
+	}
+
+	in <- gui.TouchUp{Point: p, ID: 0}
+	<-out
+	select {
+	case e := <-out:
+		t.Errorf("received %v after a LongPress; wanted nothing", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(in)
+}
@@ -0,0 +1,120 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+	"image/gif"
+	"time"
+)
+
+// PlayGIF makes an Env that plays an animated GIF in the Rectangle of its last Resize Event,
+// scaled to fit without distortion. Frames are shown for their own delays and disposed of as
+// the GIF says, and the animation loops as many times as the GIF says.
+//
+// The returned Env passes along the Events of parent and forwards draw functions, so other
+// elements can draw over the animation. Killing it stops the animation. A GIF without frames
+// shows nothing.
+func PlayGIF(parent Env, g *gif.GIF) Env {
+	if len(g.Image) == 0 {
+		return newEnv(parent, send, send, func() {})
+	}
+	resizes := make(chan image.Rectangle)
+	done := make(chan bool)
+
+	go func() {
+		width, height := g.Config.Width, g.Config.Height
+		if width == 0 || height == 0 {
+			b := g.Image[0].Bounds()
+			width, height = b.Max.X, b.Max.Y
+		}
+		canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+		previous := image.NewRGBA(canvas.Bounds()) // canvas before the current frame, for DisposalPrevious
+
+		var (
+			bounds image.Rectangle
+			frame  = -1
+			loops  = 0
+			timer  = time.NewTimer(0) // the first frame is shown right away
+		)
+		defer timer.Stop()
+
+		show := func() bool {
+			img := image.NewRGBA(canvas.Bounds())
+			copy(img.Pix, canvas.Pix)
+			r := FitRect(img.Bounds().Size(), bounds)
+			select {
+			case parent.Draw() <- func(drw draw.Image) image.Rectangle {
+				draw.Draw(drw, r, image.Transparent, image.Point{}, draw.Src)
+				return DrawScaled(drw, r, img, Nearest)
+			}:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		for {
+			select {
+			case bounds = <-resizes:
+				if frame >= 0 && !show() {
+					return
+				}
+			case <-timer.C:
+				if frame == len(g.Image)-1 {
+					loops++
+					// LoopCount is 0 to loop forever, -1 to play once, or n to repeat n times.
+					if g.LoopCount < 0 || (g.LoopCount > 0 && loops > g.LoopCount) || len(g.Image) == 1 {
+						continue // keep showing the last frame
+					}
+				}
+
+				// Dispose of the previous frame.
+				if frame >= 0 && frame < len(g.Disposal) {
+					fr := g.Image[frame].Bounds()
+					switch g.Disposal[frame] {
+					case gif.DisposalBackground:
+						draw.Draw(canvas, fr, image.Transparent, image.Point{}, draw.Src)
+					case gif.DisposalPrevious:
+						draw.Draw(canvas, fr, previous, fr.Min, draw.Src)
+					}
+				}
+
+				frame++
+				if frame == len(g.Image) {
+					frame = 0
+					draw.Draw(canvas, canvas.Bounds(), image.Transparent, image.Point{}, draw.Src)
+				}
+
+				copy(previous.Pix, canvas.Pix)
+				fr := g.Image[frame]
+				draw.Draw(canvas, fr.Bounds(), fr, fr.Bounds().Min, draw.Over)
+				if !show() {
+					return
+				}
+
+				delay := 10 // hundredths of a second; browsers treat tiny delays this way too
+				if frame < len(g.Delay) && g.Delay[frame] > 1 {
+					delay = g.Delay[frame]
+				}
+				timer.Reset(time.Duration(delay) * 10 * time.Millisecond)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				select {
+				case resizes <- resize.Rectangle:
+				case <-done:
+				}
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {
+			close(done)
+		})
+}
@@ -0,0 +1,104 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func TestPlayGIF(t *testing.T) {
+	red, blue := color.RGBA{0xff, 0, 0, 0xff}, color.RGBA{0, 0, 0xff, 0xff}
+	frame := func(c color.Color) *image.Paletted {
+		return image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{c})
+	}
+	rect := image.Rect(0, 0, 4, 4)
+
+	for _, test := range []struct {
+		loopCount int
+		want      []color.RGBA
+	}{
+		{-1, []color.RGBA{red, blue}},           // once
+		{1, []color.RGBA{red, blue, red, blue}}, // repeated once
+	} {
+		root := newDummyEnv(rect)
+		env := PlayGIF(root, &gif.GIF{
+			Image:     []*image.Paletted{frame(red), frame(blue)},
+			Delay:     []int{5, 5}, // 50ms
+			LoopCount: test.loopCount,
+		})
+		go drain(env.Events())
+
+		img := image.NewRGBA(rect)
+		var shown []color.RGBA
+		var times []time.Time
+		for {
+			d, ok := tryRecv(root.drawOut, 200*time.Millisecond)
+			if !ok {
+				break // stopped
+			}
+			if r := (*d)(img); r.Empty() {
+				continue // drawn before the first Resize
+			}
+			c := img.RGBAAt(3, 3)
+			if len(shown) > 0 && shown[len(shown)-1] == c {
+				continue // redrawn after a Resize
+			}
+			shown = append(shown, c)
+			times = append(times, time.Now())
+		}
+
+		if len(shown) != len(test.want) {
+			t.Errorf("LoopCount %d: received frames %v; wanted %v", test.loopCount, shown, test.want)
+			continue
+		}
+		for i := range shown {
+			if shown[i] != test.want[i] {
+				t.Errorf("LoopCount %d: received frames %v; wanted %v", test.loopCount, shown, test.want)
+				break
+			}
+		}
+		for i := 1; i < len(times); i++ {
+			if gap := times[i].Sub(times[i-1]); gap < 40*time.Millisecond {
+				t.Errorf("LoopCount %d: frame %d shown after %v; wanted 50ms", test.loopCount, i, gap)
+			}
+		}
+
+		env.Kill() <- true
+		<-env.Dead()
+	}
+}
+
+func TestPlayGIFEmpty(t *testing.T) {
+	rect := image.Rect(0, 0, 4, 4)
+	root := newDummyEnv(rect)
+	env := PlayGIF(root, &gif.GIF{})
+	defer func() {
+		env.Kill() <- true
+		<-env.Dead()
+	}()
+
+	// Events are still passed along after the first Resize.
+	for _, want := range []Event{Resize{rect}, MoMove{image.Pt(1, 1)}} {
+		if want != (Resize{rect}) {
+			root.events.Enqueue <- want
+		}
+		got, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+
+	// So are draw functions.
+	go func() {
+		env.Draw() <- func(drw draw.Image) image.Rectangle { return rect }
+	}()
+	if _, ok := tryRecv(root.drawOut, timeout); !ok {
+		t.Errorf("no draw function received after %v", timeout)
+	}
+}
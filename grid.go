@@ -53,16 +53,88 @@ func (g Grid) Intercept(env Env) Env {
 }
 
 func (g Grid) Partition(bounds image.Rectangle) []image.Rectangle {
-	gap := g.Gap
-	rows := g.Rows
-	splitMain := g.Split
+	splitMain, splitSec := g.splits()
+	return g.partition(bounds,
+		func(_, cols, space int) []int { return splitMain(cols, space) },
+		splitSec)
+}
+
+var _ SizedPartitioner = Grid{}
+
+// PartitionSized is like Partition, but the columns of each row get widths based on the size
+// hints of their children, and the rows get heights based on the largest hints in them.
+// Split is still used for the rows whose children have no hints, and SplitRows if none of the
+// children have hints.
+func (g Grid) PartitionSized(bounds image.Rectangle, hints []SizeHint) []image.Rectangle {
+	splitMain, splitSec := g.splits()
+
+	// Along the rows and across them, which are swapped when the grid is flipped.
+	along := func(p image.Point) int { return p.X }
+	across := func(p image.Point) int { return p.Y }
+	if g.Flip {
+		along, across = across, along
+	}
+
+	first := make([]int, len(g.Rows)) // index of the first child of each row
+	for i := 1; i < len(g.Rows); i++ {
+		first[i] = first[i-1] + g.Rows[i-1]
+	}
+	hint := func(i int) SizeHint {
+		if i < len(hints) {
+			return hints[i]
+		}
+		return SizeHint{}
+	}
+
+	return g.partition(bounds,
+		func(row, cols, space int) []int {
+			mins, prefs := make([]int, cols), make([]int, cols)
+			hinted := false
+			for c := range mins {
+				h := hint(first[row] + c)
+				mins[c], prefs[c] = along(h.Min), along(h.Preferred)
+				hinted = hinted || h != SizeHint{}
+			}
+			if !hinted {
+				return splitMain(cols, space)
+			}
+			return sizedSplit(space, mins, prefs)
+		},
+		func(rows, space int) []int {
+			mins, prefs := make([]int, rows), make([]int, rows)
+			hinted := false
+			for r := range mins {
+				for c := 0; c < g.Rows[r]; c++ {
+					h := hint(first[r] + c)
+					mins[r] = max(mins[r], across(h.Min))
+					prefs[r] = max(prefs[r], across(h.Preferred))
+				}
+				hinted = hinted || mins[r] != 0 || prefs[r] != 0
+			}
+			if !hinted {
+				return splitSec(rows, space)
+			}
+			return sizedSplit(space, mins, prefs)
+		})
+}
+
+// splits returns Split and SplitRows, or EvenSplit for either that is nil.
+func (g Grid) splits() (splitMain, splitSec SplitFunc) {
+	splitMain, splitSec = g.Split, g.SplitRows
 	if splitMain == nil {
 		splitMain = EvenSplit
 	}
-	splitSec := g.SplitRows
 	if splitSec == nil {
 		splitSec = EvenSplit
 	}
+	return splitMain, splitSec
+}
+
+// partition lays out the grid with splitRow dividing the space of each row among its columns,
+// and splitRows dividing the space among the rows.
+func (g Grid) partition(bounds image.Rectangle, splitRow func(row, cols, space int) []int, splitRows SplitFunc) []image.Rectangle {
+	gap := g.Gap
+	rows := g.Rows
 	margin := g.Margin
 	flip := g.Flip
 	if margin+gap < 0 {
@@ -88,13 +160,13 @@ func (g Grid) Partition(bounds image.Rectangle) []image.Rectangle {
 		mX = bounds.Min.X
 		mY = bounds.Min.Y
 	}
-	rowsH := splitSec(len(rows), H-(gap*(len(rows)+1))-margin*2)
+	rowsH := splitRows(len(rows), H-(gap*(len(rows)+1))-margin*2)
 	var X int
 	var Y int
 	Y = gap + mY + margin
 	for y, cols := range rows {
 		h := rowsH[y]
-		colsW := splitMain(cols, W-(gap*(cols+1))-margin*2)
+		colsW := splitRow(y, cols, W-(gap*(cols+1))-margin*2)
 		X = gap + mX + margin
 		for _, w := range colsW {
 			var r image.Rectangle
@@ -3,6 +3,7 @@ package gui
 import (
 	"image"
 	"image/draw"
+	"testing"
 	"time"
 
 	"git.samanthony.xyz/share"
@@ -110,3 +111,17 @@ type dummyEvent struct {
 func (e dummyEvent) String() string {
 	return e.s
 }
+
+// expectDetached kills env, and fails unless another Env can then be made on its parent.
+func expectDetached(t *testing.T, parent Env, env Killable) {
+	t.Helper()
+	env.Kill() <- true
+	if _, ok := tryRecv(env.Dead(), timeout); !ok {
+		t.Fatalf("Env not dead after %v", timeout)
+	}
+	made := make(chan Env)
+	go func() { made <- newEnv(parent, send, send, func() {}) }()
+	if _, ok := tryRecv(made, timeout); !ok {
+		t.Fatalf("no Env made on the parent after %v", timeout)
+	}
+}
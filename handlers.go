@@ -0,0 +1,230 @@
+package gui
+
+// Handlers holds a function for each kind of Event. Nil functions are ignored.
+//
+// Instead of a type switch, an element can fill in the functions for the Events it is
+// interested in and pass each Event to HandleEvent:
+//
+//	h := gui.Handlers{
+//		OnResize: func(r gui.Resize) { ... },
+//		OnMoDown: func(md gui.MoDown) { ... },
+//	}
+//	for e := range env.Events() {
+//		gui.HandleEvent(e, h)
+//	}
+type Handlers struct {
+	OnResize        func(Resize)
+	OnWiClose       func(WiClose)
+	OnWiFocus       func(WiFocus)
+	OnWiIconify     func(WiIconify)
+	OnWiMaximize    func(WiMaximize)
+	OnWiMove        func(WiMove)
+	OnWiScale       func(WiScale)
+	OnWiStall       func(WiStall)
+	OnFocusGained   func(FocusGained)
+	OnFocusLost     func(FocusLost)
+	OnTick          func(Tick)
+	OnMoMove        func(MoMove)
+	OnMoRelMove     func(MoRelMove)
+	OnMoDown        func(MoDown)
+	OnMoUp          func(MoUp)
+	OnMoClick       func(MoClick)
+	OnMoDoubleClick func(MoDoubleClick)
+	OnMoScroll      func(MoScroll)
+	OnHoverEnter    func(HoverEnter)
+	OnHoverLeave    func(HoverLeave)
+	OnDragStart     func(DragStart)
+	OnDragMove      func(DragMove)
+	OnDragEnd       func(DragEnd)
+	OnKbType        func(KbType)
+	OnKbPreedit     func(KbPreedit)
+	OnKbDown        func(KbDown)
+	OnKbUp          func(KbUp)
+	OnKbRepeat      func(KbRepeat)
+	OnTouchDown     func(TouchDown)
+	OnTouchMove     func(TouchMove)
+	OnTouchUp       func(TouchUp)
+	OnGamepadButton func(GamepadButton)
+	OnGamepadAxis   func(GamepadAxis)
+
+	// Other handles all Events without a non-nil function above, including kinds of
+	// Events this package doesn't know about.
+	Other func(Event)
+}
+
+// HandleEvent calls the function of h matching the kind of e. It returns false if there is no
+// such function and h.Other is nil.
+func HandleEvent(e Event, h Handlers) bool {
+	switch e := e.(type) {
+	case Resize:
+		if h.OnResize != nil {
+			h.OnResize(e)
+			return true
+		}
+	case WiClose:
+		if h.OnWiClose != nil {
+			h.OnWiClose(e)
+			return true
+		}
+	case WiFocus:
+		if h.OnWiFocus != nil {
+			h.OnWiFocus(e)
+			return true
+		}
+	case WiIconify:
+		if h.OnWiIconify != nil {
+			h.OnWiIconify(e)
+			return true
+		}
+	case WiMaximize:
+		if h.OnWiMaximize != nil {
+			h.OnWiMaximize(e)
+			return true
+		}
+	case WiMove:
+		if h.OnWiMove != nil {
+			h.OnWiMove(e)
+			return true
+		}
+	case WiScale:
+		if h.OnWiScale != nil {
+			h.OnWiScale(e)
+			return true
+		}
+	case WiStall:
+		if h.OnWiStall != nil {
+			h.OnWiStall(e)
+			return true
+		}
+	case FocusGained:
+		if h.OnFocusGained != nil {
+			h.OnFocusGained(e)
+			return true
+		}
+	case FocusLost:
+		if h.OnFocusLost != nil {
+			h.OnFocusLost(e)
+			return true
+		}
+	case Tick:
+		if h.OnTick != nil {
+			h.OnTick(e)
+			return true
+		}
+	case MoMove:
+		if h.OnMoMove != nil {
+			h.OnMoMove(e)
+			return true
+		}
+	case MoRelMove:
+		if h.OnMoRelMove != nil {
+			h.OnMoRelMove(e)
+			return true
+		}
+	case MoDown:
+		if h.OnMoDown != nil {
+			h.OnMoDown(e)
+			return true
+		}
+	case MoUp:
+		if h.OnMoUp != nil {
+			h.OnMoUp(e)
+			return true
+		}
+	case MoClick:
+		if h.OnMoClick != nil {
+			h.OnMoClick(e)
+			return true
+		}
+	case MoDoubleClick:
+		if h.OnMoDoubleClick != nil {
+			h.OnMoDoubleClick(e)
+			return true
+		}
+	case MoScroll:
+		if h.OnMoScroll != nil {
+			h.OnMoScroll(e)
+			return true
+		}
+	case HoverEnter:
+		if h.OnHoverEnter != nil {
+			h.OnHoverEnter(e)
+			return true
+		}
+	case HoverLeave:
+		if h.OnHoverLeave != nil {
+			h.OnHoverLeave(e)
+			return true
+		}
+	case DragStart:
+		if h.OnDragStart != nil {
+			h.OnDragStart(e)
+			return true
+		}
+	case DragMove:
+		if h.OnDragMove != nil {
+			h.OnDragMove(e)
+			return true
+		}
+	case DragEnd:
+		if h.OnDragEnd != nil {
+			h.OnDragEnd(e)
+			return true
+		}
+	case KbType:
+		if h.OnKbType != nil {
+			h.OnKbType(e)
+			return true
+		}
+	case KbPreedit:
+		if h.OnKbPreedit != nil {
+			h.OnKbPreedit(e)
+			return true
+		}
+	case KbDown:
+		if h.OnKbDown != nil {
+			h.OnKbDown(e)
+			return true
+		}
+	case KbUp:
+		if h.OnKbUp != nil {
+			h.OnKbUp(e)
+			return true
+		}
+	case KbRepeat:
+		if h.OnKbRepeat != nil {
+			h.OnKbRepeat(e)
+			return true
+		}
+	case TouchDown:
+		if h.OnTouchDown != nil {
+			h.OnTouchDown(e)
+			return true
+		}
+	case TouchMove:
+		if h.OnTouchMove != nil {
+			h.OnTouchMove(e)
+			return true
+		}
+	case TouchUp:
+		if h.OnTouchUp != nil {
+			h.OnTouchUp(e)
+			return true
+		}
+	case GamepadButton:
+		if h.OnGamepadButton != nil {
+			h.OnGamepadButton(e)
+			return true
+		}
+	case GamepadAxis:
+		if h.OnGamepadAxis != nil {
+			h.OnGamepadAxis(e)
+			return true
+		}
+	}
+	if h.Other != nil {
+		h.Other(e)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+)
+
+type (
+	// HoverEnter is an event that happens when the mouse moves into the area of an Env.
+	// Emitted by HoverTracker.
+	HoverEnter struct {
+		image.Point
+	}
+
+	// HoverLeave is an event that happens when the mouse moves out of the area of an Env.
+	HoverLeave struct {
+		image.Point
+	}
+)
+
+func (he HoverEnter) String() string { return fmt.Sprintf("hover/enter/%d/%d", he.X, he.Y) }
+func (hl HoverLeave) String() string { return fmt.Sprintf("hover/leave/%d/%d", hl.X, hl.Y) }
+
+var _ Intercepter = HoverTracker{}
+
+// HoverTracker is an Intercepter that emits HoverEnter and HoverLeave Events when the mouse moves
+// in and out of the Rectangle of the last Resize Event. A Resize that moves the Rectangle under
+// or away from the mouse counts as well. All Events are passed along, before the hover Events
+// they cause.
+type HoverTracker struct{}
+
+func (HoverTracker) Intercept(parent Env) Env {
+	var (
+		bounds image.Rectangle
+		mouse  image.Point
+		seen   bool // whether the mouse position is known
+		over   bool
+	)
+
+	update := func(c chan<- Event) {
+		if in := seen && mouse.In(bounds); in != over {
+			over = in
+			if over {
+				c <- HoverEnter{mouse}
+			} else {
+				c <- HoverLeave{mouse}
+			}
+		}
+	}
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			c <- e
+			switch e := e.(type) {
+			case Resize:
+				bounds = e.Rectangle
+				update(c)
+			case MoMove:
+				mouse, seen = e.Point, true
+				update(c)
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
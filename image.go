@@ -0,0 +1,69 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // register decoders for LoadImage
+	_ "image/png"
+	"io"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// LoadImage decodes a PNG, JPEG, or WebP image into an RGBA image whose bounds start at 0, 0.
+func LoadImage(r io.Reader) (*image.RGBA, error) {
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("LoadImage: %v", err)
+	}
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba, nil
+	}
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	return rgba, nil
+}
+
+// Interpolation is the way DrawScaled computes the pixels of a scaled image.
+type Interpolation int
+
+const (
+	// Nearest picks the closest source pixel. It is fast, and keeps pixel art sharp.
+	Nearest Interpolation = iota
+	// Bilinear blends the four closest source pixels. It is smoother, but slower.
+	Bilinear
+)
+
+// DrawScaled draws src over dst, scaled to fill r, and returns the changed part of dst.
+func DrawScaled(dst draw.Image, r image.Rectangle, src image.Image, interp Interpolation) image.Rectangle {
+	r = r.Canon()
+	changed := r.Intersect(dst.Bounds())
+	if changed.Empty() || src.Bounds().Empty() {
+		return image.Rectangle{}
+	}
+	var scaler xdraw.Scaler = xdraw.NearestNeighbor
+	if interp == Bilinear {
+		scaler = xdraw.BiLinear
+	}
+	scaler.Scale(dst, r, src, src.Bounds(), xdraw.Over, nil)
+	return changed
+}
+
+// FitRect returns the largest Rectangle with the aspect ratio of size that fits in r, centered
+// in it. It can be passed to DrawScaled to show a whole image without distorting it.
+func FitRect(size image.Point, r image.Rectangle) image.Rectangle {
+	if size.X <= 0 || size.Y <= 0 {
+		return image.Rectangle{}
+	}
+	w, h := r.Dx(), r.Dy()
+	if w*size.Y > h*size.X {
+		w = h * size.X / size.Y
+	} else {
+		h = w * size.Y / size.X
+	}
+	min := r.Min.Add(image.Pt((r.Dx()-w)/2, (r.Dy()-h)/2))
+	return image.Rectangle{min, min.Add(image.Pt(w, h))}
+}
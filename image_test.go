@@ -0,0 +1,50 @@
+package gui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestLoadImage(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(3, 4, 5, 6))
+	src.Set(3, 4, color.White)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+	img, err := LoadImage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b != image.Rect(0, 0, 2, 2) {
+		t.Errorf("bounds %v; wanted %v", b, image.Rect(0, 0, 2, 2))
+	}
+	if got := img.RGBAAt(0, 0); got != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("pixel 0,0 = %v; wanted white", got)
+	}
+}
+
+func TestDrawScaled(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{0xff, 0, 0, 0xff})
+	src.SetRGBA(1, 0, color.RGBA{0, 0, 0xff, 0xff})
+	dst := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+	r := DrawScaled(dst, image.Rect(-4, 0, 4, 4), src, Nearest)
+	if want := image.Rect(0, 0, 4, 4); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	if got := dst.RGBAAt(1, 1); got != (color.RGBA{0, 0, 0xff, 0xff}) {
+		t.Errorf("pixel 1,1 = %v; wanted blue", got)
+	}
+}
+
+func TestFitRect(t *testing.T) {
+	got := FitRect(image.Pt(200, 100), image.Rect(0, 0, 100, 100))
+	if want := image.Rect(0, 25, 100, 75); got != want {
+		t.Errorf("FitRect = %v; wanted %v", got, want)
+	}
+}
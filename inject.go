@@ -0,0 +1,73 @@
+package gui
+
+import "git.samanthony.xyz/share"
+
+// NewInjector makes an Env that passes along the Events of the parent, along with any Event sent to
+// the returned channel.
+//
+// This lets background goroutines deliver their own Events, such as "download finished"
+// notifications, through the same Events() channel as input, so an element can handle both in
+// one select loop. Injected Events are only seen by the returned Env and its children.
+//
+// The inject channel should be closed when it is no longer used.
+func NewInjector(parent Env) (Env, chan<- Event) {
+	inject := make(chan Event)
+	done := make(chan bool)
+	merged := share.NewQueue[Event]()
+
+	go func() {
+		defer drain(inject)
+		defer close(merged.Enqueue)
+		injecting := (<-chan Event)(inject)
+		for {
+			select {
+			case e, ok := <-parent.Events():
+				if !ok {
+					return
+				}
+				merged.Enqueue <- e
+			case e, ok := <-injecting:
+				if !ok {
+					injecting = nil // keep forwarding the parent's Events
+					continue
+				}
+				merged.Enqueue <- e
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	env := newEnv(injectedEnv{parent, merged.Dequeue},
+		send, // forward events un-modified
+		send, // forward draw functions un-modified
+		func() {
+			close(done)
+		})
+	return env, inject
+}
+
+// injectedEnv is an Env whose Events come from a different channel than the Env's own.
+type injectedEnv struct {
+	Env
+	events <-chan Event
+}
+
+func (ie injectedEnv) Events() <-chan Event {
+	return ie.events
+}
+
+// chainEnv is the innermost Env of a chain of Envs made on top of each other. Killing it kills
+// the outermost one, and with it the whole chain, so none of them stays attached to the parent.
+type chainEnv struct {
+	Env
+	outer Killable
+}
+
+func (ce chainEnv) Kill() chan<- bool {
+	return ce.outer.Kill()
+}
+
+func (ce chainEnv) Dead() <-chan bool {
+	return ce.outer.Dead()
+}
@@ -0,0 +1,70 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestInjector(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 100))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env, inject := NewInjector(root)
+	defer close(inject)
+
+	if got, ok := tryRecv(env.Events(), timeout); !ok {
+		t.Fatalf("no Event received after %v", timeout)
+	} else if _, isResize := (*got).(Resize); !isResize {
+		t.Errorf("received %v; wanted Resize", *got)
+	}
+
+	want := dummyEvent{"download/done"}
+	if !trySend(inject, Event(want), timeout) {
+		t.Fatalf("failed to inject Event after %v", timeout)
+	}
+	if got, ok := tryRecv(env.Events(), timeout); !ok {
+		t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+	} else if *got != want {
+		t.Errorf("received %v; wanted %v", *got, want)
+	}
+
+	root.events.Enqueue <- dummyEvent{"input"}
+	if got, ok := tryRecv(env.Events(), timeout); !ok || *got != (dummyEvent{"input"}) {
+		t.Errorf("parent Event not passed along after injecting")
+	}
+}
+
+func TestRefresher(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env, refresh := NewRefresher(root)
+
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			refresh()
+		}
+		if got, ok := tryRecv(env.Events(), timeout); !ok {
+			t.Fatalf("no Event received after %v", timeout)
+		} else if *got != (Resize{rect}) {
+			t.Errorf("received %v; wanted %v", *got, Resize{rect})
+		}
+	}
+}
+
+// Killing a refresher frees its parent for another Env.
+func TestRefresherKill(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 100))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env, refresh := NewRefresher(root)
+	expectDetached(t, root, env)
+	refresh() // does nothing once dead
+}
@@ -0,0 +1,114 @@
+package gui
+
+// Prioritize makes an Env that delivers the Events of parent in two lanes. Events accepted by
+// urgent overtake all other pending Events, while the order within each lane is kept.
+//
+// This keeps an element responsive when a lot of Events pile up in its queue, e.g. when the
+// application posts large batches of custom Events. The first Event, which is always a Resize,
+// is never overtaken.
+//
+// InputFirst is a good choice for urgent in interactive elements.
+func Prioritize(parent Env, urgent func(Event) bool) Env {
+	enqueue, dequeue := newLaneQueue(urgent, nil)
+	return newQueuedEnv(parent, enqueue, dequeue,
+		send, // forward events un-modified
+		send, // forward draw functions un-modified
+		func() {})
+}
+
+// CriticalFirst accepts Resize and WiClose Events, so that an element being flooded with input
+// still resizes and closes promptly.
+func CriticalFirst(e Event) bool {
+	switch e.(type) {
+	case Resize, WiClose:
+		return true
+	}
+	return false
+}
+
+// InputFirst accepts mouse and keyboard Events.
+func InputFirst(e Event) bool {
+	switch e.(type) {
+	case MoMove, MoRelMove, MoDown, MoUp, MoScroll, KbType, KbDown, KbUp, KbRepeat:
+		return true
+	}
+	return false
+}
+
+// newLaneQueue makes an unlimited queue of Events with two lanes. Events accepted by urgent
+// are dequeued before all others once the first Event has been dequeued.
+//
+// If merge is not nil, each Event is first offered to merge along with the last pending Event
+// of its lane. If merge accepts, the merged Event replaces the pending one.
+//
+// Closing enqueue closes dequeue, discarding any pending Events.
+func newLaneQueue(urgent func(Event) bool, merge func(prev, next Event) (Event, bool)) (chan<- Event, <-chan Event) {
+	in := make(chan Event)
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+
+		var fast, slow []Event
+		first := true
+		for {
+			var (
+				next Event
+				outc chan<- Event
+			)
+			if len(fast) > 0 {
+				next, outc = fast[0], out
+			} else if len(slow) > 0 {
+				next, outc = slow[0], out
+			}
+
+			select {
+			case e, ok := <-in:
+				if !ok {
+					return
+				}
+				if !first && urgent(e) {
+					fast = enqueueMerged(fast, e, merge)
+				} else {
+					slow = enqueueMerged(slow, e, merge)
+				}
+			case outc <- next:
+				if len(fast) > 0 {
+					fast = fast[1:]
+				} else {
+					slow = slow[1:]
+				}
+				if first {
+					first = false
+					// Events queued behind the first one may now be sorted into lanes.
+					fast, slow = splitLanes(slow, urgent)
+				}
+			}
+		}
+	}()
+
+	return in, out
+}
+
+// enqueueMerged appends e to lane, or merges it into the last Event of lane.
+func enqueueMerged(lane []Event, e Event, merge func(prev, next Event) (Event, bool)) []Event {
+	if n := len(lane); n > 0 && merge != nil {
+		if merged, ok := merge(lane[n-1], e); ok {
+			lane[n-1] = merged
+			return lane
+		}
+	}
+	return append(lane, e)
+}
+
+// splitLanes divides events into those accepted by urgent and the rest, keeping their order.
+func splitLanes(events []Event, urgent func(Event) bool) (fast, slow []Event) {
+	for _, e := range events {
+		if urgent(e) {
+			fast = append(fast, e)
+		} else {
+			slow = append(slow, e)
+		}
+	}
+	return fast, slow
+}
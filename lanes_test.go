@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+// Urgent Events overtake others, but never the first one.
+func TestLaneQueue(t *testing.T) {
+	enqueue, dequeue := newLaneQueue(InputFirst, nil)
+	defer close(enqueue)
+
+	resize := Resize{image.Rect(0, 0, 10, 10)}
+	events := []Event{
+		resize,
+		dummyEvent{"foo"},
+		MoMove{image.Pt(1, 2)},
+		dummyEvent{"bar"},
+		KbType{'x'},
+	}
+	for _, e := range events {
+		if !trySend(enqueue, e, timeout) {
+			t.Fatalf("queue did not accept %v after %v", e, timeout)
+		}
+	}
+
+	expect := []Event{resize, MoMove{image.Pt(1, 2)}, KbType{'x'}, dummyEvent{"foo"}, dummyEvent{"bar"}}
+	for _, want := range expect {
+		got, ok := tryRecv(dequeue, timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v", timeout)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+}
+
+// Resize and WiClose overtake input, which is still merged within its lane.
+func TestLaneQueueCritical(t *testing.T) {
+	enqueue, dequeue := newLaneQueue(CriticalFirst, coalesce)
+	defer close(enqueue)
+
+	first := Resize{image.Rect(0, 0, 10, 10)}
+	resize := Resize{image.Rect(0, 0, 20, 20)}
+	events := []Event{first, MoMove{image.Pt(1, 1)}, MoMove{image.Pt(2, 2)}, resize, WiClose{}}
+	for _, e := range events {
+		if !trySend(enqueue, e, timeout) {
+			t.Fatalf("queue did not accept %v after %v", e, timeout)
+		}
+	}
+
+	expect := []Event{first, resize, WiClose{}, MoMove{image.Pt(2, 2)}}
+	for _, want := range expect {
+		got, ok := tryRecv(dequeue, timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v", timeout)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+}
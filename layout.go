@@ -26,23 +26,40 @@ type Partitioner interface {
 //
 // The Scheme determines the look and behavior of the Layout. Resize events for each child
 // are modified according to the Partitioner. Other Events and draw functions can be modified
-// by the Intercepter.
+// by the Intercepter. If the Scheme is a SizedPartitioner, the children can tell it the size
+// they need with Prefer. If the Scheme is Routed, the children only get the mouse Events that
+// happen over them.
 //
 // Killing the returned layout kills all of the children.
 func NewLayout(parent Env, children []*Env, scheme Scheme) Killable {
-	env := newEnv(parent, send, send, func() {})
+	env, inject := NewInjector(parent)
+	l := &layout{hints: make([]Sizer, len(children)), inject: inject}
 
-	// Capture Resize Events to be sent to the Partitioner.
-	resizeSniffer, resizes := newSniffer(env, func(e Event) (r image.Rectangle, ok bool) {
+	// Remember the last Resize, so that it can be injected again when a size hint changes.
+	top := newEnv(env,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				l.mu.Lock()
+				l.bounds = resize.Rectangle
+				l.mu.Unlock()
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+
+	intercepter := scheme.Intercept(top)
+
+	// Capture Resize Events to be sent to the Partitioner. They are captured after the
+	// Intercepter, so that it can re-partition the children by emitting a Resize.
+	resizeSniffer, resizes := newSniffer(intercepter, func(e Event) (r image.Rectangle, ok bool) {
 		if resize, ok := e.(Resize); ok {
 			return resize.Rectangle, true
 		}
 		return image.Rectangle{}, false
 	})
 
-	intercepter := scheme.Intercept(resizeSniffer)
-
-	mux := NewMux(intercepter)
+	mux := NewMux(resizeSniffer)
 	muxEnvs := make([]Env, len(children))
 	resizers := make([]Env, len(children))
 	resizerChans := make([]chan image.Rectangle, len(children))
@@ -50,18 +67,30 @@ func NewLayout(parent Env, children []*Env, scheme Scheme) Killable {
 		muxEnvs[i] = mux.MakeEnv()
 		resizerChans[i] = make(chan image.Rectangle)
 		resizers[i] = newResizer(muxEnvs[i], resizerChans[i])
-		*child = resizers[i]
+		if _, ok := scheme.(Routed); ok {
+			resizers[i] = PointerRouter{}.Intercept(resizers[i])
+		}
+		*child = layoutChild{resizers[i], l, i}
 	}
 
 	go func() {
 		for rect := range resizes {
-			for i, r := range scheme.Partition(rect) {
+			var rects []image.Rectangle
+			// Until a child tells its size, the Scheme partitions as it would without hints.
+			hints, hinted := l.sizeHints()
+			if sp, ok := scheme.(SizedPartitioner); ok && hinted {
+				rects = sp.PartitionSized(rect, hints)
+			} else {
+				rects = scheme.Partition(rect)
+			}
+			for i, r := range rects {
 				resizerChans[i] <- r
 			}
 		}
 		for _, c := range resizerChans {
 			close(c)
 		}
+		l.close()
 	}()
 
 	return env
@@ -2,7 +2,9 @@ package gui
 
 import (
 	"image"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSniffer(t *testing.T) {
@@ -94,3 +96,146 @@ func TestResizer(t *testing.T) {
 		root.events.Enqueue <- Resize{image.Rectangle{}}
 	}
 }
+
+func TestGridPartitionSized(t *testing.T) {
+	g := Grid{Rows: []int{2, 1}}
+	hints := []SizeHint{
+		{Min: image.Pt(30, 10), Preferred: image.Pt(60, 20)},
+		{},
+		{Min: image.Pt(0, 40), Preferred: image.Pt(0, 40)},
+	}
+	got := g.PartitionSized(image.Rect(0, 0, 100, 100), hints)
+	want := []image.Rectangle{
+		image.Rect(0, 0, 80, 40), image.Rect(80, 0, 100, 40),
+		image.Rect(0, 40, 100, 100),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// Minimum sizes that don't fit are scaled down.
+	if got, want := sizedSplit(50, []int{60, 40}, []int{60, 40}), []int{30, 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sizedSplit = %v; wanted %v", got, want)
+	}
+}
+
+func TestDynamicLayout(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 100))
+	go drain(root.drawOut)
+	dl := NewDynamicLayout(root, func(n int) Scheme {
+		rows := make([]int, n)
+		for i := range rows {
+			rows[i] = 1
+		}
+		return Grid{Rows: rows}
+	})
+
+	expect := func(env Env, want image.Rectangle) {
+		t.Helper()
+		eventp, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Resize event received after %v", timeout)
+		}
+		if *eventp != (Resize{want}) {
+			t.Errorf("received %v; wanted %v", *eventp, Resize{want})
+		}
+	}
+
+	a := dl.Add()
+	expect(a, image.Rect(0, 0, 100, 100))
+	b := dl.Add()
+	expect(a, image.Rect(0, 0, 100, 50))
+	expect(b, image.Rect(0, 50, 100, 100))
+
+	dl.Remove(a)
+	expect(b, image.Rect(0, 0, 100, 100))
+	if _, ok := <-a.Events(); ok {
+		t.Errorf("removed child is still alive")
+	}
+}
+
+func TestPointerRouter(t *testing.T) {
+	left, right := newDummyEnv(image.Rect(0, 0, 10, 10)), newDummyEnv(image.Rect(10, 0, 20, 10))
+	defer func() {
+		for _, root := range []dummyEnv{left, right} {
+			root.kill <- true
+			<-root.dead
+		}
+	}()
+	envs := []Env{PointerRouter{}.Intercept(left), PointerRouter{}.Intercept(right)}
+	for _, env := range envs {
+		if _, ok := tryRecv(env.Events(), timeout); !ok {
+			t.Fatalf("no Resize event received after %v", timeout)
+		}
+	}
+
+	for _, test := range []struct {
+		e    Event
+		want int // index of the Env that should get e, or -1
+	}{
+		{MoMove{image.Pt(5, 5)}, 0},
+		{MoMove{image.Pt(15, 5)}, 1},
+		{MoScroll{Point: image.Pt(0, 1)}, 1},
+		{MoDown{image.Pt(15, 5), ButtonLeft}, 1},
+		{MoMove{image.Pt(5, 5)}, 1}, // grabbed
+		{MoDown{image.Pt(5, 5), ButtonRight}, 1},
+		{MoUp{image.Pt(5, 5), ButtonLeft}, 1},
+		{MoUp{image.Pt(5, 5), ButtonRight}, 1},
+		{MoMove{image.Pt(6, 5)}, 0},
+		{MoMove{image.Pt(50, 50)}, -1},
+	} {
+		left.events.Enqueue <- test.e
+		right.events.Enqueue <- test.e
+		for i, env := range envs {
+			_, got := tryRecv(env.Events(), 10*time.Millisecond)
+			if want := i == test.want; got != want {
+				t.Errorf("%v: Env %d received it: %v; wanted %v", test.e, i, got, want)
+			}
+		}
+	}
+}
+
+func TestLayoutSplit(t *testing.T) {
+	ninety := func(elements, space int) []int {
+		if elements == 1 {
+			return []int{space}
+		}
+		return []int{space * 9 / 10, space - space*9/10}
+	}
+	// await waits for env to be resized to want, skipping earlier Resizes.
+	await := func(env Env, want image.Rectangle) {
+		t.Helper()
+		for {
+			eventp, ok := tryRecv(env.Events(), timeout)
+			if !ok {
+				t.Fatalf("no Resize to %v received after %v", want, timeout)
+			}
+			if *eventp == (Resize{want}) {
+				return
+			}
+		}
+	}
+
+	for _, scheme := range []Scheme{
+		Grid{Rows: []int{2}, Split: ninety},
+		Routed{Grid{Rows: []int{2}, Split: ninety}},
+	} {
+		root := newDummyEnv(image.Rect(0, 0, 100, 100))
+		go drain(root.drawOut)
+		var a, b Env
+		NewLayout(root, []*Env{&a, &b}, scheme)
+		await(a, image.Rect(0, 0, 90, 100))
+		await(b, image.Rect(90, 0, 100, 100))
+	}
+
+	// The Split of rows without size hints is kept when other rows have them.
+	root := newDummyEnv(image.Rect(0, 0, 100, 100))
+	go drain(root.drawOut)
+	var a, b, c Env
+	NewLayout(root, []*Env{&a, &b, &c}, Grid{Rows: []int{2, 1}, Split: ninety})
+	go drain(b.Events())
+	go drain(c.Events())
+	await(a, image.Rect(0, 0, 90, 50))
+	Prefer(c, SizeHint{Min: image.Pt(0, 80), Preferred: image.Pt(0, 80)})
+	await(a, image.Rect(0, 0, 90, 10))
+}
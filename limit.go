@@ -0,0 +1,104 @@
+package gui
+
+// QueuePolicy tells LimitEvents what to do when its queue is full.
+type QueuePolicy int
+
+const (
+	// DropOldest drops the oldest pending Event to make room for a new one.
+	DropOldest QueuePolicy = iota
+	// DropMovesFirst drops the oldest pending MoMove or MoRelMove, or the oldest Event if
+	// there is none.
+	DropMovesFirst
+	// Block leaves new Events in the queue of the parent until there is room.
+	Block
+)
+
+// LimitEvents makes an Env that keeps at most limit pending Events, handling any more according
+// to policy. This bounds the memory taken by Events that pile up while the element is busy,
+// e.g. with a long-running draw function.
+//
+// Resize and WiClose Events are never dropped, so the queue may exceed the limit by them.
+//
+// With Block, the Events pile up in the queue of the parent instead, so a parent that limits
+// its own queue is needed to bound the memory.
+func LimitEvents(parent Env, limit int, policy QueuePolicy) Env {
+	if limit < 1 {
+		limit = 1
+	}
+	closer := make(chan Event)
+	events := newLimitedQueue(parent.Events(), closer, limit, policy)
+	// The queue receives the Events of parent itself, so the Env only has to forward draws.
+	return newQueuedEnv(injectedEnv{parent, nil}, closer, events,
+		send, // no events reach the filter
+		send, // forward draw functions un-modified
+		func() {})
+}
+
+// newLimitedQueue makes a queue of at most limit Events received from src. The returned channel
+// is closed when closer is closed, discarding any pending Events.
+func newLimitedQueue(src <-chan Event, closer <-chan Event, limit int, policy QueuePolicy) <-chan Event {
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+
+		var pending []Event
+		for {
+			var (
+				next Event
+				outc chan<- Event
+				in   = src
+			)
+			if len(pending) > 0 {
+				next, outc = pending[0], out
+			}
+			if len(pending) >= limit && policy == Block {
+				in = nil
+			}
+
+			select {
+			case e, ok := <-in:
+				if !ok {
+					src = nil // the parent died; wait to be closed
+					continue
+				}
+				if len(pending) >= limit && droppable(e) {
+					pending = dropOne(pending, policy)
+				}
+				pending = append(pending, e)
+			case outc <- next:
+				pending = pending[1:]
+			case <-closer:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// dropOne removes one Event from the full queue pending according to policy.
+func dropOne(pending []Event, policy QueuePolicy) []Event {
+	if policy == DropMovesFirst {
+		for i, e := range pending {
+			switch e.(type) {
+			case MoMove, MoRelMove:
+				return append(pending[:i], pending[i+1:]...)
+			}
+		}
+	}
+	for i, e := range pending {
+		if droppable(e) {
+			return append(pending[:i], pending[i+1:]...)
+		}
+	}
+	return pending
+}
+
+func droppable(e Event) bool {
+	switch e.(type) {
+	case Resize, WiClose:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package gui
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestLimitedQueue(t *testing.T) {
+	a, b, c := KbType{'a'}, KbType{'b'}, KbType{'c'}
+	move := MoMove{image.Pt(1, 1)}
+	resize := Resize{image.Rect(0, 0, 10, 10)}
+
+	for _, test := range []struct {
+		name   string
+		limit  int
+		policy QueuePolicy
+		in     []Event
+		want   []Event
+	}{
+		{"DropOldest", 2, DropOldest, []Event{move, a, b}, []Event{a, b}},
+		{"DropMovesFirst", 2, DropMovesFirst, []Event{a, move, b}, []Event{a, b}},
+		{"DropMovesFirst without moves", 2, DropMovesFirst, []Event{a, b, c}, []Event{b, c}},
+		{"Resize kept", 1, DropOldest, []Event{resize, a, b}, []Event{resize, b}},
+	} {
+		src := make(chan Event)
+		closer := make(chan Event)
+		out := newLimitedQueue(src, closer, test.limit, test.policy)
+		for _, e := range test.in {
+			if !trySend(src, e, timeout) {
+				t.Fatalf("%s: %v not accepted after %v", test.name, e, timeout)
+			}
+		}
+		for _, want := range test.want {
+			got, ok := tryRecv(out, timeout)
+			if !ok {
+				t.Fatalf("%s: no Event received after %v; wanted %v", test.name, timeout, want)
+			}
+			if *got != want {
+				t.Errorf("%s: received %v; wanted %v", test.name, *got, want)
+			}
+		}
+		close(closer)
+	}
+}
+
+// With Block, a full queue leaves new Events with the sender.
+func TestLimitedQueueBlock(t *testing.T) {
+	src := make(chan Event)
+	closer := make(chan Event)
+	defer close(closer)
+	out := newLimitedQueue(src, closer, 1, Block)
+
+	var a, b Event = KbType{'a'}, KbType{'b'}
+	if !trySend(src, a, timeout) {
+		t.Fatalf("%v not accepted after %v", a, timeout)
+	}
+	if trySend(src, b, 50*time.Millisecond) {
+		t.Fatalf("%v accepted by a full queue", b)
+	}
+	got, ok := tryRecv(out, timeout)
+	if !ok {
+		t.Fatalf("no Event received after %v; wanted %v", timeout, a)
+	}
+	if *got != a {
+		t.Errorf("received %v; wanted %v", *got, a)
+	}
+	if !trySend(src, b, timeout) {
+		t.Errorf("%v not accepted after %v once there was room", b, timeout)
+	}
+}
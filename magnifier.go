@@ -0,0 +1,131 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var _ Intercepter = Magnifier{}
+
+// Magnifier is an Intercepter that shows a magnified view of the area under the mouse cursor
+// on top of everything drawn through it.
+type Magnifier struct {
+	// Zoom is the magnification factor. Values below 2 are treated as 2.
+	Zoom int
+	// Size is the width and height of the lens in pixels. Defaults to 160.
+	Size int
+	// BorderColor is the color of the lens' 1 pixel wide border. Defaults to black.
+	BorderColor color.Color
+}
+
+func (m Magnifier) Intercept(parent Env) Env {
+	lens := &lens{
+		zoom:   m.Zoom,
+		size:   m.Size,
+		border: m.BorderColor,
+	}
+	if lens.zoom < 2 {
+		lens.zoom = 2
+	}
+	if lens.size <= 0 {
+		lens.size = 160
+	}
+	if lens.border == nil {
+		lens.border = color.Black
+	}
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if mm, ok := e.(MoMove); ok {
+				parent.Draw() <- func(drw draw.Image) image.Rectangle {
+					r := lens.erase(drw)
+					lens.pos = mm.Point
+					lens.visible = true
+					return r.Union(lens.paint(drw))
+				}
+			}
+			c <- e
+		},
+		func(d func(draw.Image) image.Rectangle, c chan<- func(draw.Image) image.Rectangle) {
+			c <- func(drw draw.Image) image.Rectangle {
+				lens.erase(drw)
+				r := d(drw)
+				return r.Union(lens.paint(drw))
+			}
+		},
+		func() {})
+}
+
+// lens is only accessed from draw functions, which are executed one at a time.
+type lens struct {
+	zoom, size int
+	border     color.Color
+
+	pos     image.Point
+	visible bool
+
+	bounds image.Rectangle // area currently covered by the lens
+	under  *image.RGBA     // pixels covered by the lens
+	src    *image.RGBA     // scratch copy of the magnified area
+}
+
+// erase restores the pixels under the lens and returns the restored area.
+func (l *lens) erase(drw draw.Image) image.Rectangle {
+	r := l.bounds
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+	draw.Draw(drw, r, l.under, r.Min, draw.Src)
+	l.bounds = image.Rectangle{}
+	return r
+}
+
+// paint draws the lens centered on the cursor and returns the area it covers.
+func (l *lens) paint(drw draw.Image) image.Rectangle {
+	if !l.visible {
+		return image.Rectangle{}
+	}
+	half := image.Pt(l.size/2, l.size/2)
+	r := image.Rectangle{l.pos.Sub(half), l.pos.Add(half)}.Intersect(drw.Bounds())
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+
+	if l.under == nil || !r.In(l.under.Bounds()) {
+		l.under = image.NewRGBA(r)
+	}
+	draw.Draw(l.under, r, drw, r.Min, draw.Src)
+
+	// The lens reaches floorDiv(-half, zoom) pixels to the left and above, which is a whole
+	// pixel further than half/zoom whenever half isn't a multiple of zoom.
+	srcMin := -floorDiv(-half.X, l.zoom)
+	srcMax := half.X / l.zoom
+	sr := image.Rectangle{l.pos.Sub(image.Pt(srcMin, srcMin)), l.pos.Add(image.Pt(srcMax+1, srcMax+1))}
+	if l.src == nil || l.src.Bounds() != sr {
+		l.src = image.NewRGBA(sr)
+	}
+	draw.Draw(l.src, sr, image.Transparent, image.ZP, draw.Src)
+	draw.Draw(l.src, sr, drw, sr.Min, draw.Src)
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			sx := l.pos.X + floorDiv(x-l.pos.X, l.zoom)
+			sy := l.pos.Y + floorDiv(y-l.pos.Y, l.zoom)
+			drw.Set(x, y, l.src.RGBAAt(sx, sy))
+		}
+	}
+	drawFrame(drw, r, 1, image.NewUniform(l.border))
+
+	l.bounds = r
+	return r
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,113 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestMagnifier(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 40, 40))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	env := Magnifier{Zoom: 2, Size: 10}.Intercept(root)
+
+	// Each column has its own shade, so the magnified columns can be told apart.
+	shade := func(x int) color.RGBA { return color.RGBA{uint8(x * 6), 0, 0, 0xff} }
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			img.SetRGBA(x, y, shade(x))
+		}
+	}
+	flush := func(wantChanged image.Rectangle) {
+		t.Helper()
+		d, ok := tryRecv(root.drawOut, timeout)
+		if !ok {
+			t.Fatalf("no draw function received after %v", timeout)
+		}
+		if r := (*d)(img); r != wantChanged {
+			t.Errorf("changed %v; wanted %v", r, wantChanged)
+		}
+	}
+	expect := func(x, y int, want color.RGBA) {
+		t.Helper()
+		if got := img.RGBAAt(x, y); got != want {
+			t.Errorf("pixel %d,%d = %v; wanted %v", x, y, got, want)
+		}
+	}
+
+	// The lens is drawn before the MoMove is passed along.
+	moved := func(pt image.Point) {
+		t.Helper()
+		for {
+			e, ok := tryRecv(env.Events(), timeout)
+			if !ok {
+				t.Fatalf("no Event received after %v", timeout)
+			}
+			if *e == (MoMove{pt}) {
+				return
+			}
+		}
+	}
+
+	// The lens is centered on the mouse, and shows the columns around it twice as wide.
+	root.events.Enqueue <- MoMove{image.Pt(20, 20)}
+	lens := image.Rect(15, 15, 25, 25)
+	flush(lens)
+	moved(image.Pt(20, 20))
+	expect(15, 20, color.RGBA{0, 0, 0, 0xff}) // border
+	expect(16, 20, shade(18))
+	expect(17, 20, shade(18))
+	expect(22, 20, shade(21))
+	expect(23, 20, shade(21))
+	expect(10, 20, shade(10))
+
+	// It stays on top of what is drawn through the Env.
+	env.Draw() <- func(drw draw.Image) image.Rectangle {
+		return image.Rectangle{}
+	}
+	flush(lens)
+	expect(16, 20, shade(18))
+
+	// Moving it restores the pixels it covered.
+	root.events.Enqueue <- MoMove{image.Pt(30, 30)}
+	flush(lens.Union(lens.Add(image.Pt(10, 10))))
+	moved(image.Pt(30, 30))
+	expect(16, 20, shade(16))
+	expect(22, 20, shade(22))
+}
+
+// When half of the Size isn't a multiple of the Zoom, the edges of the lens still show magnified
+// pixels rather than transparent ones.
+func TestMagnifierUneven(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 40, 40))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	_ = Magnifier{Zoom: 3, Size: 10}.Intercept(root)
+
+	shade := func(x int) color.RGBA { return color.RGBA{uint8(x * 6), 0, 0, 0xff} }
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			img.SetRGBA(x, y, shade(x))
+		}
+	}
+
+	root.events.Enqueue <- MoMove{image.Pt(20, 20)}
+	d, ok := tryRecv(root.drawOut, timeout)
+	if !ok {
+		t.Fatalf("no draw function received after %v", timeout)
+	}
+	(*d)(img)
+	for x, want := range map[int]color.RGBA{16: shade(18), 17: shade(19), 23: shade(21)} {
+		if got := img.RGBAAt(x, 20); got != want {
+			t.Errorf("pixel %d,20 = %v; wanted %v", x, got, want)
+		}
+	}
+}
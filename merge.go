@@ -0,0 +1,77 @@
+package gui
+
+// Merge makes an Env that passes along the Events of parent, along with the Events that run
+// emits. run is started in its own goroutine once the first Event of parent, which is a Resize,
+// has been passed along, and should return when done is closed, which happens when the Env dies.
+// If the Env dies before its first Event, run is still called, with done already closed.
+//
+// At most one Event from run waits to be received at a time: emitting another one replaces it.
+// An element that falls behind thus skips the Events in between, like with time.Ticker, instead
+// of having them pile up.
+//
+// Ticker, anim.Play and theme.NewEnv are built on Merge.
+func Merge(parent Env, run func(emit func(Event), done <-chan bool)) Env {
+	in := make(chan Event)
+	out := make(chan Event)
+	emitted := make(chan Event)
+	done := make(chan bool)
+
+	go func() {
+		defer close(out)
+
+		var queue []Event
+		pending := -1 // index of the Event from run in queue, or -1
+		for {
+			var (
+				next Event
+				outc chan<- Event
+			)
+			if len(queue) > 0 {
+				next, outc = queue[0], out
+			}
+
+			select {
+			case e, ok := <-in:
+				if !ok {
+					return
+				}
+				queue = append(queue, e)
+			case e := <-emitted:
+				if pending >= 0 {
+					queue = append(queue[:pending], queue[pending+1:]...)
+				}
+				pending = len(queue)
+				queue = append(queue, e)
+			case outc <- next:
+				queue = queue[1:]
+				if pending >= 0 {
+					pending--
+				}
+			}
+		}
+	}()
+
+	emit := func(e Event) {
+		select {
+		case emitted <- e:
+		case <-done:
+		}
+	}
+
+	started := false
+	return newQueuedEnv(parent, in, out,
+		func(e Event, c chan<- Event) {
+			c <- e
+			if !started {
+				started = true
+				go run(emit, done)
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {
+			close(done)
+			if !started {
+				go run(emit, done)
+			}
+		})
+}
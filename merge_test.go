@@ -0,0 +1,47 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+// Events from run come after the first Resize, and only the latest one waits to be received.
+func TestMerge(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+
+	emitted := make(chan bool)
+	stopped := make(chan bool)
+	env := Merge(root, func(emit func(Event), done <-chan bool) {
+		defer close(stopped)
+		for _, s := range []string{"a", "b", "c"} {
+			emit(dummyEvent{s})
+		}
+		close(emitted)
+		<-done
+	})
+
+	if _, ok := tryRecv(emitted, timeout); !ok {
+		t.Fatalf("run did not emit its Events after %v", timeout)
+	}
+	root.events.Enqueue <- dummyEvent{"parent"}
+	for _, want := range []Event{Resize{rect}, dummyEvent{"c"}, dummyEvent{"parent"}} {
+		got, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+
+	env.Kill() <- true
+	<-env.Dead()
+	if _, ok := tryRecv(stopped, timeout); !ok {
+		t.Errorf("run still running %v after the Env died", timeout)
+	}
+}
@@ -0,0 +1,198 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+)
+
+// DialogResult is how a modal Dialog was dismissed. Besides DialogOK and DialogCancel, any other
+// value can be used for custom choices, such as "save" or "discard".
+type DialogResult string
+
+const (
+	DialogOK     DialogResult = "ok"
+	DialogCancel DialogResult = "cancel"
+)
+
+// DialogManager shows modal Dialogs over the content of an Env. While a Dialog is open, everything
+// under it is covered by a dimmed backdrop and receives no input: mouse, keyboard, touch, and
+// gamepad Events only go to the Dialog opened last. Releasing buttons and keys is still passed
+// along, so nothing underneath is left thinking they're held.
+//
+// The DialogManager composites the content and the Dialogs like a Compositor.
+type DialogManager struct {
+	// Backdrop is drawn over everything under each Dialog. Defaults to black at half opacity.
+	// It must not be changed while a Dialog is open.
+	Backdrop color.Color
+
+	comp Compositor
+	mu   sync.Mutex
+	open []*Dialog // in the order they were opened
+}
+
+// Dialog is a modal dialog opened by a DialogManager.
+type Dialog struct {
+	dm     *DialogManager
+	layer  Env
+	mu     sync.Mutex
+	done   bool
+	result chan DialogResult
+}
+
+// NewDialogManager makes a DialogManager of the parent Env, and returns the Env of the content
+// under the Dialogs along with it.
+func NewDialogManager(parent Env) (*DialogManager, Env) {
+	dm := new(DialogManager)
+	// The input is routed before the Events are multiplexed, so that it reaches exactly one of the
+	// content and the Dialogs even while they open and close.
+	router := newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if !isInput(e) {
+				c <- e
+				return
+			}
+			top := dm.top()
+			if kd, ok := e.(KbDown); ok && kd.Key == KeyEscape && top != nil {
+				top.Dismiss(DialogCancel)
+				return
+			}
+			c <- routed{e, top}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+	dm.comp = NewCompositor(router)
+	content := newEnv(dm.comp.MakeLayer(0, 1), dm.receive(nil), send, func() {})
+	return dm, content
+}
+
+// routed is input for the Dialog to, or the content if to is nil. It is never passed along.
+type routed struct {
+	Event
+	to *Dialog
+}
+
+// isInput reports whether e is an Event a modal Dialog keeps from what's under it.
+func isInput(e Event) bool {
+	switch e.(type) {
+	case MoMove, MoRelMove, MoDown, MoScroll, KbType, KbPreedit, KbDown, KbRepeat, TouchDown, TouchMove, GamepadButton, GamepadAxis:
+		return true
+	}
+	return false
+}
+
+// receive returns a filter passing along the Events, and the input routed to d.
+func (dm *DialogManager) receive(d *Dialog) func(Event, chan<- Event) {
+	return func(e Event, c chan<- Event) {
+		if r, ok := e.(routed); ok {
+			if r.to == d {
+				c <- r.Event
+			}
+			return
+		}
+		c <- e
+	}
+}
+
+// top returns the Dialog opened last, or nil if none are open.
+func (dm *DialogManager) top() *Dialog {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if len(dm.open) == 0 {
+		return nil
+	}
+	return dm.open[len(dm.open)-1]
+}
+
+func (dm *DialogManager) remove(d *Dialog) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	dm.open, _ = remove(d, dm.open)
+}
+
+func (dm *DialogManager) backdrop() color.Color {
+	if dm.Backdrop == nil {
+		return color.Alpha16{0x8000}
+	}
+	return dm.Backdrop
+}
+
+// Open opens a Dialog of the given size, centered over the content and any Dialogs already open,
+// and returns its Env. The Env receives a Resize with the centered Rectangle whenever the parent of
+// the DialogManager is resized. Pressing Escape in the Dialog dismisses it with DialogCancel.
+func (dm *DialogManager) Open(size image.Point) (Env, *Dialog) {
+	d := &Dialog{
+		dm:     dm,
+		layer:  dm.comp.MakeLayer(1, 1),
+		result: make(chan DialogResult, 1),
+	}
+	dm.mu.Lock()
+	dm.open = append(dm.open, d)
+	dm.mu.Unlock()
+
+	backdrop := image.NewUniform(dm.backdrop())
+	receive := dm.receive(d)
+	env := newEnv(d.layer,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				bounds := resize.Rectangle
+				d.layer.Draw() <- func(drw draw.Image) image.Rectangle {
+					draw.Draw(drw, bounds, backdrop, image.Point{}, draw.Src)
+					return bounds
+				}
+				c <- Resize{center(bounds, size)}
+				return
+			}
+			receive(e, c)
+		},
+		send, // forward draw functions un-modified
+		func() {
+			// Killed along with the parent, rather than dismissed.
+			d.resolve(DialogCancel)
+			dm.remove(d)
+		})
+	return env, d
+}
+
+// center returns a Rectangle of the given size centered in bounds, shrunk to fit.
+func center(bounds image.Rectangle, size image.Point) image.Rectangle {
+	size.X, size.Y = min(size.X, bounds.Dx()), min(size.Y, bounds.Dy())
+	origin := bounds.Min.Add(bounds.Size().Sub(size).Div(2))
+	return image.Rectangle{origin, origin.Add(size)}
+}
+
+// Result returns a channel that receives how the Dialog was dismissed, and is closed after.
+func (d *Dialog) Result() <-chan DialogResult {
+	return d.result
+}
+
+// Dismiss closes the Dialog with the result r, killing its Env. Only the first call has an effect.
+func (d *Dialog) Dismiss(r DialogResult) {
+	if !d.resolve(r) {
+		return
+	}
+	d.dm.remove(d)
+	// The Env may be dismissed from its own goroutine, by Escape, so it's killed from another.
+	go func() {
+		d.layer.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, drw.Bounds(), image.Transparent, image.Point{}, draw.Src)
+			return drw.Bounds()
+		}
+		d.layer.Kill() <- true
+	}()
+}
+
+// resolve sends r on the Result channel, unless the Dialog already has a result. It reports
+// whether it sent r.
+func (d *Dialog) resolve(r DialogResult) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.done {
+		return false
+	}
+	d.done = true
+	d.result <- r
+	close(d.result)
+	return true
+}
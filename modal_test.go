@@ -0,0 +1,52 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDialogManager(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	root := newDummyEnv(rect)
+	go drain(root.drawOut)
+	dm, content := NewDialogManager(root)
+
+	// The Envs may receive the initial Resize more than once.
+	expect := func(env Env, name string, want Event) {
+		t.Helper()
+		for {
+			got, ok := tryRecv(env.Events(), timeout)
+			if !ok {
+				t.Fatalf("%s: no Event received after %v; wanted %v", name, timeout, want)
+			}
+			if _, resize := (*got).(Resize); resize && *got != want {
+				if _, wantResize := want.(Resize); !wantResize {
+					continue
+				}
+			}
+			if *got != want {
+				t.Errorf("%s: received %v; wanted %v", name, *got, want)
+			}
+			return
+		}
+	}
+	expect(content, "content", Resize{rect})
+
+	dialog, d := dm.Open(image.Pt(40, 20))
+	expect(dialog, "dialog", Resize{image.Rect(30, 40, 70, 60)})
+
+	// Input only reaches the Dialog, except for releases.
+	root.events.Enqueue <- MoDown{image.Pt(5, 5), ButtonLeft}
+	root.events.Enqueue <- MoUp{image.Pt(5, 5), ButtonLeft}
+	expect(dialog, "dialog", MoDown{image.Pt(5, 5), ButtonLeft})
+	expect(content, "content", MoUp{image.Pt(5, 5), ButtonLeft})
+
+	root.events.Enqueue <- KbDown{KeyEscape, "escape", 0}
+	if r, ok := tryRecv(d.Result(), timeout); !ok || *r != DialogCancel {
+		t.Fatalf("no DialogCancel result after Escape")
+	}
+	d.Dismiss(DialogOK) // no effect after the first
+
+	root.events.Enqueue <- MoDown{image.Pt(6, 6), ButtonLeft}
+	expect(content, "content", MoDown{image.Pt(6, 6), ButtonLeft})
+}
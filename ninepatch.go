@@ -0,0 +1,60 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+)
+
+// NinePatch is an image that can be drawn into a rectangle of any size without distorting its
+// corners, e.g. the background of a button or a panel.
+//
+// The image is sliced into nine parts by the edges of Center. The corners are drawn unscaled,
+// the edges are stretched along their length, and the center is stretched both ways.
+type NinePatch struct {
+	Image image.Image
+	// Center is the stretchable middle part of Image.
+	Center image.Rectangle
+}
+
+// Draw draws the NinePatch over dst, filling r, and returns the changed part of dst.
+// If r is too small for the corners, they are shrunk proportionally.
+func (np NinePatch) Draw(dst draw.Image, r image.Rectangle) image.Rectangle {
+	sub, ok := np.Image.(interface {
+		SubImage(image.Rectangle) image.Image
+	})
+	if !ok {
+		rgba := image.NewRGBA(np.Image.Bounds())
+		draw.Draw(rgba, rgba.Bounds(), np.Image, rgba.Bounds().Min, draw.Src)
+		sub = rgba
+	}
+
+	b := np.Image.Bounds()
+	c := np.Center.Intersect(b)
+	sx := [4]int{b.Min.X, c.Min.X, c.Max.X, b.Max.X}
+	sy := [4]int{b.Min.Y, c.Min.Y, c.Max.Y, b.Max.Y}
+	dx := ninePatchSlices(r.Min.X, r.Max.X, sx)
+	dy := ninePatchSlices(r.Min.Y, r.Max.Y, sy)
+
+	var changed image.Rectangle
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			src := image.Rect(sx[i], sy[j], sx[i+1], sy[j+1])
+			cell := image.Rect(dx[i], dy[j], dx[i+1], dy[j+1])
+			if src.Empty() || cell.Empty() {
+				continue
+			}
+			changed = changed.Union(DrawScaled(dst, cell, sub.SubImage(src), Nearest))
+		}
+	}
+	return changed
+}
+
+// ninePatchSlices returns the destination edges between min and max for the source edges s.
+func ninePatchSlices(min, max int, s [4]int) [4]int {
+	first, last := s[1]-s[0], s[3]-s[2]
+	if size := max - min; first+last > size {
+		first = first * size / (first + last)
+		last = size - first
+	}
+	return [4]int{min, min + first, max - last, max}
+}
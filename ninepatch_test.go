@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestNinePatch(t *testing.T) {
+	// A 6x6 image with 2 pixel insets, each of its nine parts filled with its own color.
+	colors := [3][3]color.RGBA{}
+	src := image.NewRGBA(image.Rect(0, 0, 6, 6))
+	for j := range 3 {
+		for i := range 3 {
+			colors[j][i] = color.RGBA{uint8(i * 100), uint8(j * 100), 0xff, 0xff}
+			for y := 2 * j; y < 2*j+2; y++ {
+				for x := 2 * i; x < 2*i+2; x++ {
+					src.SetRGBA(x, y, colors[j][i])
+				}
+			}
+		}
+	}
+	np := NinePatch{Image: src, Center: image.Rect(2, 2, 4, 4)}
+
+	for _, test := range []struct {
+		r      image.Rectangle
+		xs, ys [4]int // edges of the parts in dst
+	}{
+		{image.Rect(0, 0, 6, 6), [4]int{0, 2, 4, 6}, [4]int{0, 2, 4, 6}},
+		{image.Rect(10, 5, 40, 15), [4]int{10, 12, 38, 40}, [4]int{5, 7, 13, 15}},
+		// Smaller than the insets: the corners shrink and the rest is gone.
+		{image.Rect(0, 0, 3, 2), [4]int{0, 1, 1, 3}, [4]int{0, 1, 1, 2}},
+	} {
+		dst := image.NewRGBA(image.Rect(0, 0, 50, 20))
+		if changed := np.Draw(dst, test.r); changed != test.r {
+			t.Errorf("%v: changed %v; wanted %v", test.r, changed, test.r)
+		}
+		for j := range 3 {
+			for i := range 3 {
+				part := image.Rect(test.xs[i], test.ys[j], test.xs[i+1], test.ys[j+1])
+				for y := part.Min.Y; y < part.Max.Y; y++ {
+					for x := part.Min.X; x < part.Max.X; x++ {
+						if got := dst.RGBAAt(x, y); got != colors[j][i] {
+							t.Fatalf("%v: pixel %d,%d = %v; wanted %v", test.r, x, y, got, colors[j][i])
+						}
+					}
+				}
+			}
+		}
+	}
+}
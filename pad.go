@@ -0,0 +1,98 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Insets are distances from the edges of a Rectangle towards its inside.
+type Insets struct {
+	Top, Right, Bottom, Left int
+}
+
+// UniformInsets returns Insets of n on all sides.
+func UniformInsets(n int) Insets {
+	return Insets{n, n, n, n}
+}
+
+// Shrink returns r with the insets removed. It returns an empty Rectangle if nothing is left.
+func (in Insets) Shrink(r image.Rectangle) image.Rectangle {
+	// Not image.Rect, which would swap the coordinates of a negative size.
+	r = image.Rectangle{
+		image.Pt(r.Min.X+in.Left, r.Min.Y+in.Top),
+		image.Pt(r.Max.X-in.Right, r.Max.Y-in.Bottom),
+	}
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+	return r
+}
+
+// Pad makes an Env whose Resize events are shrunk by insets, leaving a margin around its area.
+// The margin is not painted; use Padding to fill it.
+func Pad(parent Env, insets Insets) Env {
+	return Padding{Insets: insets}.Intercept(parent)
+}
+
+var _ Intercepter = Padding{}
+
+// Padding is an Intercepter that shrinks Resize events by Insets, like Pad, and paints the margin
+// with an optional background and border, so Schemes don't all need their own gap fields.
+type Padding struct {
+	Insets Insets
+
+	// Background fills the margin. Nil leaves it unpainted.
+	Background color.Color
+
+	// Border is the width of a frame along the outer edges of the margin.
+	Border      int
+	BorderColor color.Color // defaults to black
+}
+
+func (p Padding) Intercept(parent Env) Env {
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			resize, ok := e.(Resize)
+			if !ok {
+				c <- e
+				return
+			}
+			if p.Background != nil || p.Border > 0 {
+				outer := resize.Rectangle
+				parent.Draw() <- func(drw draw.Image) image.Rectangle {
+					p.paint(drw, outer)
+					return outer
+				}
+			}
+			c <- Resize{p.Insets.Shrink(resize.Rectangle)}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
+
+// paint paints the margin of outer.
+func (p Padding) paint(drw draw.Image, outer image.Rectangle) {
+	if p.Background != nil {
+		inner := p.Insets.Shrink(outer)
+		if inner.Empty() {
+			inner = image.Rectangle{outer.Min, outer.Min}
+		}
+		bg := image.NewUniform(p.Background)
+		for _, side := range []image.Rectangle{
+			image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, inner.Min.Y), // top
+			image.Rect(outer.Min.X, inner.Max.Y, outer.Max.X, outer.Max.Y), // bottom
+			image.Rect(outer.Min.X, inner.Min.Y, inner.Min.X, inner.Max.Y), // left
+			image.Rect(inner.Max.X, inner.Min.Y, outer.Max.X, inner.Max.Y), // right
+		} {
+			draw.Draw(drw, side, bg, image.Point{}, draw.Src)
+		}
+	}
+	if p.Border > 0 {
+		col := p.BorderColor
+		if col == nil {
+			col = color.Black
+		}
+		drawFrame(drw, outer, p.Border, image.NewUniform(col))
+	}
+}
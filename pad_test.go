@@ -0,0 +1,27 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 50))
+	defer func() {
+		root.kill <- true
+		<-root.dead
+	}()
+	env := Pad(root, Insets{Top: 1, Right: 2, Bottom: 3, Left: 4})
+
+	eventp, ok := tryRecv(env.Events(), timeout)
+	if !ok {
+		t.Fatalf("no Resize event received after %v", timeout)
+	}
+	if want := (Resize{image.Rect(4, 1, 98, 47)}); *eventp != want {
+		t.Errorf("received %v; wanted %v", *eventp, want)
+	}
+
+	if r := UniformInsets(30).Shrink(image.Rect(0, 0, 100, 50)); !r.Empty() {
+		t.Errorf("Shrink = %v; wanted empty", r)
+	}
+}
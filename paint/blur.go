@@ -0,0 +1,104 @@
+package paint
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// blurPasses is the number of box blurs applied along each axis. Three passes are close enough
+// to a Gaussian blur to look smooth, while each pass stays linear in the number of pixels.
+const blurPasses = 3
+
+// Blur blurs the pixels of dst inside r, so that each pixel spreads over radius pixels in every
+// direction. Pixels outside r are not read; the edges of r are extended instead.
+//
+// The cost does not depend on the radius, so Blur is usable every frame on reasonably sized areas,
+// such as the backdrop of a popup.
+func Blur(dst draw.Image, r image.Rectangle, radius int) image.Rectangle {
+	r = r.Intersect(dst.Bounds())
+	if r.Empty() || radius <= 0 {
+		return image.Rectangle{}
+	}
+	buf := image.NewRGBA(r)
+	draw.Draw(buf, r, dst, r.Min, draw.Src)
+	boxBlur(buf.Pix, r.Dx(), r.Dy(), 4, radius)
+	draw.Draw(dst, r, buf, r.Min, draw.Src)
+	return r
+}
+
+// Shadow draws the blurred shadow of a rectangle with corners rounded by cornerRadius, as if r
+// was lifted above dst and lit from the opposite direction of offset. It should be drawn before
+// the element that casts it.
+//
+// The shadow spreads blur pixels beyond r moved by offset, and it returns the whole area it covers.
+func Shadow(dst draw.Image, r image.Rectangle, cornerRadius float64, blur int, offset image.Point, col color.Color) image.Rectangle {
+	if blur < 0 {
+		blur = 0
+	}
+	cast := r.Add(offset)
+	area := cast.Inset(-blur)
+	if area.Intersect(dst.Bounds()).Empty() {
+		return image.Rectangle{}
+	}
+
+	mask := image.NewAlpha(area)
+	Fill(mask, RoundedRect(cast, cornerRadius), color.Opaque)
+	boxBlur(mask.Pix, area.Dx(), area.Dy(), 1, blur)
+
+	drawn := area.Intersect(dst.Bounds())
+	draw.DrawMask(dst, drawn, image.NewUniform(col), image.Point{}, mask, drawn.Min, draw.Over)
+	return drawn
+}
+
+// boxBlur blurs the w×h image in pix, whose pixels consist of nc channels and whose rows are
+// packed tightly, by radius pixels in every direction.
+func boxBlur(pix []uint8, w, h, nc, radius int) {
+	if radius <= 0 || w == 0 || h == 0 {
+		return
+	}
+	tmp := make([]uint8, len(pix))
+	for pass := 0; pass < blurPasses; pass++ {
+		// Split the radius between the passes, so that they reach radius pixels together.
+		pr := radius / blurPasses
+		if pass < radius%blurPasses {
+			pr++
+		}
+		if pr == 0 {
+			continue
+		}
+		for y := 0; y < h; y++ {
+			for c := 0; c < nc; c++ {
+				boxLine(pix, tmp, y*w*nc+c, nc, w, pr)
+			}
+		}
+		for x := 0; x < w; x++ {
+			for c := 0; c < nc; c++ {
+				boxLine(tmp, pix, x*nc+c, w*nc, h, pr)
+			}
+		}
+	}
+}
+
+// boxLine averages each of the n values of src, starting at off and step apart, with its radius
+// neighbors on both sides, and writes the results to the same positions in dst.
+// The first and last values are repeated past the ends.
+func boxLine(src, dst []uint8, off, step, n, radius int) {
+	at := func(i int) int {
+		if i < 0 {
+			i = 0
+		} else if i >= n {
+			i = n - 1
+		}
+		return int(src[off+i*step])
+	}
+	div := 2*radius + 1
+	sum := 0
+	for i := -radius; i <= radius; i++ {
+		sum += at(i)
+	}
+	for i := 0; i < n; i++ {
+		dst[off+i*step] = uint8((sum + div/2) / div)
+		sum += at(i+radius+1) - at(i-radius)
+	}
+}
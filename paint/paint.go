@@ -0,0 +1,249 @@
+// Package paint draws anti-aliased paths, lines, rounded rectangles, and blurred shadows onto draw.Images.
+//
+// Every drawing function returns the Rectangle it changed, so it can be returned directly from
+// a draw function sent to an Env:
+//
+//	env.Draw() <- func(drw draw.Image) image.Rectangle {
+//		return paint.Border(drw, r, 2, 6, color.Black)
+//	}
+package paint
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/vector"
+)
+
+type point struct{ x, y float64 }
+
+type subpath struct {
+	pts    []point
+	closed bool
+}
+
+// Path is a sequence of lines and curves. Curves are flattened into lines as they are added.
+// The zero value is an empty Path.
+type Path struct {
+	subpaths []subpath
+}
+
+// MoveTo starts a new subpath at x, y.
+func (p *Path) MoveTo(x, y float64) {
+	p.subpaths = append(p.subpaths, subpath{pts: []point{{x, y}}})
+}
+
+// LineTo adds a line from the current point to x, y. It starts a new subpath if there is none.
+func (p *Path) LineTo(x, y float64) {
+	if len(p.subpaths) == 0 || p.last().closed {
+		p.MoveTo(x, y)
+		return
+	}
+	sp := p.last()
+	sp.pts = append(sp.pts, point{x, y})
+}
+
+// QuadTo adds a quadratic Bézier curve from the current point to x, y with the control point cx, cy.
+func (p *Path) QuadTo(cx, cy, x, y float64) {
+	p0 := p.current()
+	n := segments(p0, point{cx, cy}, point{x, y})
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		p.LineTo(u*u*p0.x+2*u*t*cx+t*t*x, u*u*p0.y+2*u*t*cy+t*t*y)
+	}
+}
+
+// CubeTo adds a cubic Bézier curve from the current point to x, y with the control points
+// c1x, c1y and c2x, c2y.
+func (p *Path) CubeTo(c1x, c1y, c2x, c2y, x, y float64) {
+	p0 := p.current()
+	n := segments(p0, point{c1x, c1y}, point{c2x, c2y}, point{x, y})
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		p.LineTo(a*p0.x+b*c1x+c*c2x+d*x, a*p0.y+b*c1y+c*c2y+d*y)
+	}
+}
+
+// Close closes the current subpath with a line back to its start.
+func (p *Path) Close() {
+	if len(p.subpaths) > 0 {
+		p.last().closed = true
+	}
+}
+
+func (p *Path) last() *subpath {
+	return &p.subpaths[len(p.subpaths)-1]
+}
+
+func (p *Path) current() point {
+	if len(p.subpaths) == 0 {
+		return point{}
+	}
+	sp := p.last()
+	if sp.closed {
+		return sp.pts[0]
+	}
+	return sp.pts[len(sp.pts)-1]
+}
+
+// segments returns how many lines a curve with the given control polygon is flattened into,
+// roughly one per 2 pixels.
+func segments(pts ...point) int {
+	var length float64
+	for i := 1; i < len(pts); i++ {
+		length += math.Hypot(pts[i].x-pts[i-1].x, pts[i].y-pts[i-1].y)
+	}
+	return max(4, min(256, int(math.Ceil(length/2))))
+}
+
+// RoundedRect returns a closed Path along r with corners rounded by radius.
+// The radius is limited to half the width and height of r.
+func RoundedRect(r image.Rectangle, radius float64) *Path {
+	x0, y0, x1, y1 := float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X), float64(r.Max.Y)
+	return roundedRect(x0, y0, x1, y1, radius)
+}
+
+func roundedRect(x0, y0, x1, y1, radius float64) *Path {
+	radius = math.Max(0, math.Min(radius, math.Min(x1-x0, y1-y0)/2))
+	k := radius * (1 - 0.5522847498) // distance of the control points from the corner
+	p := new(Path)
+	p.MoveTo(x0+radius, y0)
+	p.LineTo(x1-radius, y0)
+	p.CubeTo(x1-k, y0, x1, y0+k, x1, y0+radius)
+	p.LineTo(x1, y1-radius)
+	p.CubeTo(x1, y1-k, x1-k, y1, x1-radius, y1)
+	p.LineTo(x0+radius, y1)
+	p.CubeTo(x0+k, y1, x0, y1-k, x0, y1-radius)
+	p.LineTo(x0, y0+radius)
+	p.CubeTo(x0, y0+k, x0+k, y0, x0+radius, y0)
+	p.Close()
+	return p
+}
+
+// Circle returns a closed Path along the circle centered at cx, cy.
+func Circle(cx, cy, radius float64) *Path {
+	return roundedRect(cx-radius, cy-radius, cx+radius, cy+radius, radius)
+}
+
+// Fill fills the inside of p with col, anti-aliased. Subpaths are closed implicitly.
+func Fill(dst draw.Image, p *Path, col color.Color) image.Rectangle {
+	var polys [][]point
+	for _, sp := range p.subpaths {
+		polys = append(polys, sp.pts)
+	}
+	return fillPolygons(dst, polys, col)
+}
+
+// Stroke draws the outline of p, width pixels wide, with round joins and caps.
+func Stroke(dst draw.Image, p *Path, width float64, col color.Color) image.Rectangle {
+	half := width / 2
+	if half <= 0 {
+		return image.Rectangle{}
+	}
+	var polys [][]point
+	for _, sp := range p.subpaths {
+		pts := sp.pts
+		if sp.closed {
+			pts = append(pts[:len(pts):len(pts)], pts[0])
+		}
+		for i, a := range pts {
+			polys = append(polys, disc(a, half))
+			if i == 0 {
+				continue
+			}
+			if quad, ok := segmentQuad(pts[i-1], a, half); ok {
+				polys = append(polys, quad)
+			}
+		}
+	}
+	return fillPolygons(dst, polys, col)
+}
+
+// Line draws an anti-aliased line, width pixels wide, from x0, y0 to x1, y1 with round caps.
+func Line(dst draw.Image, x0, y0, x1, y1, width float64, col color.Color) image.Rectangle {
+	p := new(Path)
+	p.MoveTo(x0, y0)
+	p.LineTo(x1, y1)
+	return Stroke(dst, p, width, col)
+}
+
+// Border draws a frame, width pixels wide, along the inside edges of r with corners rounded
+// by radius.
+func Border(dst draw.Image, r image.Rectangle, width, radius float64, col color.Color) image.Rectangle {
+	half := width / 2
+	x0, y0, x1, y1 := float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X), float64(r.Max.Y)
+	p := roundedRect(x0+half, y0+half, x1-half, y1-half, radius-half)
+	return Stroke(dst, p, width, col)
+}
+
+// segmentQuad returns the rectangle covering the line from a to b, half pixels to each side.
+// All polygons are wound the same way, so that overlapping ones don't cancel out.
+func segmentQuad(a, b point, half float64) ([]point, bool) {
+	dx, dy := b.x-a.x, b.y-a.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil, false
+	}
+	nx, ny := -dy/length*half, dx/length*half
+	return []point{
+		{a.x + nx, a.y + ny},
+		{b.x + nx, b.y + ny},
+		{b.x - nx, b.y - ny},
+		{a.x - nx, a.y - ny},
+	}, true
+}
+
+// disc returns a polygon approximating the circle around c, wound like segmentQuad.
+func disc(c point, radius float64) []point {
+	n := max(8, min(128, int(math.Ceil(2*math.Pi*radius/2))))
+	pts := make([]point, n)
+	for i := range pts {
+		a := -2 * math.Pi * float64(i) / float64(n)
+		pts[i] = point{c.x + radius*math.Cos(a), c.y + radius*math.Sin(a)}
+	}
+	return pts
+}
+
+// fillPolygons fills the union of polys with col and returns the changed area.
+func fillPolygons(dst draw.Image, polys [][]point, col color.Color) image.Rectangle {
+	bounds := polygonBounds(polys).Intersect(dst.Bounds())
+	if bounds.Empty() {
+		return image.Rectangle{}
+	}
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	z.DrawOp = draw.Over
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	for _, poly := range polys {
+		if len(poly) < 2 {
+			continue
+		}
+		z.MoveTo(float32(poly[0].x-ox), float32(poly[0].y-oy))
+		for _, pt := range poly[1:] {
+			z.LineTo(float32(pt.x-ox), float32(pt.y-oy))
+		}
+		z.ClosePath()
+	}
+	z.Draw(dst, bounds, image.NewUniform(col), image.Point{})
+	return bounds
+}
+
+// polygonBounds returns the smallest Rectangle of whole pixels containing polys.
+func polygonBounds(polys [][]point) image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, poly := range polys {
+		for _, pt := range poly {
+			minX, minY = math.Min(minX, pt.x), math.Min(minY, pt.y)
+			maxX, maxY = math.Max(maxX, pt.x), math.Max(maxY, pt.y)
+		}
+	}
+	if minX > maxX || minY > maxY {
+		return image.Rectangle{}
+	}
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
@@ -0,0 +1,79 @@
+package paint
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFill(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	r := Fill(img, RoundedRect(image.Rect(5, 5, 15, 15), 0), red)
+	if want := image.Rect(5, 5, 15, 15); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	if got := img.RGBAAt(10, 10); got != red {
+		t.Errorf("inside = %v; wanted %v", got, red)
+	}
+	if got := img.RGBAAt(2, 2); got != (color.RGBA{}) {
+		t.Errorf("outside = %v; wanted transparent", got)
+	}
+}
+
+// Overlapping parts of a stroke must not cancel out.
+func TestStroke(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	p := new(Path)
+	p.MoveTo(2, 10)
+	p.LineTo(18, 10)
+	p.LineTo(10, 10) // back over the same line
+	Stroke(img, p, 4, color.Black)
+	for _, x := range []int{4, 10, 16} {
+		if got := img.RGBAAt(x, 10); got.A != 0xff {
+			t.Errorf("pixel %d,10 = %v; wanted opaque", x, got)
+		}
+	}
+}
+
+// Shapes reaching outside of the image are clipped.
+func TestClipped(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	r := Line(img, -20, 5, 30, 5, 2, color.Black)
+	if want := image.Rect(0, 4, 10, 6); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	if got := img.RGBAAt(0, 5); got.A == 0 {
+		t.Errorf("pixel 0,5 = %v; wanted painted", got)
+	}
+}
+
+func TestShadow(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	r := Shadow(img, image.Rect(10, 10, 30, 30), 0, 6, image.Pt(2, 2), color.Black)
+	if want := image.Rect(6, 6, 38, 38); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	if got := img.RGBAAt(22, 22); got.A != 0xff {
+		t.Errorf("center = %v; wanted opaque", got)
+	}
+	edge, outer := img.RGBAAt(12, 22).A, img.RGBAAt(7, 22).A
+	if !(0 < outer && outer < edge && edge < 0xff) {
+		t.Errorf("shadow does not fade out: %d at the edge, %d further out", edge, outer)
+	}
+}
+
+// Blurring a uniform area must not change it.
+func TestBlurUniform(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	col := color.RGBA{0x40, 0x80, 0xc0, 0xff}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = col.R, col.G, col.B, col.A
+	}
+	Blur(img, img.Bounds(), 5)
+	for _, p := range []image.Point{{0, 0}, {8, 8}, {15, 15}} {
+		if got := img.RGBAAt(p.X, p.Y); got != col {
+			t.Errorf("pixel %v = %v; wanted %v", p, got, col)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+	"runtime"
+	"sync"
+)
+
+// Parallel runs the draw functions of confined Envs concurrently when their areas don't overlap,
+// e.g. when many cells of a dashboard redraw at once. The zero value is ready to use.
+//
+// Draw functions are opaque, so the area they will change is only known if the Env is confined to
+// it. Envs made by Confine can only draw inside the Rectangle of the last Resize they received,
+// so the children of a layout can be confined and drawn in parallel:
+//
+//	var par gui.Parallel
+//	gui.NewLayout(win, []*gui.Env{&a, &b, &c}, scheme)
+//	a, b, c = par.Confine(a), par.Confine(b), par.Confine(c)
+type Parallel struct {
+	mu      sync.Mutex
+	pending []regionDraw
+	queued  uint64 // number of draw functions ever collected
+}
+
+// regionDraw is a draw function that only changes pixels inside r. seq is its place among the
+// draw functions collected by a Parallel.
+type regionDraw struct {
+	r   image.Rectangle
+	d   func(draw.Image) image.Rectangle
+	seq uint64
+}
+
+// Confine makes an Env whose draw functions can only change the pixels inside the Rectangle of
+// the last Resize it received, like Clip.
+//
+// Its draw functions are collected by the Parallel. The first one of them to be executed runs all
+// of the draw functions collected up to it, with the ones of disjoint areas in parallel, while the
+// rest have nothing left to do. Draw functions collected later wait for their own turn, so they
+// still run after the draw functions sent before them that couldn't be confined.
+func (p *Parallel) Confine(parent Env) Env {
+	var area image.Rectangle
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				area = resize.Rectangle
+			}
+			c <- e
+		},
+		func(d func(draw.Image) image.Rectangle, c chan<- func(draw.Image) image.Rectangle) {
+			if area.Empty() {
+				c <- d // nothing to confine it to yet
+				return
+			}
+			c <- p.collect(area, d)
+		},
+		func() {})
+}
+
+// collect adds d, confined to r, to the pending draw functions. It returns a draw function that
+// runs the pending ones up to d.
+func (p *Parallel) collect(r image.Rectangle, d func(draw.Image) image.Rectangle) func(draw.Image) image.Rectangle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queued++
+	seq := p.queued
+	p.pending = append(p.pending, regionDraw{r, d, seq})
+	return func(drw draw.Image) image.Rectangle {
+		return p.run(drw, seq)
+	}
+}
+
+// run executes the pending draw functions up to the one numbered last. They are split into
+// batches in their order, so that the areas within each batch are disjoint, and the functions of
+// a batch run in parallel.
+func (p *Parallel) run(drw draw.Image, last uint64) image.Rectangle {
+	p.mu.Lock()
+	n := 0
+	for n < len(p.pending) && p.pending[n].seq <= last {
+		n++
+	}
+	pending := p.pending[:n:n]
+	p.pending = p.pending[n:]
+	p.mu.Unlock()
+
+	var changed image.Rectangle
+	for len(pending) > 0 {
+		n := 1
+	batch:
+		for ; n < len(pending); n++ {
+			for _, rd := range pending[:n] {
+				if rd.r.Overlaps(pending[n].r) {
+					break batch
+				}
+			}
+		}
+		changed = changed.Union(runBatch(drw, pending[:n]))
+		pending = pending[n:]
+	}
+	return changed
+}
+
+// runBatch executes draw functions of disjoint areas on at most GOMAXPROCS goroutines.
+func runBatch(drw draw.Image, batch []regionDraw) image.Rectangle {
+	if len(batch) == 1 {
+		return batch[0].d(clipImage(drw, batch[0].r)).Intersect(batch[0].r)
+	}
+
+	results := make([]image.Rectangle, len(batch))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, rd := range batch {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = rd.d(clipImage(drw, rd.r)).Intersect(rd.r)
+		}()
+	}
+	wg.Wait()
+
+	var changed image.Rectangle
+	for _, r := range results {
+		changed = changed.Union(r)
+	}
+	return changed
+}
@@ -0,0 +1,66 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// Overlapping draw functions must run in order, and each must stay inside its area.
+func TestParallelRun(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	green := color.RGBA{0, 0xff, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	fill := func(c color.Color) func(draw.Image) image.Rectangle {
+		return func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, drw.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+			return image.Rect(-100, -100, 100, 100) // clipped to the area
+		}
+	}
+
+	var p Parallel
+	p.collect(image.Rect(0, 0, 10, 10), fill(red))
+	p.collect(image.Rect(10, 0, 20, 10), fill(blue))
+	run := p.collect(image.Rect(5, 0, 15, 10), fill(green))
+	img := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	if r, want := run(img), img.Bounds(); r != want {
+		t.Errorf("changed %v; wanted %v", r, want)
+	}
+	for x, want := range map[int]color.RGBA{2: red, 7: green, 12: green, 17: blue} {
+		if got := img.RGBAAt(x, 5); got != want {
+			t.Errorf("pixel %d,5 = %v; wanted %v", x, got, want)
+		}
+	}
+	if len(p.pending) != 0 {
+		t.Errorf("%d draw functions left pending", len(p.pending))
+	}
+}
+
+// A draw function collected after one that couldn't be confined must not run before it.
+func TestParallelOrder(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	fill := func(c color.Color) func(draw.Image) image.Rectangle {
+		return func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, drw.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+			return drw.Bounds()
+		}
+	}
+
+	var p Parallel
+	area := image.Rect(0, 0, 10, 10)
+	first := p.collect(area, fill(blue))
+	second := p.collect(area, fill(red))
+
+	img := image.NewRGBA(area)
+	first(img)
+	if got := img.RGBAAt(5, 5); got != blue {
+		t.Errorf("received %v after the first draw; wanted %v", got, blue)
+	}
+	fill(blue)(img) // not confined, sent between the two
+	second(img)
+	if got := img.RGBAAt(5, 5); got != red {
+		t.Errorf("received %v; wanted %v", got, red)
+	}
+}
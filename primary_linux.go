@@ -0,0 +1,77 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// ErrNoPrimarySelection is returned when the PRIMARY selection is not available.
+var ErrNoPrimarySelection = errors.New("PRIMARY selection not available")
+
+// The PRIMARY selection is not supported by GLFW, so it is accessed through one of the
+// common command line tools, whichever is installed.
+var (
+	primaryReaders = [][]string{
+		{"xclip", "-o", "-selection", "primary"},
+		{"xsel", "--primary", "--output"},
+		{"wl-paste", "--primary", "--no-newline"},
+	}
+	primaryWriters = [][]string{
+		{"xclip", "-i", "-selection", "primary"},
+		{"xsel", "--primary", "--input"},
+		{"wl-copy", "--primary"},
+	}
+)
+
+// PrimarySelection returns the contents of the X11 PRIMARY selection, i.e. the most recently
+// selected text, which is conventionally pasted by clicking the middle mouse button.
+//
+// It requires xclip, xsel, or wl-paste to be installed. Otherwise, it returns ErrNoPrimarySelection.
+func PrimarySelection() (string, error) {
+	for _, args := range primaryReaders {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(args[0], args[1:]...).Output()
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+	return "", ErrNoPrimarySelection
+}
+
+// SetPrimarySelection replaces the contents of the X11 PRIMARY selection.
+// Text widgets should call it whenever the user selects text.
+//
+// It returns once s is handed to the tool, without waiting for it to exit, because the tools keep
+// running for as long as they own the selection.
+//
+// It requires xclip, xsel, or wl-copy to be installed. Otherwise, it returns ErrNoPrimarySelection.
+func SetPrimarySelection(s string) error {
+	for _, args := range primaryWriters {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("%s: %v", args[0], err)
+		}
+		_, err = io.WriteString(stdin, s)
+		if cerr := stdin.Close(); err == nil {
+			err = cerr
+		}
+		go cmd.Wait() // reap the tool once it loses the selection
+		if err != nil {
+			return fmt.Errorf("%s: %v", args[0], err)
+		}
+		return nil
+	}
+	return ErrNoPrimarySelection
+}
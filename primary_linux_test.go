@@ -0,0 +1,40 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetPrimarySelection(t *testing.T) {
+	// A fake xclip that keeps running after reading the selection, like the real one.
+	dir := t.TempDir()
+	out := filepath.Join(dir, "selection")
+	script := "#!/bin/sh\ncat > " + out + "\nsleep 10\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	done := make(chan error)
+	go func() { done <- SetPrimarySelection("hello") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(timeout):
+		t.Fatalf("SetPrimarySelection still running after %v", timeout)
+	}
+
+	for deadline := time.Now().Add(timeout); ; time.Sleep(10 * time.Millisecond) {
+		got, _ := os.ReadFile(out)
+		if string(got) == "hello" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("received %q; wanted %q", got, "hello")
+		}
+	}
+}
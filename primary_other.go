@@ -0,0 +1,20 @@
+//go:build !linux
+
+package gui
+
+import "errors"
+
+// ErrNoPrimarySelection is returned when the PRIMARY selection is not available.
+var ErrNoPrimarySelection = errors.New("PRIMARY selection not available")
+
+// PrimarySelection returns the contents of the X11 PRIMARY selection.
+// It always returns ErrNoPrimarySelection, since only Linux has one.
+func PrimarySelection() (string, error) {
+	return "", ErrNoPrimarySelection
+}
+
+// SetPrimarySelection replaces the contents of the X11 PRIMARY selection.
+// It always returns ErrNoPrimarySelection, since only Linux has one.
+func SetPrimarySelection(s string) error {
+	return ErrNoPrimarySelection
+}
@@ -0,0 +1,59 @@
+package gui
+
+import "sync"
+
+// NewRefresher makes an Env that passes along the Events of parent and replays the last Resize
+// Event each time refresh is called.
+//
+// An element that changed its internal state can call refresh to redraw itself, and everything
+// below it, through the same code path that handles Resize, instead of duplicating the drawing
+// logic. Calling refresh after the Env died does nothing.
+func NewRefresher(parent Env) (env Env, refresh func()) {
+	injector, inject := NewInjector(parent)
+	var (
+		mu   sync.Mutex
+		dead bool
+	)
+
+	var (
+		last Resize
+		seen bool // whether a Resize was received; if not, one is coming anyway
+	)
+	refresher := newEnv(injector,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case Resize:
+				last, seen = e, true
+			case refreshRequest:
+				if seen {
+					c <- last
+				}
+				return
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {
+			mu.Lock()
+			defer mu.Unlock()
+			dead = true
+			close(inject)
+		})
+
+	// Killing the refresher must not leave the injector attached to parent.
+	env = chainEnv{refresher, injector}
+
+	refresh = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if !dead {
+			inject <- refreshRequest{}
+		}
+	}
+	return env, refresh
+}
+
+// refreshRequest is injected into the Events of a refresher when refresh is called.
+type refreshRequest struct{}
+
+func (refreshRequest) String() string { return "refresh" }
@@ -0,0 +1,78 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+)
+
+var _ Scheme = Responsive{}
+var _ SizedPartitioner = Responsive{}
+
+// Breakpoint is an alternative layout of a Responsive Scheme, used from MinWidth up.
+type Breakpoint struct {
+	MinWidth  int
+	Partition Partitioner
+}
+
+// Responsive is a Scheme that switches between alternative Partitioners depending on the width of
+// the layout, e.g. a sidebar next to the content above 900 pixels and stacked below. Each Resize
+// picks the Breakpoint with the largest MinWidth that fits, so the children are re-partitioned as
+// the window crosses a breakpoint.
+//
+// Only the Partitioners of the alternatives are used, their Intercepters are not, since they
+// can't be switched while the layout runs. Responsive paints a Background instead.
+type Responsive struct {
+	// Breakpoints are the alternatives. The one with the smallest MinWidth is also used when the
+	// layout is narrower than that.
+	Breakpoints []Breakpoint
+
+	// Background is the color of the space not covered by the children. Defaults to black.
+	Background color.Color
+}
+
+func (r Responsive) redraw(drw draw.Image, bounds image.Rectangle) {
+	col := r.Background
+	if col == nil {
+		col = color.Black
+	}
+	draw.Draw(drw, bounds, image.NewUniform(col), image.ZP, draw.Src)
+}
+
+func (r Responsive) Intercept(env Env) Env {
+	return RedrawIntercepter{r.redraw}.Intercept(env)
+}
+
+func (r Responsive) Partition(bounds image.Rectangle) []image.Rectangle {
+	p := r.pick(bounds.Dx())
+	if p == nil {
+		return nil
+	}
+	return p.Partition(bounds)
+}
+
+// PartitionSized passes the size hints on to the active Partitioner if it is a SizedPartitioner.
+func (r Responsive) PartitionSized(bounds image.Rectangle, hints []SizeHint) []image.Rectangle {
+	p := r.pick(bounds.Dx())
+	if sp, ok := p.(SizedPartitioner); ok {
+		return sp.PartitionSized(bounds, hints)
+	}
+	return r.Partition(bounds)
+}
+
+// pick returns the Partitioner for the given width.
+func (r Responsive) pick(width int) Partitioner {
+	if len(r.Breakpoints) == 0 {
+		return nil
+	}
+	bps := append([]Breakpoint(nil), r.Breakpoints...)
+	sort.Slice(bps, func(i, j int) bool { return bps[i].MinWidth < bps[j].MinWidth })
+	picked := bps[0]
+	for _, bp := range bps[1:] {
+		if bp.MinWidth <= width {
+			picked = bp
+		}
+	}
+	return picked.Partition
+}
@@ -0,0 +1,24 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestResponsive(t *testing.T) {
+	r := Responsive{Breakpoints: []Breakpoint{
+		{MinWidth: 900, Partition: Grid{Rows: []int{2}}},  // side by side
+		{MinWidth: 0, Partition: Grid{Rows: []int{1, 1}}}, // stacked
+	}}
+	for _, test := range []struct {
+		bounds image.Rectangle
+		want   image.Rectangle
+	}{
+		{image.Rect(0, 0, 1000, 100), image.Rect(500, 0, 1000, 100)},
+		{image.Rect(0, 0, 600, 100), image.Rect(0, 50, 600, 100)},
+	} {
+		if got := r.Partition(test.bounds); got[1] != test.want {
+			t.Errorf("second child in %v at %v; wanted %v", test.bounds, got[1], test.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package gui
+
+import "image"
+
+var _ Intercepter = PointerRouter{}
+
+// PointerRouter is an Intercepter that only passes along the mouse Events (MoMove, MoDown, MoUp and
+// MoScroll) that happen inside the Rectangle of the last Resize Event. MoScroll happens at the
+// last known position of the mouse.
+//
+// While a mouse button is held, the Env that received the MoDown which pressed the first button
+// grabs the mouse: it gets all the mouse Events until every button is released, even outside of its
+// Rectangle, and no other Env gets any. Each Env decides on its own, but since the children of a
+// layout receive the same Events, each mouse Event goes to at most one child.
+type PointerRouter struct{}
+
+func (PointerRouter) Intercept(parent Env) Env {
+	var (
+		bounds  image.Rectangle
+		mouse   image.Point
+		held    = make(map[Button]bool)
+		grabbed bool // whether this Env got the MoDown that started the held buttons
+	)
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case Resize:
+				bounds = e.Rectangle
+				c <- e
+				return
+			case MoDown:
+				mouse = e.Point
+				if len(held) == 0 {
+					grabbed = e.Point.In(bounds)
+				}
+				held[e.Button] = true
+				if grabbed {
+					c <- e
+				}
+				return
+			case MoUp:
+				mouse = e.Point
+				delete(held, e.Button)
+				if grabbed {
+					c <- e
+				}
+				if len(held) == 0 {
+					grabbed = false
+				}
+				return
+			case MoMove:
+				mouse = e.Point
+			case MoScroll:
+			default:
+				c <- e
+				return
+			}
+			if len(held) > 0 {
+				if grabbed {
+					c <- e
+				}
+			} else if mouse.In(bounds) {
+				c <- e
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
+
+// Routed is a Scheme that makes layouts route the mouse Events of their children with a
+// PointerRouter, so that only the child under the pointer gets them. The rest of the Scheme is
+// left as is.
+type Routed struct {
+	Scheme
+}
+
+var _ SizedPartitioner = Routed{}
+
+func (r Routed) PartitionSized(bounds image.Rectangle, hints []SizeHint) []image.Rectangle {
+	if sp, ok := r.Scheme.(SizedPartitioner); ok {
+		return sp.PartitionSized(bounds, hints)
+	}
+	return r.Scheme.Partition(bounds)
+}
@@ -4,22 +4,176 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
 	"git.samanthony.xyz/share"
 )
 
-var _ Scheme = Scroller{}
+var _ Scheme = &Scroller{}
 
+// ScrollAxes tells along which axes a Scroller scrolls.
+type ScrollAxes int
+
+const (
+	ScrollVertical ScrollAxes = iota
+	ScrollHorizontal
+	ScrollBoth
+)
+
+// Scroller is a Scheme that places children of equal size in an area that scrolls with the mouse
+// wheel while the mouse is over it.
+//
+// While the mouse is over it, or while it has focus from a FocusManager, the arrow keys scroll by
+// lines, PageUp and PageDown by pages, and Home and End to either end of the content. Pages and
+// the ends are vertical, unless the Scroller only scrolls horizontally.
+//
+// Along each axis whose content doesn't fit, a scrollbar is drawn on top of the children, at the
+// right or bottom edge. Its thumb is as long as the visible fraction of the content. The thumb can
+// be dragged with the left mouse button, and clicking the trough jumps the thumb to the click.
+//
+// Scrolling vertically, the children are stacked top to bottom. Scrolling horizontally, they are
+// side by side. Scrolling along both axes, they are in a grid of Columns columns. Each axis has its
+// own offset.
+//
+// A Scroller must be used by pointer, because scrolling changes its state. For lists too long to
+// run an element for every child, see NewVirtualScroller.
 type Scroller struct {
-	Background  color.Color
-	Length      int
-	ChildHeight int
-	Offset      int
-	Gap         int
-	Vertical    bool
+	Background color.Color
+	// Length is the number of children.
+	Length int
+	// ChildSize is the size of each child. Along an axis that doesn't scroll, a zero size fills
+	// the available space.
+	ChildSize image.Point
+	// Extent, if not nil, overrides ChildSize with the size of child i along the axis the
+	// children are stacked on, i.e. the width of the children of a Scroller that only scrolls
+	// horizontally and the height otherwise. When scrolling along both axes, each row is as tall
+	// as its tallest child. Extent is called often, so it should be quick.
+	Extent func(i int) int
+	Gap    int
+	Axes   ScrollAxes
+	// Columns is the number of columns when scrolling along both axes. Defaults to 1.
+	Columns int
+	// Offset is the initial scroll position, i.e. how far right and down the content is scrolled.
+	Offset image.Point
+
+	// ScrollbarWidth defaults to 8 pixels. A negative width hides the scrollbars.
+	ScrollbarWidth int
+	// ScrollbarColor is the color of the thumb. Defaults to gray.
+	ScrollbarColor color.Color
+	// TroughColor is the color of the rest of the scrollbar. Defaults to transparent.
+	TroughColor color.Color
+
+	// Smooth animates scrolling: mouse wheel ticks ease towards where they scroll to, and
+	// trackpad flicks keep the content moving with decaying momentum once the fingers stop.
+	// It must be set before the Scroller intercepts an Env.
+	Smooth bool
+
+	mu     sync.Mutex
+	offset image.Point
+	init   bool            // whether offset has been set from Offset
+	bounds image.Rectangle // last Resize of the Intercepter
+	reveal int             // child to scroll into view once the bounds are known, plus 1
+	inject chan<- Event    // wakes up the Intercepter after scrolling by a method; nil when dead
 }
 
-func (s Scroller) redraw(drw draw.Image, bounds image.Rectangle) {
+// ScrollPosition returns how far right and down the content is scrolled, e.g. to restore it later
+// with ScrollTo.
+func (s *Scroller) ScrollPosition() image.Point {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.init {
+		return s.Offset
+	}
+	return s.offset
+}
+
+// ScrollTo scrolls the content to off, limited to the size of the content.
+func (s *Scroller) ScrollTo(off image.Point) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.init, s.reveal = true, 0
+	if s.bounds.Empty() {
+		s.offset = off // limited when partitioned
+		return
+	}
+	if s.scrollTo(s.bounds, off) {
+		s.moved()
+	}
+}
+
+// ScrollIntoView scrolls the content the least amount to show child i entirely, or as much of it
+// as fits, e.g. to jump to a search result.
+func (s *Scroller) ScrollIntoView(i int) {
+	if i < 0 || i >= s.Length {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bounds.Empty() {
+		s.reveal = i + 1
+		return
+	}
+	if s.scrollTo(s.bounds, s.revealOffset(s.bounds, i)) {
+		s.moved()
+	}
+}
+
+// SetLength changes the number of children while the Scroller is in use, e.g. by a virtual list
+// whose data changed, keeping the content scrolled as far as still fits.
+func (s *Scroller) SetLength(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Length = n
+	if !s.bounds.Empty() {
+		s.scrollTo(s.bounds, s.offset)
+		s.moved()
+	}
+}
+
+// ChildAt returns the index of the child under pt, e.g. to find the row of a list that was clicked,
+// or -1 if there's none or pt is over a scrollbar.
+func (s *Scroller) ChildAt(pt image.Point) int {
+	s.mu.Lock()
+	bounds := s.bounds
+	troughs, _ := s.scrollbars(bounds)
+	s.mu.Unlock()
+	if !pt.In(bounds) || pt.In(troughs[0]) || pt.In(troughs[1]) {
+		return -1
+	}
+	for i, r := range s.visible(bounds) {
+		if pt.In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// revealOffset returns the offset closest to the current one that shows child i. s.mu must be held.
+func (s *Scroller) revealOffset(bounds image.Rectangle, i int) image.Point {
+	r := s.geometry(bounds).rect(i)
+	off := s.offset
+	if r.Max.X > off.X+bounds.Dx() {
+		off.X = r.Max.X - bounds.Dx()
+	}
+	if r.Max.Y > off.Y+bounds.Dy() {
+		off.Y = r.Max.Y - bounds.Dy()
+	}
+	off.X = min(off.X, r.Min.X)
+	off.Y = min(off.Y, r.Min.Y)
+	return off
+}
+
+// moved tells the Intercepter that the offset was changed by a method. s.mu must be held.
+func (s *Scroller) moved() {
+	if s.inject != nil {
+		s.inject <- scrollMoved{}
+	}
+}
+
+func (s *Scroller) redraw(drw draw.Image, bounds image.Rectangle) {
 	col := s.Background
 	if col == nil {
 		col = image.Black
@@ -46,22 +200,304 @@ func clamp(val, a, b int) int {
 	return val
 }
 
-func (s Scroller) Partition(bounds image.Rectangle) []image.Rectangle {
-	items := s.Length
-	ch := s.ChildHeight
-	gap := s.Gap
+func (s *Scroller) scrolls(axes ScrollAxes) bool {
+	return s.Axes == axes || s.Axes == ScrollBoth
+}
+
+// childSize returns the size of each child within bounds.
+func (s *Scroller) childSize(bounds image.Rectangle) image.Point {
+	size := s.ChildSize
+	if !s.scrolls(ScrollHorizontal) && size.X <= 0 {
+		size.X = max(bounds.Dx()-2*s.Gap, 0)
+	}
+	if !s.scrolls(ScrollVertical) && size.Y <= 0 {
+		size.Y = max(bounds.Dy()-2*s.Gap, 0)
+	}
+	return size
+}
+
+// scrollGeometry is where the children of a Scroller go. The children are stacked in tracks,
+// which are rows, unless the Scroller only scrolls horizontally, in which case they are columns.
+type scrollGeometry struct {
+	horizontal bool  // whether the tracks are columns
+	per        int   // number of children in each track
+	cross      int   // size of each child across the tracks
+	gap        int   // between the children and around them
+	starts     []int // position of each track along the stack, relative to the content
+	sizes      []int // size of each track along the stack
+	content    image.Point
+}
+
+// geometry returns where the children go within bounds.
+func (s *Scroller) geometry(bounds image.Rectangle) scrollGeometry {
+	g := scrollGeometry{horizontal: s.Axes == ScrollHorizontal, per: 1, gap: s.Gap}
+	if s.Axes == ScrollBoth {
+		g.per = max(s.Columns, 1)
+	}
+	size := s.childSize(bounds)
+	along := size.Y
+	g.cross = size.X
+	if g.horizontal {
+		along, g.cross = size.X, size.Y
+	}
+
+	n := (s.Length + g.per - 1) / g.per
+	g.starts, g.sizes = make([]int, n), make([]int, n)
+	pos := s.Gap
+	for t := range n {
+		g.sizes[t] = along
+		if s.Extent != nil {
+			g.sizes[t] = 0
+			for i := t * g.per; i < min((t+1)*g.per, s.Length); i++ {
+				g.sizes[t] = max(g.sizes[t], s.Extent(i))
+			}
+		}
+		g.starts[t] = pos
+		pos += g.sizes[t] + s.Gap
+	}
+
+	g.content = image.Pt(g.per*g.cross+(g.per+1)*s.Gap, pos)
+	if g.horizontal {
+		g.content.X, g.content.Y = g.content.Y, g.content.X
+	}
+	return g
+}
+
+// rect returns the Rectangle of child i, relative to the content.
+func (g scrollGeometry) rect(i int) image.Rectangle {
+	t := i / g.per
+	along, across := g.starts[t], g.gap+i%g.per*(g.cross+g.gap)
+	if g.horizontal {
+		return image.Rect(along, across, along+g.sizes[t], across+g.cross)
+	}
+	return image.Rect(across, along, across+g.cross, along+g.sizes[t])
+}
+
+// contentSize returns the size of all the children with the gaps around them.
+func (s *Scroller) contentSize(bounds image.Rectangle) image.Point {
+	return s.geometry(bounds).content
+}
+
+// scrollTo sets the offset to off, limited to the scrolling axes and the size of the content.
+// It returns false if the offset didn't change. s.mu must be held.
+func (s *Scroller) scrollTo(bounds image.Rectangle, off image.Point) bool {
+	content := s.contentSize(bounds)
+	if s.scrolls(ScrollHorizontal) {
+		off.X = clamp(off.X, 0, max(content.X-bounds.Dx(), 0))
+	} else {
+		off.X = 0
+	}
+	if s.scrolls(ScrollVertical) {
+		off.Y = clamp(off.Y, 0, max(content.Y-bounds.Dy(), 0))
+	} else {
+		off.Y = 0
+	}
+	changed := off != s.offset
+	s.offset = off
+	return changed
+}
+
+// scrollLine is how far a mouse wheel tick or an arrow key scrolls, in pixels.
+const scrollLine = 16
+
+// scrollKey scrolls by a line, by a page, or to either end of the content, depending on key.
+// It returns false if nothing moved.
+func (s *Scroller) scrollKey(bounds image.Rectangle, key Key) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	off := s.offset
+	// Pages and ends go along the Y axis, unless only the X axis scrolls.
+	along, page := &off.Y, bounds.Dy()
+	if s.Axes == ScrollHorizontal {
+		along, page = &off.X, bounds.Dx()
+	}
+	page = max(page-scrollLine, scrollLine) // keep a line of the last page in view
+	switch key {
+	case KeyUp:
+		off.Y -= scrollLine
+	case KeyDown:
+		off.Y += scrollLine
+	case KeyLeft:
+		off.X -= scrollLine
+	case KeyRight:
+		off.X += scrollLine
+	case KeyPageUp:
+		*along -= page
+	case KeyPageDown:
+		*along += page
+	case KeyHome:
+		*along = 0
+	case KeyEnd:
+		*along = math.MaxInt32 // limited by scrollTo
+	default:
+		return false
+	}
+	return s.scrollTo(bounds, off)
+}
+
+// scroll moves the content by delta pixels. It returns false if nothing moved.
+func (s *Scroller) scroll(bounds image.Rectangle, delta image.Point) bool {
+	return s.move(bounds, delta) != image.ZP
+}
+
+// move moves the content by delta pixels, and returns how far it actually moved.
+func (s *Scroller) move(bounds image.Rectangle, delta image.Point) image.Point {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := s.offset
+	s.scrollTo(bounds, old.Sub(delta))
+	return old.Sub(s.offset)
+}
+
+func (s *Scroller) scrollbarWidth() int {
+	if s.ScrollbarWidth == 0 {
+		return 8
+	}
+	return max(s.ScrollbarWidth, 0)
+}
+
+// scrollbars returns the troughs and thumbs of the horizontal and vertical scrollbars, in this
+// order. They are empty along axes whose content fits in bounds. s.mu must be held.
+func (s *Scroller) scrollbars(bounds image.Rectangle) (troughs, thumbs [2]image.Rectangle) {
+	width := s.scrollbarWidth()
+	if width == 0 {
+		return troughs, thumbs
+	}
+	content := s.contentSize(bounds)
+	view := bounds.Size()
+	showX := s.scrolls(ScrollHorizontal) && content.X > view.X
+	showY := s.scrolls(ScrollVertical) && content.Y > view.Y
+
+	if showX {
+		troughs[0] = image.Rect(bounds.Min.X, bounds.Max.Y-width, bounds.Max.X, bounds.Max.Y)
+		if showY {
+			troughs[0].Max.X -= width
+		}
+		length, pos := thumb(troughs[0].Dx(), width, view.X, content.X, s.offset.X)
+		thumbs[0] = image.Rect(troughs[0].Min.X+pos, troughs[0].Min.Y, troughs[0].Min.X+pos+length, troughs[0].Max.Y)
+	}
+	if showY {
+		troughs[1] = image.Rect(bounds.Max.X-width, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		if showX {
+			troughs[1].Max.Y -= width
+		}
+		length, pos := thumb(troughs[1].Dy(), width, view.Y, content.Y, s.offset.Y)
+		thumbs[1] = image.Rect(troughs[1].Min.X, troughs[1].Min.Y+pos, troughs[1].Max.X, troughs[1].Min.Y+pos+length)
+	}
+	return troughs, thumbs
+}
+
+// thumb returns the length and position of a scrollbar thumb within a trough.
+func thumb(trough, minLength, view, content, offset int) (length, pos int) {
+	length = min(max(trough*view/content, minLength), trough)
+	if content > view {
+		pos = (trough - length) * offset / (content - view)
+	}
+	return length, pos
+}
+
+// dragThumb scrolls so that the thumb along axis (0 for horizontal, 1 for vertical) starts at pos.
+// It returns false if nothing moved.
+func (s *Scroller) dragThumb(bounds image.Rectangle, axis, pos int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	troughs, thumbs := s.scrollbars(bounds)
+	content := s.contentSize(bounds)
+	off := s.offset
+	if axis == 0 {
+		if space := troughs[0].Dx() - thumbs[0].Dx(); space > 0 {
+			off.X = (pos - troughs[0].Min.X) * (content.X - bounds.Dx()) / space
+		}
+	} else {
+		if space := troughs[1].Dy() - thumbs[1].Dy(); space > 0 {
+			off.Y = (pos - troughs[1].Min.Y) * (content.Y - bounds.Dy()) / space
+		}
+	}
+	return s.scrollTo(bounds, off)
+}
+
+// paintScrollbars draws the scrollbars and returns the area they cover.
+func (s *Scroller) paintScrollbars(drw draw.Image, bounds image.Rectangle) image.Rectangle {
+	s.mu.Lock()
+	troughs, thumbs := s.scrollbars(bounds)
+	s.mu.Unlock()
+
+	thumbCol := s.ScrollbarColor
+	if thumbCol == nil {
+		thumbCol = color.RGBA{0x80, 0x80, 0x80, 0xff}
+	}
+	var r image.Rectangle
+	for i := range troughs {
+		if troughs[i].Empty() {
+			continue
+		}
+		if s.TroughColor != nil {
+			draw.Draw(drw, troughs[i], image.NewUniform(s.TroughColor), image.ZP, draw.Over)
+		}
+		draw.Draw(drw, thumbs[i], image.NewUniform(thumbCol), image.ZP, draw.Over)
+		r = r.Union(troughs[i])
+	}
+	return r
+}
 
-	ret := make([]image.Rectangle, items)
-	Y := bounds.Min.Y + s.Offset + gap
-	for i := 0; i < items; i++ {
-		r := image.Rect(bounds.Min.X+gap, Y, bounds.Max.X-gap, Y+ch)
-		ret[i] = r
-		Y += ch + gap
+func (s *Scroller) Partition(bounds image.Rectangle) []image.Rectangle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	place := s.placer(bounds)
+	ret := make([]image.Rectangle, s.Length)
+	for i := range ret {
+		ret[i] = place(i)
 	}
 	return ret
 }
 
-func (s Scroller) Intercept(parent Env) Env {
+// placer returns a function that returns the Rectangle of the i-th child within bounds at the
+// current offset. s.mu must be held.
+func (s *Scroller) placer(bounds image.Rectangle) func(i int) image.Rectangle {
+	off := s.offset
+	if !s.init {
+		off, s.init = s.Offset, true
+	}
+	if s.reveal > 0 {
+		s.offset = off
+		off, s.reveal = s.revealOffset(bounds, s.reveal-1), 0
+	}
+	s.scrollTo(bounds, off) // keep the offset within the content when bounds shrink
+
+	g := s.geometry(bounds)
+	origin := bounds.Min.Sub(s.offset)
+	return func(i int) image.Rectangle {
+		return g.rect(i).Add(origin)
+	}
+}
+
+// visible returns the Rectangles of the children that intersect bounds, by index.
+func (s *Scroller) visible(bounds image.Rectangle) map[int]image.Rectangle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	place := s.placer(bounds)
+	g := s.geometry(bounds)
+
+	off, view := s.offset.Y, bounds.Dy()
+	if g.horizontal {
+		off, view = s.offset.X, bounds.Dx()
+	}
+	// The tracks are sorted, so the visible ones can be found by binary search.
+	first := sort.Search(len(g.starts), func(t int) bool { return g.starts[t]+g.sizes[t] > off })
+	last := sort.Search(len(g.starts), func(t int) bool { return g.starts[t] >= off+view })
+
+	vis := make(map[int]image.Rectangle)
+	for t := first; t < last; t++ {
+		for i := t * g.per; i < min((t+1)*g.per, s.Length); i++ {
+			if r := place(i); r.Overlaps(bounds) {
+				vis[i] = r
+			}
+		}
+	}
+	return vis
+}
+
+func (s *Scroller) Intercept(parent Env) Env {
 	lastResize := share.NewVal[image.Rectangle]()
 	img := share.NewVal[draw.Image]()
 	mouseOver := share.NewVal[bool]()
@@ -70,35 +506,189 @@ func (s Scroller) Intercept(parent Env) Env {
 	img.Set <- image.NewRGBA(image.Rectangle{})
 	mouseOver.Set <- false
 
-	return newEnv(parent,
+	// The thumb being dragged, if any, and where it was grabbed.
+	dragAxis, grab := -1, 0
+	focused := false
+
+	// Smooth scrolling is animated by the Ticks of a Ticker, which are never passed along.
+	var outer Killable // the first of the Envs made below the intercepted one
+	if s.Smooth {
+		parent = Ticker(parent, 60)
+		outer = parent
+	}
+
+	// The methods of the Scroller inject scrollMoved after scrolling.
+	env, inject := NewInjector(parent)
+	if outer == nil {
+		outer = env
+	}
+	parent = env
+	s.mu.Lock()
+	s.inject = inject
+	s.mu.Unlock()
+
+	var (
+		motion scrollMotion
+		moving bool // whether the Ticks move the content
+	)
+
+	// repaint shows the content and the scrollbars after scrolling, until the children redraw.
+	repaint := func(bounds image.Rectangle) {
+		m := img.Get()
+		s.redraw(m, m.Bounds())
+		parent.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, bounds, m, bounds.Min, draw.Src)
+			s.paintScrollbars(drw, bounds)
+			return bounds
+		}
+	}
+
+	// key scrolls with a key if it has focus or the mouse is over it. It returns false if nothing
+	// moved.
+	key := func(k Key, events chan<- Event) bool {
+		bounds := lastResize.Get()
+		if (focused || mouseOver.Get()) && s.scrollKey(bounds, k) {
+			repaint(bounds)
+			events <- Resize{bounds}
+			return true
+		}
+		return false
+	}
+
+	intercepted := newEnv(parent,
 		func(event Event, events chan<- Event) {
 			switch event := event.(type) {
 			case MoMove:
 				mouseOver.Set <- event.Point.In(lastResize.Get())
+				if dragAxis >= 0 {
+					bounds := lastResize.Get()
+					pos := event.X
+					if dragAxis == 1 {
+						pos = event.Y
+					}
+					if s.dragThumb(bounds, dragAxis, pos-grab) {
+						repaint(bounds)
+						events <- Resize{bounds}
+					}
+				}
+				events <- event
+			case MoDown:
+				if event.Button != ButtonLeft {
+					events <- event
+					break
+				}
+				bounds := lastResize.Get()
+				s.mu.Lock()
+				troughs, thumbs := s.scrollbars(bounds)
+				s.mu.Unlock()
+				for axis := range troughs {
+					if !event.Point.In(troughs[axis]) {
+						continue
+					}
+					dragAxis = axis
+					motion = scrollMotion{flick: motion.flick} // the thumb follows the mouse only
+					pos, thumbMin, length := event.X, thumbs[axis].Min.X, thumbs[axis].Dx()
+					if axis == 1 {
+						pos, thumbMin, length = event.Y, thumbs[axis].Min.Y, thumbs[axis].Dy()
+					}
+					if event.Point.In(thumbs[axis]) {
+						grab = pos - thumbMin
+					} else {
+						// Jump so that the thumb is centered on the click.
+						grab = length / 2
+						if s.dragThumb(bounds, axis, pos-grab) {
+							repaint(bounds)
+							events <- Resize{bounds}
+						}
+					}
+					break
+				}
+				if dragAxis < 0 {
+					events <- event
+				}
+			case MoUp:
+				if dragAxis >= 0 && event.Button == ButtonLeft {
+					dragAxis = -1
+					break
+				}
+				events <- event
 			case MoScroll:
 				if !mouseOver.Get() {
+					events <- event
 					break
 				}
 
-				oldoff := s.Offset
-				v := s.Length*s.ChildHeight + ((s.Length + 1) * s.Gap)
-				bounds := lastResize.Get()
-
-				if s.Vertical {
-					h := bounds.Dx()
-					s.Offset = clamp(s.Offset+event.Point.X*16, h-v, 0)
-				} else {
-					h := bounds.Dy()
-					s.Offset = clamp(s.Offset+event.Point.Y*16, h-v, 0)
+				step := float64(scrollLine)
+				if event.Unit == ScrollPixels {
+					step = 1
+				}
+				dx, dy := event.DX, event.DY
+				if s.Axes == ScrollHorizontal && dx == 0 {
+					dx = dy // let plain mouse wheels scroll sideways
+				}
+				if s.Smooth && event.Unit == ScrollLines {
+					motion.wheel(dx*step, dy*step)
+					moving = true
+					break
+				}
+				if s.Smooth {
+					motion.pixels(time.Now(), dx, dy)
+					moving = true
 				}
 
-				if oldoff != s.Offset {
-					m := img.Get()
-					s.redraw(m, m.Bounds())
+				delta := image.Pt(int(math.Round(dx*step)), int(math.Round(dy*step)))
+				bounds := lastResize.Get()
+				if s.scroll(bounds, delta) {
+					repaint(bounds)
 					events <- Resize{bounds}
 				}
+			case Tick:
+				if !moving {
+					break
+				}
+				delta, more := motion.step(event.Time)
+				if delta != image.ZP {
+					bounds := lastResize.Get()
+					moved := s.move(bounds, delta)
+					// Stop at the ends of the content.
+					if moved.X != delta.X {
+						motion.stop(0)
+					}
+					if moved.Y != delta.Y {
+						motion.stop(1)
+					}
+					if moved != image.ZP {
+						repaint(bounds)
+						events <- Resize{bounds}
+					}
+				}
+				if !more {
+					moving = false
+				}
+			case FocusGained:
+				focused = true
+				events <- event
+			case FocusLost:
+				focused = false
+				events <- event
+			case KbDown:
+				if !key(event.Key, events) {
+					events <- event
+				}
+			case KbRepeat:
+				if !key(event.Key, events) {
+					events <- event
+				}
+			case scrollMoved:
+				motion = scrollMotion{flick: motion.flick}
+				bounds := lastResize.Get()
+				repaint(bounds)
+				events <- Resize{bounds}
 			case Resize:
 				lastResize.Set <- event.Rectangle
+				s.mu.Lock()
+				s.bounds = event.Rectangle
+				s.mu.Unlock()
 
 				m := image.NewRGBA(event.Rectangle)
 				img.Set <- m
@@ -115,13 +705,106 @@ func (s Scroller) Intercept(parent Env) Env {
 				drawChan <- func(drw draw.Image) image.Rectangle {
 					bounds := lastResize.Get()
 					draw.Draw(drw, bounds, m, bounds.Min, draw.Over)
+					s.paintScrollbars(drw, bounds)
 					return m.Bounds()
 				}
 			}
 		},
 		func() {
+			s.mu.Lock()
+			close(inject)
+			s.inject = nil
+			s.mu.Unlock()
 			lastResize.Close()
 			img.Close()
 			mouseOver.Close()
 		})
+
+	// Killing the intercepted Env must not leave the Envs below it attached to the parent.
+	return chainEnv{intercepted, outer}
+}
+
+// scrollMoved is injected when a method of Scroller scrolls. It is never passed along.
+type scrollMoved struct{}
+
+func (scrollMoved) String() string { return "scroll/moved" }
+
+const (
+	scrollEase     = 60 * time.Millisecond  // time constant of easing towards a wheel's target
+	scrollFriction = 325 * time.Millisecond // time constant of the decay of a flick
+	scrollFlickGap = 50 * time.Millisecond  // pause after a trackpad scroll before momentum
+)
+
+// scrollMotion is the smooth and kinetic motion of a Scroller. Amounts are in pixels in the
+// direction the content moves, like the deltas of Scroller.scroll.
+type scrollMotion struct {
+	ease  [2]float64 // distance left to ease towards the target of the wheel
+	vel   [2]float64 // pixels per second of a flick
+	frac  [2]float64 // fractions of a pixel not moved yet
+	flick time.Time  // time of the last trackpad scroll
+	last  time.Time  // time of the last step
+}
+
+// wheel eases the content by dx, dy on top of where it is already going.
+func (m *scrollMotion) wheel(dx, dy float64) {
+	m.ease[0] += dx
+	m.ease[1] += dy
+	m.vel = [2]float64{}
+}
+
+// pixels records a trackpad scroll by dx, dy at now, which the caller moves right away, to
+// estimate the velocity of the flick.
+func (m *scrollMotion) pixels(now time.Time, dx, dy float64) {
+	m.ease = [2]float64{}
+	if dt := now.Sub(m.flick).Seconds(); dt > 0 && dt < 0.1 {
+		for i, d := range [2]float64{dx, dy} {
+			m.vel[i] = 0.8*d/dt + 0.2*m.vel[i]
+		}
+	} else {
+		m.vel = [2]float64{}
+	}
+	m.flick = now
+}
+
+// stop stops the motion along axis (0 for horizontal, 1 for vertical).
+func (m *scrollMotion) stop(axis int) {
+	m.ease[axis], m.vel[axis], m.frac[axis] = 0, 0, 0
+}
+
+// step advances the motion to now, and returns how many whole pixels to move. It returns false
+// when the motion is over.
+func (m *scrollMotion) step(now time.Time) (delta image.Point, more bool) {
+	dt := 1.0 / 60
+	if !m.last.IsZero() {
+		dt = min(now.Sub(m.last).Seconds(), 0.1)
+	}
+	m.last = now
+
+	var whole [2]int
+	for i := range whole {
+		var d float64
+		if m.ease[i] != 0 {
+			step := m.ease[i] * (1 - math.Exp(-dt/scrollEase.Seconds()))
+			if math.Abs(m.ease[i]) < 0.5 {
+				step = m.ease[i]
+			}
+			m.ease[i] -= step
+			d += step
+		}
+		if m.vel[i] != 0 && now.Sub(m.flick) >= scrollFlickGap {
+			d += m.vel[i] * dt
+			m.vel[i] *= math.Exp(-dt / scrollFriction.Seconds())
+			if math.Abs(m.vel[i]) < 10 {
+				m.vel[i] = 0
+			}
+		}
+		m.frac[i] += d
+		whole[i] = int(m.frac[i])
+		m.frac[i] -= float64(whole[i])
+		more = more || m.ease[i] != 0 || m.vel[i] != 0
+	}
+	if !more {
+		*m = scrollMotion{flick: m.flick}
+	}
+	return image.Pt(whole[0], whole[1]), more
 }
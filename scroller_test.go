@@ -0,0 +1,299 @@
+package gui
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestScrollerPartition(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	for _, test := range []struct {
+		s    *Scroller
+		want image.Rectangle // of the second child
+	}{
+		{&Scroller{Length: 3, ChildSize: image.Pt(0, 80), Gap: 2}, image.Rect(2, 84, 98, 164)},
+		{&Scroller{Length: 3, ChildSize: image.Pt(80, 0), Gap: 2, Axes: ScrollHorizontal}, image.Rect(84, 2, 164, 98)},
+		{&Scroller{Length: 4, ChildSize: image.Pt(80, 80), Axes: ScrollBoth, Columns: 2, Offset: image.Pt(30, 10)}, image.Rect(50, -10, 130, 70)},
+	} {
+		if got := test.s.Partition(bounds); got[1] != test.want {
+			t.Errorf("received %v; wanted %v", got[1], test.want)
+		}
+	}
+}
+
+func TestScrollerScroll(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	s := &Scroller{Length: 4, ChildSize: image.Pt(80, 80), Axes: ScrollBoth, Columns: 2}
+	s.Partition(bounds)
+
+	// Scrolling down and right moves the content up and left, but not past its end.
+	if !s.scroll(bounds, image.Pt(-20, -1000)) {
+		t.Fatalf("scroll did not move the content")
+	}
+	if got, want := s.Partition(bounds)[0], image.Rect(-20, -60, 60, 20); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// Nothing moves at the end of the content.
+	if s.scroll(bounds, image.Pt(0, -10)) {
+		t.Errorf("scroll moved the content past its end")
+	}
+
+	// Vertical Scrollers don't scroll sideways.
+	s = &Scroller{Length: 4, ChildSize: image.Pt(0, 80)}
+	if s.scroll(bounds, image.Pt(-20, 0)) {
+		t.Errorf("vertical Scroller scrolled sideways")
+	}
+}
+
+func TestScrollerScrollbars(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	s := &Scroller{Length: 4, ChildSize: image.Pt(0, 100)} // content is 4 times the view
+	s.Partition(bounds)
+
+	troughs, thumbs := s.scrollbars(bounds)
+	if !troughs[0].Empty() {
+		t.Errorf("horizontal scrollbar shown at %v; wanted none", troughs[0])
+	}
+	if want := image.Rect(92, 0, 100, 25); thumbs[1] != want {
+		t.Errorf("received %v; wanted %v", thumbs[1], want)
+	}
+
+	// Dragging the thumb to the end of the trough scrolls to the end of the content.
+	if !s.dragThumb(bounds, 1, 75) {
+		t.Fatalf("dragThumb did not move the content")
+	}
+	if got, want := s.Partition(bounds)[3], image.Rect(0, 0, 100, 100); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
+
+func TestScrollerScrollKey(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	s := &Scroller{Length: 10, ChildSize: image.Pt(0, 100)}
+	s.Partition(bounds)
+
+	for _, test := range []struct {
+		key  Key
+		want int // Y offset after the key
+	}{
+		{KeyDown, 16},
+		{KeyPageDown, 100},
+		{KeyEnd, 900},
+		{KeyPageUp, 816},
+		{KeyHome, 0},
+		{KeyUp, 0},
+	} {
+		s.scrollKey(bounds, test.key)
+		if got := s.offset.Y; got != test.want {
+			t.Errorf("%v: received offset %v; wanted %v", test.key, got, test.want)
+		}
+	}
+	if s.scrollKey(bounds, KeyA) {
+		t.Errorf("KeyA scrolled")
+	}
+}
+
+func TestScrollMotion(t *testing.T) {
+	var m scrollMotion
+	now := time.Now()
+
+	// A wheel tick eases all the way to its target.
+	m.wheel(0, 48)
+	var total image.Point
+	for i := 0; i < 100; i++ {
+		now = now.Add(time.Second / 60)
+		delta, more := m.step(now)
+		total = total.Add(delta)
+		if !more {
+			break
+		}
+	}
+	if want := image.Pt(0, 48); total != want {
+		t.Errorf("wheel moved %v; wanted %v", total, want)
+	}
+	if _, more := m.step(now); more {
+		t.Errorf("motion did not stop")
+	}
+
+	// A flick keeps moving in the same direction after the trackpad stops.
+	for i := 0; i < 5; i++ {
+		now = now.Add(10 * time.Millisecond)
+		m.pixels(now, 0, -10)
+	}
+	now = now.Add(scrollFlickGap)
+	delta, more := m.step(now)
+	if !more || delta.Y >= 0 {
+		t.Errorf("flick moved %v, more: %v; wanted upwards motion", delta, more)
+	}
+}
+
+func TestVirtualScroller(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 50))
+	go drain(root.drawOut)
+
+	type shown struct {
+		i int
+		r image.Rectangle
+	}
+	shownc := make(chan shown, 100)
+	s := &Scroller{Length: 10000, ChildSize: image.Pt(0, 10), ScrollbarWidth: -1}
+	NewVirtualScroller(root, s, func(env Env, i int) {
+		for e := range env.Events() {
+			if resize, ok := e.(Resize); ok {
+				shownc <- shown{i, resize.Rectangle}
+			}
+		}
+	})
+
+	expect := func(first, last int) {
+		t.Helper()
+		got := make(map[int]image.Rectangle)
+		for len(got) < last-first {
+			sp, ok := tryRecv((<-chan shown)(shownc), timeout)
+			if !ok {
+				t.Fatalf("received %v after %v; wanted children %d to %d", got, timeout, first, last)
+			}
+			got[sp.i] = sp.r
+		}
+		for i, r := range got {
+			want := image.Rect(0, (i-first)*10, 100, (i-first+1)*10)
+			if i < first || i >= last || r != want {
+				t.Errorf("child %d at %v; wanted children %d to %d", i, r, first, last)
+			}
+		}
+	}
+	expect(0, 5)
+
+	root.events.Enqueue <- MoMove{image.Pt(50, 25)}
+	root.events.Enqueue <- MoScroll{Point: image.Pt(0, -10), DY: -10, Unit: ScrollLines}
+	expect(16, 21)
+}
+
+// Smooth scrolling eases to where the wheel scrolls to, driven by Ticks that aren't passed along.
+func TestScrollerSmooth(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 50))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	go drain(root.drawOut)
+	s := &Scroller{Length: 100, ChildSize: image.Pt(0, 10), ScrollbarWidth: -1, Smooth: true}
+	s.Partition(image.Rect(0, 0, 100, 50))
+	env := s.Intercept(root)
+	ticks := make(chan bool, 1)
+	go func() {
+		for e := range env.Events() {
+			if _, ok := e.(Tick); ok {
+				select {
+				case ticks <- true:
+				default:
+				}
+			}
+		}
+	}()
+
+	root.events.Enqueue <- MoMove{image.Pt(50, 25)}
+	root.events.Enqueue <- MoScroll{Point: image.Pt(0, -1), DY: -1, Unit: ScrollLines}
+	// The easing may stop a pixel short of a line.
+	for deadline := time.Now().Add(timeout); s.ScrollPosition().Y < scrollLine-1; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("scrolled to %v after %v; wanted about %v", s.ScrollPosition(), timeout, image.Pt(0, scrollLine))
+		}
+	}
+	select {
+	case <-ticks:
+		t.Errorf("Tick passed along")
+	default:
+	}
+}
+
+// Killing the intercepted Env frees its parent for another Env.
+func TestScrollerKill(t *testing.T) {
+	for _, smooth := range []bool{false, true} {
+		root := newDummyEnv(image.Rect(0, 0, 100, 50))
+		go drain(root.drawOut)
+		env := (&Scroller{Smooth: smooth}).Intercept(root)
+		expectDetached(t, root, env)
+		root.Kill() <- true
+		<-root.Dead()
+	}
+}
+
+// Killing a virtual scroller frees its parent for another Env.
+func TestVirtualScrollerKill(t *testing.T) {
+	root := newDummyEnv(image.Rect(0, 0, 100, 50))
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	go drain(root.drawOut)
+	s := &Scroller{Length: 10000, ChildSize: image.Pt(0, 10), ScrollbarWidth: -1}
+	vs := NewVirtualScroller(root, s, func(env Env, i int) {
+		for range env.Events() {
+		}
+	})
+	expectDetached(t, root, vs)
+}
+
+func TestScrollerExtent(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	s := &Scroller{Length: 100, Extent: func(i int) int { return 10 + i%3*10 }} // 10, 20, 30, ...
+	got := s.Partition(bounds)
+	if want := image.Rect(0, 30, 100, 60); got[2] != want {
+		t.Errorf("received %v; wanted %v", got[2], want)
+	}
+
+	// The end of the content is at the sum of the heights.
+	s.scrollKey(bounds, KeyEnd)
+	if got, want := s.Partition(bounds)[99], image.Rect(0, 90, 100, 100); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	vis := s.visible(bounds)
+	for _, i := range []int{95, 99} {
+		if _, ok := vis[i]; !ok {
+			t.Errorf("child %d is not visible", i)
+		}
+	}
+	if _, ok := vis[94]; ok {
+		t.Errorf("child 94 is visible")
+	}
+}
+
+func TestScrollerScrollIntoView(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 100)
+	s := &Scroller{Length: 100, ChildSize: image.Pt(0, 30)}
+
+	// Before the bounds are known, the child is shown once partitioned.
+	s.ScrollIntoView(10)
+	if got, want := s.Partition(bounds)[10], image.Rect(0, 70, 100, 100); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	s.bounds = bounds
+	s.ScrollIntoView(2)
+	if got, want := s.ScrollPosition(), image.Pt(0, 60); got != want {
+		t.Errorf("ScrollPosition = %v; wanted %v", got, want)
+	}
+	s.ScrollIntoView(3) // already visible
+	if got, want := s.ScrollPosition(), image.Pt(0, 60); got != want {
+		t.Errorf("ScrollPosition = %v; wanted %v", got, want)
+	}
+
+	s.ScrollTo(image.Pt(5, 1e6))
+	if got, want := s.ScrollPosition(), image.Pt(0, 2900); got != want {
+		t.Errorf("ScrollPosition = %v; wanted %v", got, want)
+	}
+	if got, want := s.ChildAt(image.Pt(50, 20)), 97; got != want {
+		t.Errorf("ChildAt = %d; wanted %d", got, want)
+	}
+	if got := s.ChildAt(image.Pt(95, 20)); got != -1 { // over the scrollbar
+		t.Errorf("ChildAt = %d; wanted -1", got)
+	}
+
+	s.SetLength(50)
+	if got, want := s.ScrollPosition(), image.Pt(0, 1400); got != want {
+		t.Errorf("ScrollPosition = %v; wanted %v", got, want)
+	}
+}
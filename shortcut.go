@@ -0,0 +1,101 @@
+package gui
+
+import (
+	"strings"
+	"sync"
+)
+
+// Shortcut is a key together with the modifier keys held while pressing it, e.g. Ctrl+S.
+type Shortcut struct {
+	Key              Key
+	Ctrl, Shift, Alt bool
+}
+
+// String returns the Shortcut the way menus show it, e.g. "Ctrl+Shift+S".
+func (s Shortcut) String() string {
+	var b strings.Builder
+	for _, mod := range []struct {
+		held bool
+		name string
+	}{{s.Ctrl, "Ctrl+"}, {s.Shift, "Shift+"}, {s.Alt, "Alt+"}} {
+		if mod.held {
+			b.WriteString(mod.name)
+		}
+	}
+	key := string(s.Key)
+	if key != "" {
+		key = strings.ToUpper(key[:1]) + key[1:]
+	}
+	b.WriteString(key)
+	return b.String()
+}
+
+var _ Intercepter = &Shortcuts{}
+
+// Shortcuts is a registry of keyboard shortcuts. Each bound Shortcut runs an action when it's
+// pressed in an Env wrapped by Intercept; the KbDown of the key is not passed along. Wrapping the
+// Env of the window makes the Shortcuts work wherever the keyboard focus is.
+//
+// The actions are run from the goroutine passing along the Events of the wrapped Env, so they
+// should be quick and must not wait for the Env.
+//
+// The zero value is ready to use. Shortcuts must not be copied after first use.
+type Shortcuts struct {
+	mu    sync.Mutex
+	bound map[Shortcut]func()
+}
+
+// Bind makes s run action, replacing what it ran before. A nil action unbinds s.
+func (sc *Shortcuts) Bind(s Shortcut, action func()) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if action == nil {
+		delete(sc.bound, s)
+		return
+	}
+	if sc.bound == nil {
+		sc.bound = make(map[Shortcut]func())
+	}
+	sc.bound[s] = action
+}
+
+// action returns the action bound to s, or nil.
+func (sc *Shortcuts) action(s Shortcut) func() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.bound[s]
+}
+
+func (sc *Shortcuts) Intercept(parent Env) Env {
+	var held Shortcut // the modifiers currently held
+
+	modifier := func(k Key, down bool) {
+		switch k {
+		case KeyCtrl:
+			held.Ctrl = down
+		case KeyShift:
+			held.Shift = down
+		case KeyAlt:
+			held.Alt = down
+		}
+	}
+
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case KbDown:
+				modifier(e.Key, true)
+				s := held
+				s.Key = e.Key
+				if action := sc.action(s); action != nil {
+					action()
+					return
+				}
+			case KbUp:
+				modifier(e.Key, false)
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
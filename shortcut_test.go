@@ -0,0 +1,48 @@
+package gui
+
+import (
+	"image"
+	"testing"
+)
+
+func TestShortcuts(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	var sc Shortcuts
+	saved := make(chan bool, 1)
+	sc.Bind(Shortcut{Key: KeyS, Ctrl: true}, func() { saved <- true })
+	env := sc.Intercept(root)
+
+	expect := func(want Event) {
+		t.Helper()
+		got, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+	}
+	expect(Resize{rect})
+
+	// Without Ctrl, S is passed along.
+	root.events.Enqueue <- KbDown{KeyS, "s", 0}
+	expect(KbDown{KeyS, "s", 0})
+
+	root.events.Enqueue <- KbDown{KeyCtrl, "ctrl", 0}
+	root.events.Enqueue <- KbDown{KeyS, "s", 0}
+	root.events.Enqueue <- KbUp{KeyCtrl, 0}
+	expect(KbDown{KeyCtrl, "ctrl", 0})
+	expect(KbUp{KeyCtrl, 0})
+	if _, ok := tryRecv(saved, timeout); !ok {
+		t.Errorf("Ctrl+S did not run its action")
+	}
+
+	if got, want := (Shortcut{Key: KeyPageDown, Ctrl: true, Shift: true}).String(), "Ctrl+Shift+Pagedown"; got != want {
+		t.Errorf("received %q; wanted %q", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+package gui
+
+import (
+	"image"
+	"sync"
+)
+
+// Sizer tells a layout what size an element needs and what size it would like, so that e.g. text
+// labels and buttons get Rectangles that fit their content instead of arbitrary equal splits.
+type Sizer interface {
+	// MinSize is the smallest size the element can be shown at.
+	MinSize() image.Point
+	// PreferredSize is the size the element would like to have.
+	PreferredSize() image.Point
+}
+
+// SizeHint is a fixed Sizer.
+type SizeHint struct {
+	Min, Preferred image.Point
+}
+
+func (sh SizeHint) MinSize() image.Point       { return sh.Min }
+func (sh SizeHint) PreferredSize() image.Point { return sh.Preferred }
+
+// SizedPartitioner is a Partitioner that takes the size hints of the children into account.
+// Layouts use PartitionSized instead of Partition if their Scheme implements it, once any of the
+// children has called Prefer.
+//
+// Children without a Sizer have a zero SizeHint.
+type SizedPartitioner interface {
+	PartitionSized(bounds image.Rectangle, hints []SizeHint) []image.Rectangle
+}
+
+// Prefer sets the Sizer of a child of a layout, and re-partitions the layout if its Scheme is a
+// SizedPartitioner. The env must be the Env the layout made for the child, not one derived from it.
+// It returns false if env is not the Env of a child of a layout.
+func Prefer(env Env, s Sizer) bool {
+	child, ok := env.(layoutChild)
+	if !ok {
+		return false
+	}
+	child.layout.prefer(child.index, s)
+	return true
+}
+
+// layoutChild is the Env of a child of a layout.
+type layoutChild struct {
+	Env
+	layout *layout
+	index  int
+}
+
+// layout is the state of a layout shared with its children.
+type layout struct {
+	mu     sync.Mutex
+	hints  []Sizer
+	bounds image.Rectangle // last Resize of the layout
+	inject chan<- Event
+	closed bool
+}
+
+func (l *layout) prefer(i int, s Sizer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.hints[i] = s
+	if !l.bounds.Empty() {
+		l.inject <- Resize{l.bounds} // re-partition the children
+	}
+}
+
+// sizeHints returns the size hints of the children, and whether any of them has a Sizer.
+func (l *layout) sizeHints() ([]SizeHint, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	hints := make([]SizeHint, len(l.hints))
+	hinted := false
+	for i, s := range l.hints {
+		if s != nil {
+			hints[i] = SizeHint{s.MinSize(), s.PreferredSize()}
+			hinted = true
+		}
+	}
+	return hints, hinted
+}
+
+func (l *layout) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	close(l.inject)
+}
+
+// sizedSplit splits space among elements with the given minimum and preferred sizes. Each element
+// gets its minimum size first, then the rest goes towards the preferred sizes, and what is left
+// after that is split evenly. When even the minimum sizes don't fit, they are scaled down.
+func sizedSplit(space int, mins, prefs []int) []int {
+	n := len(mins)
+	sizes := make([]float64, n)
+	var sumMin, sumWant float64
+	for i := range mins {
+		sumMin += float64(mins[i])
+		sumWant += float64(max(prefs[i]-mins[i], 0))
+	}
+	left := float64(space)
+	switch {
+	case sumMin >= left:
+		for i := range sizes {
+			if sumMin > 0 {
+				sizes[i] = left * float64(mins[i]) / sumMin
+			}
+		}
+		left = 0
+	case sumMin+sumWant >= left:
+		for i := range sizes {
+			sizes[i] = float64(mins[i])
+			if sumWant > 0 {
+				sizes[i] += (left - sumMin) * float64(max(prefs[i]-mins[i], 0)) / sumWant
+			}
+		}
+		left = 0
+	default:
+		for i := range sizes {
+			sizes[i] = float64(max(mins[i], prefs[i]))
+		}
+		left -= sumMin + sumWant
+	}
+	if left > 0 && n > 0 {
+		for i := range sizes {
+			sizes[i] += left / float64(n)
+		}
+	}
+
+	// Round so that the sizes add up to space.
+	ret := make([]int, n)
+	var carry float64
+	for i, s := range sizes {
+		s += carry
+		ret[i] = int(s + 0.5)
+		carry = s - float64(ret[i])
+	}
+	return ret
+}
@@ -0,0 +1,147 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sync"
+)
+
+var _ Scheme = &Splitter{}
+
+// Splitter is a Scheme that divides its space between two children, with a divider between them
+// that can be dragged with the left mouse button. Dragging the divider re-partitions the space
+// and sends new Resize events to the children as it moves.
+//
+// A Splitter must be used by pointer, because dragging changes its state.
+type Splitter struct {
+	// Vertical stacks the children top to bottom, with a horizontal divider between them.
+	// Otherwise the children are side by side.
+	Vertical bool
+	// Ratio is the initial share of the space, without the divider, given to the first child.
+	// Defaults to 0.5.
+	Ratio float64
+	// MinSize is the smallest size the divider can make either child.
+	MinSize int
+	// DividerWidth defaults to 6 pixels.
+	DividerWidth int
+	// DividerColor defaults to gray.
+	DividerColor color.Color
+
+	mu    sync.Mutex
+	ratio float64 // current share of the first child; 0 until first used
+}
+
+func (s *Splitter) Partition(bounds image.Rectangle) []image.Rectangle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	first, _, second := s.split(bounds)
+	return []image.Rectangle{first, second}
+}
+
+// split returns the areas of the children and the divider. s.mu must be held.
+func (s *Splitter) split(bounds image.Rectangle) (first, divider, second image.Rectangle) {
+	width := s.dividerWidth()
+	size := bounds.Dx()
+	if s.Vertical {
+		size = bounds.Dy()
+	}
+	space := max(size-width, 0)
+	if s.ratio == 0 {
+		s.ratio = s.Ratio
+		if s.ratio <= 0 || s.ratio >= 1 {
+			s.ratio = 0.5
+		}
+	}
+	n := int(math.Round(float64(space) * s.ratio))
+	if s.Vertical {
+		first = image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, bounds.Min.Y+n)
+		divider = image.Rect(bounds.Min.X, first.Max.Y, bounds.Max.X, first.Max.Y+width)
+		second = image.Rect(bounds.Min.X, divider.Max.Y, bounds.Max.X, bounds.Max.Y)
+	} else {
+		first = image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+n, bounds.Max.Y)
+		divider = image.Rect(first.Max.X, bounds.Min.Y, first.Max.X+width, bounds.Max.Y)
+		second = image.Rect(divider.Max.X, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+	}
+	return first, divider, second
+}
+
+func (s *Splitter) dividerWidth() int {
+	if s.DividerWidth <= 0 {
+		return 6
+	}
+	return s.DividerWidth
+}
+
+// drag moves the divider to p and returns the area it covers afterwards.
+func (s *Splitter) drag(bounds image.Rectangle, p image.Point) image.Rectangle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	width := s.dividerWidth()
+	size, pos := bounds.Dx(), p.X-bounds.Min.X
+	if s.Vertical {
+		size, pos = bounds.Dy(), p.Y-bounds.Min.Y
+	}
+	space := size - width
+	if space <= 0 {
+		return image.Rectangle{}
+	}
+	pos = clamp(pos-width/2, min(s.MinSize, space/2), max(space-s.MinSize, space/2))
+	s.ratio = math.Max(float64(pos)/float64(space), math.SmallestNonzeroFloat64) // 0 means unset
+	_, divider, _ := s.split(bounds)
+	return divider
+}
+
+func (s *Splitter) Intercept(parent Env) Env {
+	col := s.DividerColor
+	if col == nil {
+		col = color.Gray{0x80}
+	}
+	drawDivider := func(r image.Rectangle) {
+		parent.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, r, image.NewUniform(col), image.ZP, draw.Src)
+			return r
+		}
+	}
+
+	var (
+		bounds   image.Rectangle
+		dragging bool
+	)
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			switch e := e.(type) {
+			case Resize:
+				bounds = e.Rectangle
+				c <- e
+				s.mu.Lock()
+				_, divider, _ := s.split(bounds)
+				s.mu.Unlock()
+				drawDivider(divider)
+				return
+			case MoDown:
+				s.mu.Lock()
+				_, divider, _ := s.split(bounds)
+				s.mu.Unlock()
+				if e.Button == ButtonLeft && e.In(divider) {
+					dragging = true
+					return
+				}
+			case MoMove:
+				if dragging {
+					drawDivider(s.drag(bounds, e.Point))
+					c <- Resize{bounds} // re-partition the children
+					return
+				}
+			case MoUp:
+				if dragging && e.Button == ButtonLeft {
+					dragging = false
+					return
+				}
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
@@ -0,0 +1,33 @@
+package gui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestSplitterDrag(t *testing.T) {
+	s := &Splitter{Ratio: 0.25, DividerWidth: 4, MinSize: 10}
+	bounds := image.Rect(0, 0, 104, 50)
+
+	got := s.Partition(bounds)
+	want := []image.Rectangle{image.Rect(0, 0, 25, 50), image.Rect(29, 0, 104, 50)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	if divider, want := s.drag(bounds, image.Pt(62, 20)), image.Rect(60, 0, 64, 50); divider != want {
+		t.Errorf("divider at %v; wanted %v", divider, want)
+	}
+	got = s.Partition(bounds)
+	want = []image.Rectangle{image.Rect(0, 0, 60, 50), image.Rect(64, 0, 104, 50)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// The divider can't make a child smaller than MinSize.
+	s.drag(bounds, image.Pt(0, 20))
+	if got := s.Partition(bounds)[0].Dx(); got != 10 {
+		t.Errorf("first child is %d wide; wanted 10", got)
+	}
+}
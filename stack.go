@@ -0,0 +1,76 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+)
+
+// Stack lays out its children on top of each other. Each child gets the whole area of the parent
+// and draws onto its own layer, and the layers are composited in z-order, so modal dialogs and
+// dropdown popups can cover existing content without erasing it.
+//
+// Unlike a Scheme, which only sees the draw functions of all children mixed together, a Stack
+// knows which child each draw function comes from, which is what compositing requires.
+type Stack struct {
+	Compositor
+	layers []*layer
+
+	redraw Env           // sends the draw functions that recomposite after a restack
+	done   chan struct{} // closed when the Stack dies
+	// sending is read-locked by restack while it may send to redraw, which keeps redraw from
+	// dying, and closing its Draw channel, meanwhile.
+	sending sync.RWMutex
+}
+
+// NewStack takes an array of uninitialized `child' Envs and stacks them on the parent Env, the
+// first at the bottom and the last on top. The children receive the same events from the parent.
+//
+// Killing the returned Stack kills all of the children.
+func NewStack(parent Env, children []*Env) *Stack {
+	s := &Stack{
+		Compositor: NewCompositor(parent),
+		layers:     make([]*layer, len(children)),
+		done:       make(chan struct{}),
+	}
+	for i, child := range children {
+		*child, s.layers[i] = s.makeLayer(0, 1)
+	}
+	s.redraw = s.MakeEnv()
+	newEnv(s.redraw, func(Event, chan<- Event) {}, send, func() {
+		close(s.done)
+		// Wait for restack to see that the Stack is dead.
+		s.sending.Lock()
+		s.sending.Unlock()
+	})
+	return s
+}
+
+// Raise moves the i-th child above all other children.
+func (s *Stack) Raise(i int) {
+	s.restack(func() { s.stack.raise(s.layers[i]) })
+}
+
+// Lower moves the i-th child below all other children.
+func (s *Stack) Lower(i int) {
+	s.restack(func() { s.stack.lower(s.layers[i]) })
+}
+
+// restack changes the order of the layers and composites them again. The order is changed inside
+// a draw function, so it takes effect between the draw functions of the children.
+func (s *Stack) restack(change func()) {
+	s.sending.RLock()
+	defer s.sending.RUnlock()
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	select {
+	case s.redraw.Draw() <- func(drw draw.Image) image.Rectangle {
+		change()
+		return s.stack.composite(drw, drw.Bounds())
+	}:
+	case <-s.done:
+	}
+}
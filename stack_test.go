@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestStackRaise(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	root := newDummyEnv(rect)
+	var a, b Env
+	stack := NewStack(root, []*Env{&a, &b})
+
+	img := image.NewRGBA(rect)
+	apply := func() {
+		d, ok := tryRecv(root.drawOut, timeout)
+		if !ok {
+			t.Fatalf("no draw function received after %v", timeout)
+		}
+		(*d)(img)
+	}
+	red, blue := color.RGBA{0xff, 0, 0, 0xff}, color.RGBA{0, 0, 0xff, 0xff}
+	for _, child := range []struct {
+		env Env
+		col color.Color
+	}{{a, red}, {b, blue}} {
+		go func() {
+			child.env.Draw() <- func(drw draw.Image) image.Rectangle {
+				draw.Draw(drw, rect, image.NewUniform(child.col), image.Point{}, draw.Src)
+				return rect
+			}
+		}()
+		apply()
+	}
+	if got := img.RGBAAt(5, 5); got != blue {
+		t.Errorf("pixel = %v; wanted %v", got, blue)
+	}
+
+	go stack.Raise(0)
+	apply()
+	if got := img.RGBAAt(5, 5); got != red {
+		t.Errorf("pixel after Raise = %v; wanted %v", got, red)
+	}
+
+	// Restacking after the Stack died does nothing.
+	go drain(a.Events())
+	go drain(b.Events())
+	root.Kill() <- true
+	<-root.Dead()
+	stack.Lower(0)
+}
@@ -0,0 +1,126 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+var _ Scheme = Table{}
+
+// TrackSize is the size policy of a column or row of a Table. Tracks with neither a Fixed size
+// nor a Percent are auto-sized: they share the space left by the other tracks evenly.
+type TrackSize struct {
+	// Fixed is the size in pixels.
+	Fixed int
+	// Percent is the size as a percentage, from 0 to 100, of the space of the Table without gaps.
+	Percent float64
+}
+
+// TableCell is the position of a child in a Table.
+type TableCell struct {
+	Row, Col int
+	// RowSpan and ColSpan are the numbers of rows and columns the cell covers. 0 means 1.
+	RowSpan, ColSpan int
+}
+
+// Table is a Scheme that places its children in the cells of a table, where cells can span
+// multiple rows and columns, for form-style and spreadsheet-style UIs.
+type Table struct {
+	// Cells holds the position of each child, in order. There must be one cell per child.
+	Cells []TableCell
+
+	// Columns and Rows hold the size policies of the tracks. Tracks without a policy,
+	// e.g. when these are empty, are auto-sized.
+	Columns []TrackSize
+	Rows    []TrackSize
+
+	// Gap is the space between neighboring columns and rows.
+	Gap int
+
+	// Background is the color of the space not covered by cells. Defaults to black.
+	Background color.Color
+}
+
+func (t Table) redraw(drw draw.Image, bounds image.Rectangle) {
+	col := t.Background
+	if col == nil {
+		col = color.Black
+	}
+	draw.Draw(drw, bounds, image.NewUniform(col), image.ZP, draw.Src)
+}
+
+func (t Table) Intercept(env Env) Env {
+	return RedrawIntercepter{t.redraw}.Intercept(env)
+}
+
+func (t Table) Partition(bounds image.Rectangle) []image.Rectangle {
+	ncols, nrows := len(t.Columns), len(t.Rows)
+	for _, c := range t.Cells {
+		ncols = max(ncols, c.Col+span(c.ColSpan))
+		nrows = max(nrows, c.Row+span(c.RowSpan))
+	}
+	xs := t.tracks(t.Columns, ncols, bounds.Min.X, bounds.Dx())
+	ys := t.tracks(t.Rows, nrows, bounds.Min.Y, bounds.Dy())
+
+	ret := make([]image.Rectangle, len(t.Cells))
+	for i, c := range t.Cells {
+		if c.Col < 0 || c.Row < 0 {
+			continue
+		}
+		ret[i] = image.Rect(
+			xs[c.Col][0], ys[c.Row][0],
+			xs[c.Col+span(c.ColSpan)-1][1], ys[c.Row+span(c.RowSpan)-1][1],
+		)
+	}
+	return ret
+}
+
+// tracks returns the start and end of n tracks dividing size pixels from start on.
+func (t Table) tracks(policies []TrackSize, n, start, size int) [][2]int {
+	if n == 0 {
+		return nil
+	}
+	space := max(size-t.Gap*(n-1), 0)
+	sizes := make([]int, n)
+	left := space
+	var auto []int
+	for i := range sizes {
+		var p TrackSize
+		if i < len(policies) {
+			p = policies[i]
+		}
+		switch {
+		case p.Fixed > 0:
+			sizes[i] = p.Fixed
+		case p.Percent > 0:
+			sizes[i] = int(math.Round(float64(space) * p.Percent / 100))
+		default:
+			auto = append(auto, i)
+			continue
+		}
+		left -= sizes[i]
+	}
+	if len(auto) > 0 {
+		for j, s := range EvenSplit(len(auto), max(left, 0)) {
+			sizes[auto[j]] = s
+		}
+	}
+
+	ret := make([][2]int, n)
+	pos := start
+	for i, s := range sizes {
+		ret[i] = [2]int{pos, pos + s}
+		pos += s + t.Gap
+	}
+	return ret
+}
+
+// span returns the number of tracks covered by a span, where 0 means 1.
+func span(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+package gui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestTablePartition(t *testing.T) {
+	table := Table{
+		Columns: []TrackSize{{Fixed: 20}, {Percent: 50}, {}},
+		Cells: []TableCell{
+			{Row: 0, Col: 0, ColSpan: 3}, // header
+			{Row: 1, Col: 0},
+			{Row: 1, Col: 1, RowSpan: 2},
+			{Row: 2, Col: 2},
+		},
+		Gap: 10,
+	}
+	got := table.Partition(image.Rect(0, 0, 120, 80))
+	want := []image.Rectangle{
+		image.Rect(0, 0, 120, 20),
+		image.Rect(0, 30, 20, 50),
+		image.Rect(30, 30, 80, 80),
+		image.Rect(90, 60, 120, 80),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package text
+
+import "strings"
+
+// Parse converts simple markup into spans: **bold**, *italic*, and [links](target).
+// A backslash makes the next character literal.
+func Parse(markup string) []Span {
+	var (
+		spans  []Span
+		buf    strings.Builder
+		style  Style
+		link   string
+		inLink bool
+	)
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		spans = append(spans, Span{Text: buf.String(), Style: style, Link: link})
+		buf.Reset()
+	}
+
+	for i := 0; i < len(markup); i++ {
+		c := markup[i]
+		switch {
+		case c == '\\' && i+1 < len(markup):
+			i++
+			buf.WriteByte(markup[i])
+		case strings.HasPrefix(markup[i:], "**"):
+			flush()
+			style ^= Bold
+			i++
+		case c == '*':
+			flush()
+			style ^= Italic
+		case c == '[' && !inLink:
+			mid := strings.Index(markup[i:], "](")
+			if mid < 0 || strings.IndexByte(markup[i+mid:], ')') < 0 {
+				buf.WriteByte(c) // not a link
+				continue
+			}
+			end := i + mid + strings.IndexByte(markup[i+mid:], ')')
+			flush()
+			link, inLink = markup[i+mid+2:end], true
+		case c == ']' && inLink:
+			flush()
+			i = i + strings.IndexByte(markup[i:], ')')
+			link, inLink = "", false
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	flush()
+	return spans
+}
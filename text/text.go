@@ -0,0 +1,264 @@
+// Package text lays out paragraphs of styled text: word wrapping inside a rectangle, alignment,
+// bold and italic spans, and links that can be hit-tested for click handling.
+package text
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Style is a combination of font styles.
+type Style int
+
+// List of font styles. They can be combined, e.g. Bold|Italic.
+const (
+	Regular Style = 0
+	Bold    Style = 1 << iota
+	Italic
+)
+
+// Span is a run of text in a single style. If Link is not empty, the span is a link to it.
+type Span struct {
+	Text  string
+	Style Style
+	Link  string
+}
+
+// Faces holds a font face for each Style. Missing faces fall back to Bold or Italic, and then
+// to Regular, which must be set.
+type Faces struct {
+	Regular, Bold, Italic, BoldItalic font.Face
+}
+
+func (f Faces) face(s Style) font.Face {
+	switch {
+	case s&Bold != 0 && s&Italic != 0 && f.BoldItalic != nil:
+		return f.BoldItalic
+	case s&Bold != 0 && f.Bold != nil:
+		return f.Bold
+	case s&Italic != 0 && f.Italic != nil:
+		return f.Italic
+	}
+	return f.Regular
+}
+
+// Align is the horizontal alignment of lines in a paragraph.
+type Align int
+
+// List of alignments.
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Paragraph is a sequence of spans laid out together. Lines are wrapped at spaces, and at
+// newlines in the text. A word too long for a line is put on its own line and overflows it.
+type Paragraph struct {
+	Spans []Span
+	Faces Faces
+	Align Align
+
+	// Color is the color of the text. Defaults to black.
+	Color color.Color
+	// LinkColor is the color of links, which are also underlined. Defaults to blue.
+	LinkColor color.Color
+}
+
+// Box is the area covered by a part of a span on one line.
+type Box struct {
+	Span int // index in Paragraph.Spans
+	image.Rectangle
+}
+
+// Layout is a rendered Paragraph.
+type Layout struct {
+	// Image holds the text on a transparent background. Its bounds are the Rectangle the
+	// Paragraph was rendered into.
+	Image *image.RGBA
+	// Boxes are the areas covered by the spans, in the order they appear.
+	Boxes []Box
+	// Height is the height of all lines, which may be more than the height of Image.
+	Height int
+
+	spans []Span
+}
+
+// Hit returns the index of the span at pt, or false if there is no text at pt.
+func (l *Layout) Hit(pt image.Point) (span int, ok bool) {
+	for _, b := range l.Boxes {
+		if pt.In(b.Rectangle) {
+			return b.Span, true
+		}
+	}
+	return 0, false
+}
+
+// LinkAt returns the link of the span at pt, or false if there is no link at pt.
+func (l *Layout) LinkAt(pt image.Point) (string, bool) {
+	if i, ok := l.Hit(pt); ok && l.spans[i].Link != "" {
+		return l.spans[i].Link, true
+	}
+	return "", false
+}
+
+// token is a word, a run of spaces, or a newline within a span.
+type token struct {
+	span  int
+	text  string
+	space bool
+	br    bool
+	width fixed.Int26_6
+}
+
+type line struct {
+	tokens  []token
+	width   fixed.Int26_6 // without trailing spaces
+	ascent  fixed.Int26_6
+	descent fixed.Int26_6
+}
+
+// Render lays out the Paragraph inside r and draws it. Lines that don't fit below r are laid out,
+// but not drawn.
+func (p Paragraph) Render(r image.Rectangle) *Layout {
+	textColor, linkColor := p.Color, p.LinkColor
+	if textColor == nil {
+		textColor = color.Black
+	}
+	if linkColor == nil {
+		linkColor = color.RGBA{0x20, 0x60, 0xd0, 0xff}
+	}
+
+	lines := p.wrap(p.tokenize(), fixed.I(r.Dx()))
+	l := &Layout{Image: image.NewRGBA(r), spans: p.Spans}
+
+	y := fixed.I(r.Min.Y)
+	for _, ln := range lines {
+		x := fixed.I(r.Min.X)
+		switch p.Align {
+		case AlignCenter:
+			x += (fixed.I(r.Dx()) - ln.width) / 2
+		case AlignRight:
+			x += fixed.I(r.Dx()) - ln.width
+		}
+		baseline := y + ln.ascent
+		top, bottom := y.Floor(), (baseline + ln.descent).Ceil()
+
+		for _, t := range ln.tokens {
+			span := p.Spans[t.span]
+			start := x
+			if !t.space {
+				col := textColor
+				if span.Link != "" {
+					col = linkColor
+				}
+				d := font.Drawer{
+					Dst:  l.Image,
+					Src:  image.NewUniform(col),
+					Face: p.Faces.face(span.Style),
+					Dot:  fixed.Point26_6{X: x, Y: baseline},
+				}
+				d.DrawString(t.text)
+				if span.Link != "" {
+					underline := image.Rect(start.Floor(), baseline.Ceil()+1, d.Dot.X.Ceil(), baseline.Ceil()+2)
+					draw.Draw(l.Image, underline, image.NewUniform(col), image.Point{}, draw.Over)
+				}
+			}
+			x += t.width
+			box := image.Rect(start.Floor(), top, x.Ceil(), bottom)
+			if n := len(l.Boxes); n > 0 && l.Boxes[n-1].Span == t.span && l.Boxes[n-1].Min.Y == top {
+				l.Boxes[n-1].Rectangle = l.Boxes[n-1].Union(box)
+			} else if !t.space {
+				l.Boxes = append(l.Boxes, Box{t.span, box})
+			}
+		}
+		y = baseline + ln.descent
+	}
+	l.Height = (y - fixed.I(r.Min.Y)).Ceil()
+	return l
+}
+
+// tokenize splits the spans into words, spaces, and newlines, and measures them.
+func (p Paragraph) tokenize() []token {
+	var tokens []token
+	for i, span := range p.Spans {
+		face := p.Faces.face(span.Style)
+		text := span.Text
+		for len(text) > 0 {
+			if text[0] == '\n' {
+				tokens = append(tokens, token{span: i, br: true})
+				text = text[1:]
+				continue
+			}
+			space := unicode.IsSpace(rune(text[0]))
+			end := strings.IndexFunc(text, func(r rune) bool {
+				return r == '\n' || unicode.IsSpace(r) != space
+			})
+			if end < 0 {
+				end = len(text)
+			}
+			t := token{span: i, text: text[:end], space: space}
+			if space {
+				t.text = strings.Repeat(" ", end) // tabs and such as plain spaces
+			}
+			t.width = font.MeasureString(face, t.text)
+			tokens = append(tokens, t)
+			text = text[end:]
+		}
+	}
+	return tokens
+}
+
+// wrap breaks tokens into lines no wider than width.
+func (p Paragraph) wrap(tokens []token, width fixed.Int26_6) []line {
+	var (
+		lines []line
+		cur   line
+		x     fixed.Int26_6
+	)
+	flush := func() {
+		// Trailing spaces don't count towards the width.
+		cur.width = 0
+		var w fixed.Int26_6
+		for _, t := range cur.tokens {
+			w += t.width
+			if !t.space {
+				cur.width = w
+			}
+		}
+		if cur.ascent == 0 && cur.descent == 0 {
+			m := p.Faces.Regular.Metrics()
+			cur.ascent, cur.descent = m.Ascent, m.Descent
+		}
+		lines = append(lines, cur)
+		cur, x = line{}, 0
+	}
+
+	for _, t := range tokens {
+		if t.br {
+			flush()
+			continue
+		}
+		if t.space && len(cur.tokens) == 0 && len(lines) > 0 {
+			continue // no leading spaces on wrapped lines
+		}
+		if !t.space && x > 0 && x+t.width > width {
+			flush()
+		}
+		m := p.Faces.face(p.Spans[t.span].Style).Metrics()
+		cur.ascent = max(cur.ascent, m.Ascent)
+		cur.descent = max(cur.descent, m.Descent)
+		cur.tokens = append(cur.tokens, t)
+		x += t.width
+	}
+	if len(cur.tokens) > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
@@ -0,0 +1,58 @@
+package text
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestParse(t *testing.T) {
+	got := Parse(`plain **bold *both*** \*not\* [link](http://x)`)
+	want := []Span{
+		{Text: "plain "},
+		{Text: "bold ", Style: Bold},
+		{Text: "both", Style: Bold | Italic},
+		{Text: " *not* "},
+		{Text: "link", Link: "http://x"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse = %#v; wanted %#v", got, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	face := basicfont.Face7x13 // 7 pixels per character, 13 per line
+	p := Paragraph{
+		Spans: Parse("aaa bbb [ccc](target) ddd"),
+		Faces: Faces{Regular: face},
+	}
+	// 8 characters per line: "aaa bbb" fits, "ccc ddd" goes on the next line.
+	l := p.Render(image.Rect(10, 10, 10+8*7, 100))
+	if l.Height != 2*13 {
+		t.Errorf("Height = %d; wanted %d", l.Height, 2*13)
+	}
+
+	if link, ok := l.LinkAt(image.Pt(10+7, 10+13+5)); !ok || link != "target" {
+		t.Errorf("LinkAt(second line) = %q, %t; wanted %q", link, ok, "target")
+	}
+	if _, ok := l.LinkAt(image.Pt(10+7, 10+5)); ok {
+		t.Errorf("found a link on the first line")
+	}
+	if span, ok := l.Hit(image.Pt(10+5*7, 10+13+5)); !ok || span != 2 {
+		t.Errorf("Hit(ddd) = %d, %t; wanted 2", span, ok)
+	}
+}
+
+func TestRenderAlign(t *testing.T) {
+	p := Paragraph{
+		Spans: []Span{{Text: "ab"}},
+		Faces: Faces{Regular: basicfont.Face7x13},
+		Align: AlignRight,
+	}
+	l := p.Render(image.Rect(0, 0, 100, 20))
+	if len(l.Boxes) != 1 || l.Boxes[0].Min.X != 100-14 || l.Boxes[0].Max.X != 100 {
+		t.Errorf("Boxes = %v; wanted one ending at the right edge", l.Boxes)
+	}
+}
@@ -0,0 +1,159 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"unsafe"
+
+	"github.com/go-gl/gl/v2.1/gl"
+)
+
+// Texture is an image uploaded to the GPU of a window once and drawn by the GPU on top of
+// the drawing area of the window, without being composed into it by the CPU.
+//
+// Textures suit big images that are shown every frame at changing places, such as the tiles of
+// a map viewer: moving a Texture only re-uploads the part of the drawing area it uncovers.
+// Textures are shown in the order they were created, on top of everything drawn to the window.
+// They are not converted to the color profile of the display.
+type Texture struct {
+	w    *Win
+	id   uint32
+	size image.Point
+
+	// only accessed on the OpenGL thread
+	r        image.Rectangle // where the Texture is shown; empty if hidden
+	released bool
+}
+
+// NewTexture uploads img to the GPU. The Texture is hidden until it is shown with Show.
+func (w *Win) NewTexture(img image.Image) (*Texture, error) {
+	b := img.Bounds()
+	if b.Empty() {
+		return nil, fmt.Errorf("NewTexture: empty image")
+	}
+	rgba, ok := img.(*image.RGBA)
+	if !ok || rgba.Stride != 4*b.Dx() {
+		rgba = image.NewRGBA(b)
+		draw.Draw(rgba, b, img, b.Min, draw.Src)
+	}
+
+	t := &Texture{w: w, size: b.Size()}
+	ok = w.onOpenGLThread(func() image.Rectangle {
+		gl.GenTextures(1, &t.id)
+		gl.BindTexture(gl.TEXTURE_2D, t.id)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(b.Dx()), int32(b.Dy()), 0,
+			gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&rgba.Pix[0]))
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+		w.textures = append(w.textures, t)
+		return image.ZR
+	})
+	if !ok {
+		return nil, ErrWindowDead
+	}
+	return t, nil
+}
+
+// Size returns the size of the image the Texture was made from.
+func (t *Texture) Size() image.Point { return t.size }
+
+// Show draws the Texture stretched over r. Showing it somewhere else moves it,
+// and showing it at an empty Rectangle hides it.
+func (t *Texture) Show(r image.Rectangle) {
+	t.w.onOpenGLThread(func() image.Rectangle {
+		if t.released {
+			return image.ZR
+		}
+		old := t.r
+		t.r = r.Canon()
+		return old.Union(t.r)
+	})
+}
+
+// Hide stops drawing the Texture. It is the same as showing it at an empty Rectangle.
+func (t *Texture) Hide() { t.Show(image.ZR) }
+
+// Release hides the Texture and frees its GPU memory. The Texture must not be used afterwards.
+func (t *Texture) Release() {
+	t.w.onOpenGLThread(func() image.Rectangle {
+		if t.released {
+			return image.ZR
+		}
+		t.released = true
+		gl.DeleteTextures(1, &t.id)
+		for i, other := range t.w.textures {
+			if other == t {
+				t.w.textures = append(t.w.textures[:i], t.w.textures[i+1:]...)
+				break
+			}
+		}
+		return t.r
+	})
+}
+
+// onOpenGLThread runs f on the OpenGL thread in place of a draw function, so that the OpenGL
+// context is current, and waits until it returns. The area returned by f is flushed.
+// It returns false if the window is dead.
+func (w *Win) onOpenGLThread(f func() image.Rectangle) bool {
+	done := make(chan struct{})
+	d := func(draw.Image) image.Rectangle {
+		defer close(done)
+		return f()
+	}
+	if !w.sendDraw(d) {
+		return false
+	}
+	<-done
+	return true
+}
+
+// openGLDrawTextures draws the shown Textures over the flushed areas of the drawing area with the
+// given bounds. Only the flushed areas are drawn, because the rest of the screen still shows the
+// Textures from before, which must not be blended over again.
+func (w *Win) openGLDrawTextures(flushed damageList, bounds image.Rectangle) {
+	enabled := false
+	// The flushed areas don't overlap, so each pixel is drawn once.
+	for _, r := range flushed {
+		for _, t := range w.textures {
+			if !t.r.Overlaps(r) {
+				continue
+			}
+			if !enabled {
+				gl.Enable(gl.TEXTURE_2D)
+				gl.Enable(gl.BLEND)
+				gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA) // premultiplied alpha
+				gl.Enable(gl.SCISSOR_TEST)
+				enabled = true
+			}
+			// The scissor box is in window coordinates, which go up from the bottom left.
+			gl.Scissor(int32(r.Min.X-bounds.Min.X), int32(bounds.Max.Y-r.Max.Y), int32(r.Dx()), int32(r.Dy()))
+
+			x0 := float32(-1 + 2*float64(t.r.Min.X)/float64(bounds.Dx()))
+			x1 := float32(-1 + 2*float64(t.r.Max.X)/float64(bounds.Dx()))
+			y0 := float32(+1 - 2*float64(t.r.Min.Y)/float64(bounds.Dy()))
+			y1 := float32(+1 - 2*float64(t.r.Max.Y)/float64(bounds.Dy()))
+
+			gl.BindTexture(gl.TEXTURE_2D, t.id)
+			gl.Begin(gl.QUADS)
+			gl.TexCoord2f(0, 0)
+			gl.Vertex2f(x0, y0)
+			gl.TexCoord2f(1, 0)
+			gl.Vertex2f(x1, y0)
+			gl.TexCoord2f(1, 1)
+			gl.Vertex2f(x1, y1)
+			gl.TexCoord2f(0, 1)
+			gl.Vertex2f(x0, y1)
+			gl.End()
+		}
+	}
+	if enabled {
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+		gl.Disable(gl.SCISSOR_TEST)
+		gl.Disable(gl.BLEND)
+		gl.Disable(gl.TEXTURE_2D)
+	}
+}
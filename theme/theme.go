@@ -0,0 +1,168 @@
+// Package theme defines the colors, font faces, and metrics that elements draw with, so
+// a whole UI can be restyled at once, even while it's running.
+//
+// A Theme reaches the elements through their Envs. NewEnv makes an Env that delivers
+// a ThemeChanged Event right after the first Resize, and again each time the theme changes:
+//
+//	env, themes := theme.NewEnv(win, theme.Light())
+//	...
+//	themes <- theme.Dark() // every element below env restyles itself
+package theme
+
+import (
+	"image/color"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/faiface/gui"
+)
+
+// ColorName names a color of a Theme.
+type ColorName string
+
+// Colors every Theme should define.
+const (
+	Background       ColorName = "background"        // behind everything
+	Surface          ColorName = "surface"           // panels, cards, and inputs
+	Foreground       ColorName = "foreground"        // text and icons
+	Muted            ColorName = "muted"             // secondary text, disabled elements
+	Accent           ColorName = "accent"            // primary buttons, links, focus
+	AccentForeground ColorName = "accent-foreground" // text on the accent color
+	Border           ColorName = "border"
+	Selection        ColorName = "selection"
+	Error            ColorName = "error"
+)
+
+// FaceName names a font face of a Theme.
+type FaceName string
+
+// Font faces every Theme should define.
+const (
+	Body    FaceName = "body"
+	Heading FaceName = "heading"
+	Mono    FaceName = "mono"
+)
+
+// Theme holds the look of a UI. Maps may define additional names for application elements.
+type Theme struct {
+	Colors map[ColorName]color.Color
+	Faces  map[FaceName]font.Face
+
+	Spacing     int     // between neighboring elements, in pixels
+	Padding     int     // between the edge of an element and its content, in pixels
+	BorderWidth int     // in pixels
+	Radius      float64 // of rounded corners, in pixels
+}
+
+// Color returns the named color. Unknown names fall back to Foreground, and then to black.
+func (t *Theme) Color(name ColorName) color.Color {
+	if c, ok := t.Colors[name]; ok {
+		return c
+	}
+	if c, ok := t.Colors[Foreground]; ok {
+		return c
+	}
+	return color.Black
+}
+
+// Face returns the named font face. Unknown names fall back to Body, and then to a basic face.
+func (t *Theme) Face(name FaceName) font.Face {
+	if f, ok := t.Faces[name]; ok {
+		return f
+	}
+	if f, ok := t.Faces[Body]; ok {
+		return f
+	}
+	return basicfont.Face7x13
+}
+
+// Light returns a new light Theme.
+func Light() *Theme {
+	return &Theme{
+		Colors: map[ColorName]color.Color{
+			Background:       color.RGBA{0xf4, 0xf4, 0xf5, 0xff},
+			Surface:          color.RGBA{0xff, 0xff, 0xff, 0xff},
+			Foreground:       color.RGBA{0x18, 0x18, 0x1b, 0xff},
+			Muted:            color.RGBA{0x71, 0x71, 0x7a, 0xff},
+			Accent:           color.RGBA{0x25, 0x63, 0xeb, 0xff},
+			AccentForeground: color.RGBA{0xff, 0xff, 0xff, 0xff},
+			Border:           color.RGBA{0xd4, 0xd4, 0xd8, 0xff},
+			Selection:        color.RGBA{0xbf, 0xdb, 0xfe, 0xff},
+			Error:            color.RGBA{0xdc, 0x26, 0x26, 0xff},
+		},
+		Faces:       defaultFaces(),
+		Spacing:     8,
+		Padding:     6,
+		BorderWidth: 1,
+		Radius:      4,
+	}
+}
+
+// Dark returns a new dark Theme.
+func Dark() *Theme {
+	return &Theme{
+		Colors: map[ColorName]color.Color{
+			Background:       color.RGBA{0x18, 0x18, 0x1b, 0xff},
+			Surface:          color.RGBA{0x27, 0x27, 0x2a, 0xff},
+			Foreground:       color.RGBA{0xf4, 0xf4, 0xf5, 0xff},
+			Muted:            color.RGBA{0xa1, 0xa1, 0xaa, 0xff},
+			Accent:           color.RGBA{0x60, 0xa5, 0xfa, 0xff},
+			AccentForeground: color.RGBA{0x18, 0x18, 0x1b, 0xff},
+			Border:           color.RGBA{0x3f, 0x3f, 0x46, 0xff},
+			Selection:        color.RGBA{0x1e, 0x3a, 0x8a, 0xff},
+			Error:            color.RGBA{0xf8, 0x71, 0x71, 0xff},
+		},
+		Faces:       defaultFaces(),
+		Spacing:     8,
+		Padding:     6,
+		BorderWidth: 1,
+		Radius:      4,
+	}
+}
+
+// defaultFaces uses the basic face for everything, so that no font files are needed.
+func defaultFaces() map[FaceName]font.Face {
+	return map[FaceName]font.Face{
+		Body:    basicfont.Face7x13,
+		Heading: basicfont.Face7x13,
+		Mono:    basicfont.Face7x13,
+	}
+}
+
+// ThemeChanged is an event that happens when the Theme of an Env made by NewEnv is set.
+// Elements should keep the Theme and redraw themselves with it.
+type ThemeChanged struct {
+	Theme *Theme
+}
+
+func (ThemeChanged) String() string { return "theme/changed" }
+
+// NewEnv makes an Env that passes along the Events of parent, along with a ThemeChanged Event
+// for t once the first Resize has been passed along, and for each Theme sent to the returned
+// channel. If a Theme is set before the element received the ThemeChanged Event of the previous
+// one, only the latest is delivered.
+//
+// The themes channel should be closed when it is no longer used.
+func NewEnv(parent gui.Env, t *Theme) (gui.Env, chan<- *Theme) {
+	themes := make(chan *Theme)
+	env := gui.Merge(parent, func(emit func(gui.Event), done <-chan bool) {
+		defer func() {
+			for range themes { // don't block senders after the Env dies
+			}
+		}()
+		emit(ThemeChanged{t})
+		for {
+			select {
+			case t, ok := <-themes:
+				if !ok {
+					return
+				}
+				emit(ThemeChanged{t})
+			case <-done:
+				return
+			}
+		}
+	})
+	return env, themes
+}
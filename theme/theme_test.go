@@ -0,0 +1,29 @@
+package theme
+
+import (
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+func TestLookup(t *testing.T) {
+	for name, th := range map[string]*Theme{"Light": Light(), "Dark": Dark()} {
+		for _, c := range []ColorName{Background, Surface, Foreground, Muted, Accent, AccentForeground, Border, Selection, Error} {
+			if _, ok := th.Colors[c]; !ok {
+				t.Errorf("%s does not define %q", name, c)
+			}
+		}
+		if got, want := th.Color("no-such-color"), th.Colors[Foreground]; got != want {
+			t.Errorf("%s: unknown color = %v; wanted %v", name, got, want)
+		}
+	}
+
+	var empty Theme
+	if got := empty.Color(Accent); got != color.Black {
+		t.Errorf("empty theme color = %v; wanted black", got)
+	}
+	if got := empty.Face(Heading); got != basicfont.Face7x13 {
+		t.Errorf("empty theme face = %v; wanted basic face", got)
+	}
+}
@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"time"
+
+	"git.samanthony.xyz/share"
+)
+
+var _ Intercepter = MoveThrottle{}
+
+// MoveThrottle is an Intercepter that passes along at most one MoMove event per Interval.
+//
+// When the mouse moves faster than that, the MoMove events in between are dropped and the last
+// one is delivered at the end of the interval, so the final position is never lost. Other Events
+// are passed along immediately, after any MoMove that is still waiting, so their order is kept.
+//
+// This keeps elements that redraw on hover responsive with high polling rate mice.
+type MoveThrottle struct {
+	// Interval is the minimum time between MoMove events. Defaults to 1/60 of a second.
+	Interval time.Duration
+}
+
+func (mt MoveThrottle) Intercept(parent Env) Env {
+	interval := mt.Interval
+	if interval <= 0 {
+		interval = time.Second / 60
+	}
+
+	done := make(chan bool)
+	throttled := share.NewQueue[Event]()
+
+	go func() {
+		defer close(throttled.Enqueue)
+
+		var (
+			pending *MoMove
+			last    time.Time // when the last MoMove was passed along
+			timer   = time.NewTimer(0)
+			wait    <-chan time.Time
+		)
+		<-timer.C
+		defer timer.Stop()
+
+		flush := func() {
+			if pending != nil {
+				throttled.Enqueue <- *pending
+				pending = nil
+				last = time.Now()
+			}
+			if wait != nil && !timer.Stop() {
+				<-timer.C
+			}
+			wait = nil
+		}
+
+		for {
+			select {
+			case e, ok := <-parent.Events():
+				if !ok {
+					return
+				}
+				mm, isMove := e.(MoMove)
+				if !isMove {
+					flush()
+					throttled.Enqueue <- e
+					continue
+				}
+				if since := time.Since(last); since >= interval && pending == nil {
+					throttled.Enqueue <- mm
+					last = time.Now()
+					continue
+				} else if pending == nil {
+					timer.Reset(interval - since)
+					wait = timer.C
+				}
+				pending = &mm
+			case <-wait:
+				wait = nil
+				flush()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return newEnv(injectedEnv{parent, throttled.Dequeue},
+		send, // forward events un-modified
+		send, // forward draw functions un-modified
+		func() {
+			close(done)
+		})
+}
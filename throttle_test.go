@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestMoveThrottle(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	root := newDummyEnv(rect)
+	defer func() {
+		root.Kill() <- true
+		<-root.Dead()
+	}()
+	const interval = 100 * time.Millisecond
+	env := MoveThrottle{Interval: interval}.Intercept(root)
+
+	expect := func(want Event) time.Time {
+		t.Helper()
+		got, ok := tryRecv(env.Events(), timeout)
+		if !ok {
+			t.Fatalf("no Event received after %v; wanted %v", timeout, want)
+		}
+		if *got != want {
+			t.Errorf("received %v; wanted %v", *got, want)
+		}
+		return time.Now()
+	}
+	expect(Resize{rect})
+
+	// The first MoMove goes through, the next ones are dropped but the last, which waits for
+	// the end of the interval.
+	start := time.Now()
+	for i := range 3 {
+		root.events.Enqueue <- MoMove{image.Pt(i, i)}
+	}
+	expect(MoMove{image.Pt(0, 0)})
+	if elapsed := expect(MoMove{image.Pt(2, 2)}).Sub(start); elapsed < interval {
+		t.Errorf("received the last MoMove after %v; wanted at least %v", elapsed, interval)
+	}
+
+	// Other Events aren't throttled, and the MoMove waiting before them goes first.
+	start = time.Now()
+	root.events.Enqueue <- MoMove{image.Pt(3, 3)}
+	root.events.Enqueue <- KbType{'x'}
+	expect(MoMove{image.Pt(3, 3)})
+	if elapsed := expect(KbType{'x'}).Sub(start); elapsed >= interval {
+		t.Errorf("received the KbType after %v; wanted it before %v", elapsed, interval)
+	}
+}
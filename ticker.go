@@ -0,0 +1,35 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tick is an event that happens at a steady rate in an Env made by Ticker.
+type Tick struct {
+	Time time.Time
+}
+
+func (t Tick) String() string { return fmt.Sprintf("tick/%d", t.Time.UnixNano()) }
+
+// Ticker makes an Env that passes along the Events of parent, along with fps Tick Events per
+// second, so animations and game loops can be driven from the same select loop as the input.
+// If the element falls behind, only the latest Tick waits for it, like with time.Ticker. The
+// Ticks stop when the Env dies.
+func Ticker(parent Env, fps int) Env {
+	if fps <= 0 {
+		fps = 60
+	}
+	return Merge(parent, func(emit func(Event), done <-chan bool) {
+		ticker := time.NewTicker(time.Second / time.Duration(fps))
+		defer ticker.Stop()
+		for {
+			select {
+			case t := <-ticker.C:
+				emit(Tick{t})
+			case <-done:
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+)
+
+type (
+	// TouchDown is an event that happens when a finger touches the screen.
+	//
+	// ID identifies the contact in the following TouchMove and TouchUp events. Multiple contacts
+	// may be active at the same time.
+	TouchDown struct {
+		image.Point
+		ID int
+	}
+
+	// TouchMove is an event that happens when a finger moves across the screen.
+	TouchMove struct {
+		image.Point
+		ID int
+	}
+
+	// TouchUp is an event that happens when a finger is lifted from the screen.
+	TouchUp struct {
+		image.Point
+		ID int
+	}
+)
+
+func (td TouchDown) String() string { return fmt.Sprintf("touch/down/%d/%d/%d", td.X, td.Y, td.ID) }
+func (tm TouchMove) String() string { return fmt.Sprintf("touch/move/%d/%d/%d", tm.X, tm.Y, tm.ID) }
+func (tu TouchUp) String() string   { return fmt.Sprintf("touch/up/%d/%d/%d", tu.X, tu.Y, tu.ID) }
+
+var _ Intercepter = MouseTouch{}
+
+// MouseTouch is an Intercepter that emits touch Events for the left mouse button, as if it was
+// a single finger with ID 0. The mouse Events are passed along as well.
+//
+// GLFW does not report touches, but touchscreens are usually exposed to it as a mouse.
+// MouseTouch lets elements written for touch Events work with them, and with a mouse for testing.
+type MouseTouch struct{}
+
+func (MouseTouch) Intercept(parent Env) Env {
+	down := false
+	return newEnv(parent,
+		func(e Event, c chan<- Event) {
+			c <- e
+			switch e := e.(type) {
+			case MoDown:
+				if e.Button == ButtonLeft {
+					down = true
+					c <- TouchDown{e.Point, 0}
+				}
+			case MoMove:
+				if down {
+					c <- TouchMove{e.Point, 0}
+				}
+			case MoUp:
+				if e.Button == ButtonLeft && down {
+					down = false
+					c <- TouchUp{e.Point, 0}
+				}
+			}
+		},
+		send, // forward draw functions un-modified
+		func() {})
+}
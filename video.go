@@ -0,0 +1,149 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+	"time"
+)
+
+// VideoFrame is a frame of video to be shown at Time, counted from the first frame.
+type VideoFrame struct {
+	Image *image.YCbCr
+	Time  time.Duration
+}
+
+// NewVideo makes an Env that shows the VideoFrames sent to the returned channel in the Rectangle
+// of its last Resize Event, scaled to fit without distortion.
+//
+// Frames are converted to RGBA with lookup tables on a separate goroutine, and shown at their
+// Time, measured from when the first frame arrives. Frames that arrive more than maxVideoLag
+// late are dropped, so a slow consumer catches up instead of falling further behind.
+//
+// The frames channel should be closed when it is no longer used. The last frame stays visible.
+func NewVideo(parent Env) (Env, chan<- VideoFrame) {
+	frames := make(chan VideoFrame)
+	resizes := make(chan image.Rectangle)
+	done := make(chan bool)
+
+	go func() {
+		defer drain(frames)
+
+		var (
+			bounds image.Rectangle
+			start  time.Time
+			last   *image.RGBA // last shown frame, for redrawing on resize
+		)
+		show := func(img *image.RGBA) bool {
+			r := FitRect(img.Bounds().Size(), bounds)
+			select {
+			case parent.Draw() <- func(drw draw.Image) image.Rectangle {
+				return DrawScaled(drw, r, img, Bilinear)
+			}:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		for {
+			select {
+			case bounds = <-resizes:
+				if last != nil && !show(last) {
+					return
+				}
+			case f, ok := <-frames:
+				if !ok {
+					frames = nil
+					continue
+				}
+				if start.IsZero() {
+					start = time.Now().Add(-f.Time)
+				}
+				due := start.Add(f.Time)
+				if time.Since(due) > maxVideoLag {
+					continue // too late
+				}
+
+				// A new buffer each time, because draw functions showing the previous
+				// ones may still be on their way.
+				buf := image.NewRGBA(image.Rectangle{Max: f.Image.Bounds().Size()})
+				ycbcrToRGBA(buf, f.Image)
+
+				if wait := time.Until(due); wait > 0 {
+					select {
+					case <-time.After(wait):
+					case <-done:
+						return
+					}
+				}
+				last = buf
+				if !show(buf) {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	env := newEnv(parent,
+		func(e Event, c chan<- Event) {
+			if resize, ok := e.(Resize); ok {
+				select {
+				case resizes <- resize.Rectangle:
+				case <-done:
+				}
+			}
+			c <- e
+		},
+		send, // forward draw functions un-modified
+		func() {
+			close(done)
+		})
+	return env, frames
+}
+
+// maxVideoLag is how late a VideoFrame may be before it is dropped.
+const maxVideoLag = 100 * time.Millisecond
+
+// Lookup tables for converting full-range YCbCr to RGB, in 16.16 fixed point, as in JPEG.
+var crToR, cbToB, crToG, cbToG [256]int32
+
+func init() {
+	for i := range crToR {
+		c := int32(i) - 128
+		crToR[i] = 91881 * c  // 1.40200
+		cbToB[i] = 116130 * c // 1.77200
+		crToG[i] = -46802 * c // -0.71414
+		cbToG[i] = -22554 * c // -0.34414
+	}
+}
+
+// ycbcrToRGBA converts src into dst, which must be as large as src, with bounds starting at 0, 0.
+func ycbcrToRGBA(dst *image.RGBA, src *image.YCbCr) {
+	b := src.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := dst.Pix[(y-b.Min.Y)*dst.Stride:]
+		yi := src.YOffset(b.Min.X, y)
+		for x := b.Min.X; x < b.Max.X; x, yi = x+1, yi+1 {
+			ci := src.COffset(x, y)
+			yy := int32(src.Y[yi])<<16 + 1<<15
+			cb, cr := src.Cb[ci], src.Cr[ci]
+			i := 4 * (x - b.Min.X)
+			row[i+0] = clampByte((yy + crToR[cr]) >> 16)
+			row[i+1] = clampByte((yy + crToG[cr] + cbToG[cb]) >> 16)
+			row[i+2] = clampByte((yy + cbToB[cb]) >> 16)
+			row[i+3] = 0xff
+		}
+	}
+}
+
+func clampByte(v int32) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 0xff {
+		return 0xff
+	}
+	return uint8(v)
+}
@@ -0,0 +1,32 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// The lookup tables agree with the standard library.
+func TestYCbCrToRGBA(t *testing.T) {
+	src := image.NewYCbCr(image.Rect(0, 0, 16, 16), image.YCbCrSubsampleRatio420)
+	for i := range src.Y {
+		src.Y[i] = uint8(i * 7)
+	}
+	for i := range src.Cb {
+		src.Cb[i] = uint8(i * 13)
+		src.Cr[i] = uint8(255 - i*11)
+	}
+	dst := image.NewRGBA(src.Bounds())
+	ycbcrToRGBA(dst, src)
+
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			c := src.YCbCrAt(x, y)
+			r, g, b := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+			got := dst.RGBAAt(x, y)
+			if absDiff(got.R, r) > 1 || absDiff(got.G, g) > 1 || absDiff(got.B, b) > 1 {
+				t.Errorf("pixel %d,%d = %v; wanted %v", x, y, got, color.RGBA{r, g, b, 0xff})
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"image"
+	"sync"
+
+	"git.samanthony.xyz/share"
+)
+
+// NewVirtualScroller makes a scrolling list like a layout with a Scroller, but only the children
+// intersecting the visible area have Envs. The Scroller must not be used by anything else.
+//
+// When child i scrolls into view, an Env is made for it and item(env, i) is run in a new goroutine.
+// When it scrolls out of view, its Env is killed and item should return, like any element whose
+// Env closes. This way lists with tens of thousands of rows only have as many elements running as
+// fit on the screen.
+//
+// Killing the returned Killable kills all of the children.
+func NewVirtualScroller(parent Env, s *Scroller, item func(env Env, i int)) Killable {
+	// The children must stop being made and killed before the Mux dies, so the outermost Env
+	// stops the goroutine below before it kills the rest.
+	stop, stopped := make(chan bool), make(chan bool)
+	events := share.NewQueue[Event]()
+	outer := newStoppingEnv(parent, events.Enqueue, events.Dequeue, send, send, func() {
+		close(stop)
+		<-stopped
+	}, func() {})
+	intercepter := s.Intercept(outer)
+	resizeSniffer, resizes := newSniffer(intercepter, func(e Event) (r image.Rectangle, ok bool) {
+		if resize, ok := e.(Resize); ok {
+			return resize.Rectangle, true
+		}
+		return image.Rectangle{}, false
+	})
+	mux := NewMux(resizeSniffer)
+
+	go func() {
+		children := make(map[int]dynamicChild)
+		var hidden sync.WaitGroup
+		defer func() {
+			for _, child := range children {
+				close(child.inject)
+			}
+			hidden.Wait()
+			close(stopped)
+			go drain(resizes)
+		}()
+
+		for {
+			var bounds image.Rectangle
+			select {
+			case r, ok := <-resizes:
+				if !ok {
+					return
+				}
+				bounds = r
+			case <-stop:
+				return
+			}
+			vis := s.visible(bounds)
+			for i, child := range children {
+				if _, ok := vis[i]; !ok {
+					close(child.inject)
+					delete(children, i)
+					hidden.Add(1)
+					go func(muxEnv Env) {
+						defer hidden.Done()
+						muxEnv.Kill() <- true
+						<-muxEnv.Dead()
+					}(child.muxEnv)
+				}
+			}
+			for i, r := range vis {
+				child, ok := children[i]
+				if !ok {
+					child = newDynamicChild(mux)
+					children[i] = child
+					go item(child.env, i)
+				}
+				child.inject <- Resize{r}
+			}
+		}
+	}()
+
+	return chainEnv{intercepter, outer}
+}
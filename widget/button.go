@@ -0,0 +1,128 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+var (
+	_ Widget = &Button{}
+	_ Sizer  = &Button{}
+)
+
+// Button is a Widget that is activated by clicking it with the left mouse button, or by pressing
+// Enter or Space while it has focus.
+//
+// A click only counts if the button is released over the Button it was pressed on.
+type Button struct {
+	// Label is the text on the Button.
+	Label string
+	// Icon is drawn before the Label, or alone if there is no Label. It may be nil.
+	Icon image.Image
+	// Disabled Buttons are drawn muted and can't be activated.
+	Disabled bool
+	// OnClick is called when the Button is activated, from the goroutine running the Button.
+	OnClick func()
+
+	pressed bool // by the mouse
+	hovered bool // last drawn as hovered
+}
+
+func (b *Button) Event(ctx *Context, e gui.Event) bool {
+	switch e := e.(type) {
+	case gui.MoMove:
+		if ctx.Hovered != b.hovered {
+			b.hovered = ctx.Hovered
+			return true
+		}
+	case gui.MoDown:
+		if e.Button == gui.ButtonLeft && e.Point.In(ctx.Bounds) && !b.Disabled {
+			b.pressed = true
+			return true
+		}
+	case gui.MoUp:
+		if e.Button == gui.ButtonLeft && b.pressed {
+			b.pressed = false
+			if e.Point.In(ctx.Bounds) {
+				b.click()
+			}
+			return true
+		}
+	case gui.KbDown:
+		if ctx.Focused && (e.Key == gui.KeyEnter || e.Key == gui.KeySpace) && !b.Disabled {
+			b.click()
+		}
+	case gui.FocusGained, gui.FocusLost:
+		return true
+	}
+	return false
+}
+
+func (b *Button) click() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Draw(ctx *Context, dst draw.Image) {
+	bg, fg := ctx.Color(theme.Surface), ctx.Color(theme.Foreground)
+	switch {
+	case b.Disabled:
+		fg = ctx.Color(theme.Muted)
+	case b.pressed:
+		bg, fg = ctx.Color(theme.Accent), ctx.Color(theme.AccentForeground)
+	case b.hovered:
+		bg = ctx.Color(theme.Selection)
+	}
+	border := ctx.Color(theme.Border)
+	if ctx.Focused {
+		border = ctx.Color(theme.Accent)
+	}
+
+	r := ctx.Bounds
+	paint.Fill(dst, paint.RoundedRect(r, ctx.Theme.Radius), bg)
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(dst, r, float64(w), ctx.Theme.Radius, border)
+	}
+
+	content := b.contentSize(ctx)
+	pt := r.Min.Add(r.Size().Sub(content).Div(2))
+	if b.Icon != nil {
+		ib := b.Icon.Bounds()
+		at := image.Pt(pt.X, r.Min.Y+(r.Dy()-ib.Dy())/2)
+		draw.Draw(dst, image.Rectangle{at, at.Add(ib.Size())}.Intersect(r), b.Icon, ib.Min, draw.Over)
+		pt.X += ib.Dx() + ctx.Theme.Padding/2
+	}
+	if b.Label != "" {
+		lr := image.Rect(pt.X, r.Min.Y, r.Max.X, r.Max.Y)
+		ctx.DrawText(dst, lr, b.Label, theme.Body, fg, text.AlignLeft)
+	}
+}
+
+// contentSize returns the size of the Icon and the Label together.
+func (b *Button) contentSize(ctx *Context) image.Point {
+	var size image.Point
+	if b.Icon != nil {
+		size = b.Icon.Bounds().Size()
+	}
+	if b.Label != "" {
+		ls := ctx.MeasureText(b.Label, theme.Body)
+		if b.Icon != nil {
+			size.X += ctx.Theme.Padding / 2
+		}
+		size.X += ls.X
+		size.Y = max(size.Y, ls.Y)
+	}
+	return size
+}
+
+func (b *Button) SizeHint(ctx *Context) gui.SizeHint {
+	pad := ctx.Theme.Padding + ctx.Theme.BorderWidth
+	size := b.contentSize(ctx).Add(image.Pt(2*pad, 2*pad))
+	return gui.SizeHint{Min: size, Preferred: size}
+}
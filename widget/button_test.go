@@ -0,0 +1,48 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestButton(t *testing.T) {
+	clicks := 0
+	b := &Button{Label: "OK", OnClick: func() { clicks++ }}
+	ctx := &Context{Bounds: image.Rect(0, 0, 80, 30), Theme: theme.Light()}
+
+	for _, test := range []struct {
+		e      gui.Event
+		clicks int
+	}{
+		{gui.MoDown{Point: image.Pt(10, 10), Button: gui.ButtonLeft}, 0},
+		{gui.MoUp{Point: image.Pt(20, 10), Button: gui.ButtonLeft}, 1},
+		{gui.MoDown{Point: image.Pt(10, 10), Button: gui.ButtonLeft}, 1},
+		{gui.MoUp{Point: image.Pt(100, 10), Button: gui.ButtonLeft}, 1}, // released outside
+		{gui.KbDown{Key: gui.KeyEnter}, 1},                              // not focused
+		{gui.FocusGained{}, 1},
+		{gui.KbDown{Key: gui.KeySpace}, 2},
+	} {
+		if _, ok := test.e.(gui.FocusGained); ok {
+			ctx.Focused = true
+		}
+		b.Event(ctx, test.e)
+		if clicks != test.clicks {
+			t.Errorf("after %v: %d clicks; wanted %d", test.e, clicks, test.clicks)
+		}
+	}
+
+	b.Disabled = true
+	b.Event(ctx, gui.KbDown{Key: gui.KeyEnter})
+	if clicks != 2 {
+		t.Errorf("disabled Button clicked")
+	}
+
+	hint := b.SizeHint(ctx)
+	if text := ctx.MeasureText("OK", theme.Body); hint.Preferred.X <= text.X || hint.Preferred.Y <= text.Y {
+		t.Errorf("SizeHint %v does not fit the label of size %v", hint, text)
+	}
+	b.Draw(ctx, image.NewRGBA(ctx.Bounds))
+}
@@ -0,0 +1,317 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+	"time"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+var (
+	_ Widget = &Dropdown{}
+	_ Sizer  = &Dropdown{}
+)
+
+// Dropdown is a Widget that shows the selected one of a list of items, and opens a popup with the
+// whole list to choose from.
+//
+// Clicking the Dropdown opens the popup, and clicking an item selects it. While the Dropdown has
+// focus, Up and Down move through the items, opening the popup if it's closed, Enter and Space
+// select the highlighted item, and Escape closes the popup. Typing the start of an item highlights
+// it, or selects it while the popup is closed.
+//
+// The popup is drawn onto Overlay, so that it covers the siblings of the Dropdown. Overlay should be
+// a layer of a Compositor above the layout the Dropdown is in, e.g. one made by MakeLayer with a
+// higher z. It is owned by the Dropdown, which drains its Events. Without an Overlay, the items can
+// only be chosen with the keyboard.
+type Dropdown struct {
+	Items []string
+	// Selected is the index of the initially selected item. Negative means none.
+	Selected int
+	// Placeholder is shown muted while nothing is selected.
+	Placeholder string
+	// MaxVisible is how many items the popup shows at once. Defaults to 8.
+	MaxVisible int
+	Overlay    gui.Env
+	// OnSelect is called when an item is selected.
+	OnSelect func(i int, item string)
+
+	init      bool
+	selected  int
+	open      bool
+	highlight int // item under the mouse or chosen with the keyboard
+	top       int // first item shown in the popup
+	popup     image.Rectangle
+	prefix    string // typed so far
+	typed     time.Time
+}
+
+// typeAheadTimeout is how long a pause in typing starts a new type-ahead prefix.
+const typeAheadTimeout = time.Second
+
+func (d *Dropdown) maxVisible() int {
+	if d.MaxVisible <= 0 {
+		return 8
+	}
+	return d.MaxVisible
+}
+
+func (d *Dropdown) rowHeight(ctx *Context) int {
+	return ctx.Face(theme.Body).Metrics().Height.Ceil() + ctx.Theme.Padding
+}
+
+func (d *Dropdown) Event(ctx *Context, e gui.Event) bool {
+	if !d.init {
+		d.init = true
+		d.selected = d.Selected
+		if d.selected >= len(d.Items) {
+			d.selected = -1
+		}
+		drainOverlay(d.Overlay)
+	}
+
+	switch e := e.(type) {
+	case gui.Resize, theme.ThemeChanged, gui.FocusLost:
+		d.close()
+		return true
+	case gui.FocusGained:
+		return true
+	case gui.MoDown:
+		if e.Button != gui.ButtonLeft {
+			return false
+		}
+		switch {
+		case d.open && e.Point.In(d.popup):
+			d.choose(d.itemAt(ctx, e.Point))
+			d.close()
+		case d.open:
+			d.close()
+		case e.Point.In(ctx.Bounds):
+			d.openPopup(ctx, d.selected)
+		default:
+			return false
+		}
+		return true
+	case gui.MoMove:
+		if d.open && e.Point.In(d.popup) {
+			if i := d.itemAt(ctx, e.Point); i != d.highlight {
+				d.highlight = i
+				d.drawPopup(ctx)
+			}
+		}
+		return false
+	case gui.MoScroll:
+		if d.open {
+			d.scrollTo(d.top - e.Y)
+			d.drawPopup(ctx)
+		}
+		return false
+	case gui.KbDown:
+		if ctx.Focused {
+			return d.key(ctx, e.Key)
+		}
+	case gui.KbRepeat:
+		if ctx.Focused {
+			return d.key(ctx, e.Key)
+		}
+	case gui.KbType:
+		if ctx.Focused {
+			return d.typeAhead(ctx, e.Rune)
+		}
+	}
+	return false
+}
+
+// key handles a pressed key. It returns false if the Dropdown doesn't need to be redrawn.
+func (d *Dropdown) key(ctx *Context, k gui.Key) bool {
+	switch k {
+	case gui.KeyDown, gui.KeyUp:
+		step := 1
+		if k == gui.KeyUp {
+			step = -1
+		}
+		if !d.open {
+			d.openPopup(ctx, d.selected)
+			return true
+		}
+		d.highlight = max(0, min(d.highlight+step, len(d.Items)-1))
+		d.scrollToHighlight()
+		d.drawPopup(ctx)
+		return false
+	case gui.KeyEnter, gui.KeySpace:
+		if !d.open {
+			d.openPopup(ctx, d.selected)
+			return true
+		}
+		d.choose(d.highlight)
+		d.close()
+		return true
+	case gui.KeyEscape:
+		if d.open {
+			d.close()
+		}
+	}
+	return false
+}
+
+// typeAhead highlights the first item starting with the runes typed so far, or selects it while
+// the popup is closed. It returns false if the Dropdown doesn't need to be redrawn.
+func (d *Dropdown) typeAhead(ctx *Context, r rune) bool {
+	now := time.Now()
+	if now.Sub(d.typed) > typeAheadTimeout {
+		d.prefix = ""
+	}
+	d.typed = now
+	d.prefix += strings.ToLower(string(r))
+
+	for i, item := range d.Items {
+		if !strings.HasPrefix(strings.ToLower(item), d.prefix) {
+			continue
+		}
+		if !d.open {
+			d.choose(i)
+			return true
+		}
+		d.highlight = i
+		d.scrollToHighlight()
+		d.drawPopup(ctx)
+		return false
+	}
+	return false
+}
+
+// choose selects item i, if it's an item.
+func (d *Dropdown) choose(i int) {
+	if i < 0 || i >= len(d.Items) || i == d.selected {
+		return
+	}
+	d.selected = i
+	if d.OnSelect != nil {
+		d.OnSelect(i, d.Items[i])
+	}
+}
+
+// itemAt returns the index of the item of the popup at pt.
+func (d *Dropdown) itemAt(ctx *Context, pt image.Point) int {
+	return d.top + (pt.Y-d.popup.Min.Y)/d.rowHeight(ctx)
+}
+
+func (d *Dropdown) scrollTo(top int) {
+	d.top = max(0, min(top, len(d.Items)-d.maxVisible()))
+}
+
+func (d *Dropdown) scrollToHighlight() {
+	switch {
+	case d.highlight < d.top:
+		d.scrollTo(d.highlight)
+	case d.highlight >= d.top+d.maxVisible():
+		d.scrollTo(d.highlight - d.maxVisible() + 1)
+	}
+}
+
+func (d *Dropdown) openPopup(ctx *Context, highlight int) {
+	if len(d.Items) == 0 {
+		return
+	}
+	d.open = true
+	d.highlight = max(highlight, 0)
+	rows := min(len(d.Items), d.maxVisible())
+	b := ctx.Bounds
+	d.popup = image.Rect(b.Min.X, b.Max.Y, b.Max.X, b.Max.Y+rows*d.rowHeight(ctx))
+	d.scrollTo(d.highlight - rows/2)
+	d.drawPopup(ctx)
+}
+
+// close hides the popup.
+func (d *Dropdown) close() {
+	if !d.open {
+		return
+	}
+	d.open = false
+	if d.Overlay != nil {
+		r := d.popup
+		d.Overlay.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, r, image.Transparent, image.Point{}, draw.Src)
+			return r
+		}
+	}
+}
+
+// drawPopup draws the popup onto the Overlay.
+func (d *Dropdown) drawPopup(ctx *Context) {
+	if d.Overlay == nil || !d.open {
+		return
+	}
+	r := d.popup
+	img := image.NewRGBA(r)
+	draw.Draw(img, r, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+
+	h := d.rowHeight(ctx)
+	pad := ctx.Theme.Padding
+	for row := 0; row < d.maxVisible() && d.top+row < len(d.Items); row++ {
+		i := d.top + row
+		rr := image.Rect(r.Min.X, r.Min.Y+row*h, r.Max.X, r.Min.Y+(row+1)*h)
+		fg := ctx.Color(theme.Foreground)
+		if i == d.highlight {
+			draw.Draw(img, rr, image.NewUniform(ctx.Color(theme.Accent)), image.Point{}, draw.Src)
+			fg = ctx.Color(theme.AccentForeground)
+		}
+		ctx.DrawText(img, image.Rect(rr.Min.X+pad, rr.Min.Y, rr.Max.X-pad, rr.Max.Y), d.Items[i], theme.Body, fg, text.AlignLeft)
+	}
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(img, r, float64(w), 0, ctx.Color(theme.Border))
+	}
+
+	d.Overlay.Draw() <- func(drw draw.Image) image.Rectangle {
+		draw.Draw(drw, r, img, r.Min, draw.Src)
+		return r
+	}
+}
+
+func (d *Dropdown) Draw(ctx *Context, dst draw.Image) {
+	r := ctx.Bounds
+	border := ctx.Color(theme.Border)
+	if ctx.Focused || d.open {
+		border = ctx.Color(theme.Accent)
+	}
+	paint.Fill(dst, paint.RoundedRect(r, ctx.Theme.Radius), ctx.Color(theme.Surface))
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(dst, r, float64(w), ctx.Theme.Radius, border)
+	}
+
+	inner := r.Inset(ctx.Theme.Padding + ctx.Theme.BorderWidth)
+	arrow := inner.Dy() / 2
+	label, fg := d.Placeholder, ctx.Color(theme.Muted)
+	if d.selected >= 0 {
+		label, fg = d.Items[d.selected], ctx.Color(theme.Foreground)
+	}
+	tr := inner
+	tr.Max.X -= arrow + ctx.Theme.Padding
+	ctx.DrawText(dst, tr, label, theme.Body, fg, text.AlignLeft)
+
+	// A triangle pointing down at the right end.
+	cx, cy := float64(inner.Max.X-arrow/2), float64(inner.Min.Y+inner.Dy()/2)
+	a := float64(arrow) / 2
+	p := new(paint.Path)
+	p.MoveTo(cx-a, cy-a/2)
+	p.LineTo(cx+a, cy-a/2)
+	p.LineTo(cx, cy+a/2)
+	p.Close()
+	paint.Fill(dst, p, ctx.Color(theme.Foreground))
+}
+
+func (d *Dropdown) SizeHint(ctx *Context) gui.SizeHint {
+	pad := ctx.Theme.Padding + ctx.Theme.BorderWidth
+	var width int
+	for _, item := range append([]string{d.Placeholder}, d.Items...) {
+		width = max(width, ctx.MeasureText(item, theme.Body).X)
+	}
+	height := ctx.Face(theme.Body).Metrics().Height.Ceil()
+	size := image.Pt(width+height/2+ctx.Theme.Padding+2*pad, height+2*pad)
+	return gui.SizeHint{Min: size, Preferred: size}
+}
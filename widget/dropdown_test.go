@@ -0,0 +1,47 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestDropdown(t *testing.T) {
+	var selected []int
+	d := &Dropdown{
+		Items:    []string{"apple", "banana", "blueberry", "cherry"},
+		Selected: -1,
+		OnSelect: func(i int, item string) { selected = append(selected, i) },
+	}
+	ctx := &Context{Bounds: image.Rect(0, 0, 100, 20), Theme: theme.Light(), Focused: true}
+	d.Event(ctx, gui.Resize{Rectangle: ctx.Bounds})
+
+	// Typing selects while the popup is closed.
+	for _, r := range "bl" {
+		d.Event(ctx, gui.KbType{Rune: r})
+	}
+	// Down opens the popup at the selection, and moves through the items.
+	for _, k := range []gui.Key{gui.KeyDown, gui.KeyDown, gui.KeyEnter} {
+		d.Event(ctx, gui.KbDown{Key: k})
+	}
+	if want := []int{1, 2, 3}; len(selected) != len(want) || selected[0] != 1 || selected[1] != 2 || selected[2] != 3 {
+		t.Errorf("selected %v; wanted %v", selected, want)
+	}
+	if d.open {
+		t.Errorf("popup still open after Enter")
+	}
+
+	// Clicking opens the popup below the Dropdown, and clicking an item selects it.
+	selected = nil
+	d.Event(ctx, gui.MoDown{Point: image.Pt(50, 10), Button: gui.ButtonLeft})
+	if !d.open || d.popup.Min.Y != ctx.Bounds.Max.Y {
+		t.Fatalf("popup at %v, open: %v; wanted open below %v", d.popup, d.open, ctx.Bounds)
+	}
+	d.Event(ctx, gui.MoDown{Point: image.Pt(50, d.popup.Min.Y+1), Button: gui.ButtonLeft})
+	if len(selected) != 1 || selected[0] != 0 {
+		t.Errorf("selected %v; wanted [0]", selected)
+	}
+	d.Draw(ctx, image.NewRGBA(ctx.Bounds))
+}
@@ -0,0 +1,179 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	"github.com/faiface/gui"
+)
+
+var (
+	_ Widget  = &ImageView{}
+	_ Damager = &ImageView{}
+)
+
+// ImageView is a Widget showing an Image, possibly much larger than its Bounds. Scrolling the mouse
+// wheel zooms in and out about the cursor, and dragging with the left button pans the Image.
+//
+// The Image is first shown whole, fit to the Bounds. It can't be zoomed out further than that, or
+// than its actual size if it's smaller, nor panned out of view. A Value[image.Image] Event, e.g.
+// from Feed, shows another Image the same way.
+//
+// Only the visible part of the Image is scaled on each Draw, and only the part of the Bounds it
+// covers, now or before, is redrawn.
+type ImageView struct {
+	Image         image.Image
+	Interpolation gui.Interpolation
+	// MaxZoom is the most screen pixels per pixel of the Image. Defaults to 32.
+	MaxZoom float64
+
+	zoom     float64 // screen pixels per pixel of the Image, or 0 until the first Resize
+	pos      [2]float64
+	mouse    image.Point // of the last MoMove
+	dragging bool
+	drawn    image.Rectangle
+}
+
+// imageViewStep is how much one line of a mouse wheel zooms an ImageView. Scrolling by pixels
+// zooms by the same amount per imageViewPixels.
+const (
+	imageViewStep   = 1.25
+	imageViewPixels = 50
+)
+
+func (v *ImageView) Event(ctx *Context, e gui.Event) bool {
+	switch e := e.(type) {
+	case gui.Resize:
+		if v.zoom == 0 {
+			v.fit(ctx)
+		}
+		v.clamp(ctx)
+	case Value[image.Image]:
+		v.Image = e.Value
+		v.fit(ctx)
+		return true
+	case gui.MoScroll:
+		// The Point of a MoScroll is the amount scrolled, so the cursor is where it last moved.
+		if !ctx.Hovered || v.zoom == 0 {
+			return false
+		}
+		lines := e.DY
+		if e.Unit == gui.ScrollPixels {
+			lines /= imageViewPixels
+		}
+		return v.zoomAt(ctx, v.mouse, v.zoom*math.Pow(imageViewStep, lines))
+	case gui.MoDown:
+		if e.Button == gui.ButtonLeft && e.Point.In(ctx.Bounds) {
+			v.dragging, v.mouse = true, e.Point
+		}
+	case gui.MoMove:
+		delta := e.Point.Sub(v.mouse)
+		v.mouse = e.Point
+		if v.dragging {
+			return v.pan(ctx, float64(delta.X), float64(delta.Y))
+		}
+	case gui.MoUp:
+		if e.Button == gui.ButtonLeft {
+			v.dragging = false
+		}
+	}
+	return false
+}
+
+// minZoom returns the zoom at which the Image fits the Bounds, or 1 if it's smaller than them.
+func (v *ImageView) minZoom(ctx *Context) float64 {
+	size := v.Image.Bounds().Size()
+	if size.X <= 0 || size.Y <= 0 {
+		return 1
+	}
+	b := ctx.Bounds.Size()
+	return min(float64(b.X)/float64(size.X), float64(b.Y)/float64(size.Y), 1)
+}
+
+func (v *ImageView) maxZoom() float64 {
+	if v.MaxZoom <= 0 {
+		return 32
+	}
+	return v.MaxZoom
+}
+
+// fit zooms out to show the whole Image.
+func (v *ImageView) fit(ctx *Context) {
+	if v.Image == nil {
+		v.zoom = 0
+		return
+	}
+	v.zoom = v.minZoom(ctx)
+	v.clamp(ctx)
+}
+
+// zoomAt zooms to zoom, keeping the point of the Image under pt in place. It reports whether
+// anything changed.
+func (v *ImageView) zoomAt(ctx *Context, pt image.Point, zoom float64) bool {
+	zoom = max(v.minZoom(ctx), min(zoom, v.maxZoom()))
+	if zoom == v.zoom {
+		return false
+	}
+	c := pt.Sub(ctx.Bounds.Min)
+	for i, c := range [2]float64{float64(c.X), float64(c.Y)} {
+		v.pos[i] = c - (c-v.pos[i])*zoom/v.zoom
+	}
+	v.zoom = zoom
+	v.clamp(ctx)
+	return true
+}
+
+// pan moves the Image by dx, dy pixels. It reports whether it moved.
+func (v *ImageView) pan(ctx *Context, dx, dy float64) bool {
+	old := v.pos
+	v.pos[0] += dx
+	v.pos[1] += dy
+	v.clamp(ctx)
+	return v.pos != old
+}
+
+// clamp keeps the Image covering the Bounds along each axis it's larger than them, and centers it
+// along the others.
+func (v *ImageView) clamp(ctx *Context) {
+	if v.zoom == 0 {
+		return
+	}
+	size, b := v.Image.Bounds().Size(), ctx.Bounds.Size()
+	for i, n := range [2][2]int{{size.X, b.X}, {size.Y, b.Y}} {
+		scaled, room := float64(n[0])*v.zoom, float64(n[1])
+		if scaled <= room {
+			v.pos[i] = (room - scaled) / 2
+		} else {
+			v.pos[i] = max(room-scaled, min(v.pos[i], 0))
+		}
+	}
+}
+
+// rect returns where the whole Image is drawn, most of it possibly outside of the Bounds.
+func (v *ImageView) rect(ctx *Context) image.Rectangle {
+	if v.zoom == 0 {
+		return image.Rectangle{}
+	}
+	size := v.Image.Bounds().Size()
+	origin := ctx.Bounds.Min.Add(image.Pt(int(math.Round(v.pos[0])), int(math.Round(v.pos[1]))))
+	return image.Rectangle{origin, origin.Add(image.Pt(
+		int(math.Round(float64(size.X)*v.zoom)),
+		int(math.Round(float64(size.Y)*v.zoom)),
+	))}
+}
+
+func (v *ImageView) Damage(ctx *Context) image.Rectangle {
+	// The background around the Image only changes where the Image was or is.
+	return v.rect(ctx).Intersect(ctx.Bounds).Union(v.drawn)
+}
+
+func (v *ImageView) Draw(ctx *Context, dst draw.Image) {
+	r := v.rect(ctx)
+	v.drawn = r.Intersect(ctx.Bounds)
+	if v.drawn.Empty() {
+		return
+	}
+	// Scaling onto the part of dst in the Bounds only computes the visible pixels.
+	gui.DrawScaled(clip(dst, ctx.Bounds), r, v.Image, v.Interpolation)
+}
@@ -0,0 +1,97 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestImageView(t *testing.T) {
+	// The left half of the image is red, the right half blue.
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for x := 0; x < 200; x++ {
+		for y := 0; y < 100; y++ {
+			c := color.RGBA{R: 0xff, A: 0xff}
+			if x >= 100 {
+				c = color.RGBA{B: 0xff, A: 0xff}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	v := &ImageView{Image: img}
+	ctx := &Context{Bounds: image.Rect(0, 0, 100, 100), Theme: theme.Light()}
+
+	// Fit, centered vertically.
+	v.Event(ctx, gui.Resize{Rectangle: ctx.Bounds})
+	if got, want := v.rect(ctx), image.Rect(0, 25, 100, 75); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	dst := image.NewRGBA(ctx.Bounds)
+	v.Draw(ctx, dst)
+	if got, want := dst.RGBAAt(10, 50), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// move moves the mouse like Run would.
+	move := func(pt image.Point) {
+		ctx.Hovered = pt.In(ctx.Bounds)
+		v.Event(ctx, gui.MoMove{Point: pt})
+	}
+
+	// The wheel does nothing while the mouse is elsewhere.
+	move(image.Pt(150, 50))
+	if v.Event(ctx, gui.MoScroll{Point: image.Pt(0, 1), DY: 1}) {
+		t.Errorf("zoomed in with the mouse outside")
+	}
+
+	// Can't zoom out further.
+	move(image.Pt(50, 50))
+	if v.Event(ctx, gui.MoScroll{Point: image.Pt(0, -1), DY: -1}) {
+		t.Errorf("zoomed out past fit")
+	}
+
+	// Zooming in about the middle keeps it in place.
+	if !v.Event(ctx, gui.MoScroll{Point: image.Pt(0, 3), DY: 3}) {
+		t.Fatalf("didn't zoom in")
+	}
+	r := v.rect(ctx)
+	if mid := (r.Min.X + r.Max.X) / 2; absDiff(mid, 50) > 1 {
+		t.Errorf("received middle at %d; wanted 50", mid)
+	}
+	if got, want := v.Damage(ctx), image.Rect(0, 25, 100, 75).Union(r.Intersect(ctx.Bounds)); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+
+	// Zooming in about the cursor keeps the point under it in place.
+	move(image.Pt(20, 50))
+	before := v.rect(ctx)
+	v.Event(ctx, gui.MoScroll{Point: image.Pt(0, 1), DY: 1})
+	after := v.rect(ctx)
+	x0 := float64(20-before.Min.X) / float64(before.Dx())
+	x1 := float64(20-after.Min.X) / float64(after.Dx())
+	if math.Abs(x0-x1) > 0.01 {
+		t.Errorf("received %.3f of the width under the cursor; wanted %.3f", x1, x0)
+	}
+
+	// Dragging pans, but not past the edge of the image.
+	v.Event(ctx, gui.MoDown{Point: image.Pt(50, 50), Button: gui.ButtonLeft})
+	move(image.Pt(1000, 50))
+	v.Event(ctx, gui.MoUp{Point: image.Pt(1000, 50), Button: gui.ButtonLeft})
+	if got := v.rect(ctx).Min.X; got != 0 {
+		t.Errorf("received left edge at %d; wanted 0", got)
+	}
+	move(image.Pt(0, 50))
+	if got := v.rect(ctx).Min.X; got != 0 {
+		t.Errorf("panned after MoUp")
+	}
+
+	dst = image.NewRGBA(ctx.Bounds)
+	v.Draw(ctx, dst)
+	if got, want := dst.RGBAAt(90, 50), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
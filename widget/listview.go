@@ -0,0 +1,289 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"slices"
+	"sync"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+// ListView is a list of rows that can be selected. It's a virtual scrolling list, see
+// gui.NewVirtualScroller, so only the visible rows are run and drawn.
+//
+// Clicking a row selects it. With Multiple, ctrl-click toggles a row and shift-click selects the
+// range from the last clicked row. While the ListView has focus, Up, Down, Home, and End move the
+// cursor and select the row under it, extending the selection with shift or only moving the cursor
+// with ctrl, Space selects the row under the cursor, or toggles it with ctrl, and ctrl-A selects
+// every row.
+//
+// A ListView must be used by pointer, and run by NewListView.
+type ListView struct {
+	// Scroller lays out the rows. Its Length is the number of rows.
+	Scroller *gui.Scroller
+	// Items are the labels of the rows if Row is nil.
+	Items []string
+	// Row, if not nil, draws row i inside ctx.Bounds. dst is already filled with the Selection
+	// color if the row is selected. The rows run in goroutines of their own, so Row may be called
+	// concurrently.
+	Row func(ctx *Context, dst draw.Image, i int, selected bool)
+	// Multiple allows selecting more than one row.
+	Multiple bool
+	// OnSelectionChange is called with the selected rows, in order, when the user changes the
+	// selection. It's called from the goroutine handling the input of the ListView.
+	OnSelectionChange func(selected []int)
+
+	mu       sync.Mutex
+	selected map[int]bool
+	anchor   int                       // where shift-click ranges start
+	cursor   int                       // row moved by the keyboard
+	rows     map[chan<- gui.Event]bool // wake up the running rows
+}
+
+// listChanged is injected into the rows to redraw them after the selection or the cursor changes.
+type listChanged struct{}
+
+func (listChanged) String() string { return "list/changed" }
+
+// NewListView runs l in env. Killing the returned Killable kills all of the rows.
+func NewListView(env gui.Env, l *ListView) gui.Killable {
+	l.mu.Lock()
+	l.rows = make(map[chan<- gui.Event]bool)
+	l.mu.Unlock()
+
+	// Handle the input above the Scroller, so that the keys moving the cursor don't scroll it too.
+	var focused, shift, ctrl bool
+	input := gui.Filter(env, func(e gui.Event) bool {
+		switch e := e.(type) {
+		case gui.FocusGained:
+			focused = true
+		case gui.FocusLost:
+			focused = false
+		case gui.MoDown:
+			if e.Button == gui.ButtonLeft {
+				if i := l.Scroller.ChildAt(e.Point); i >= 0 {
+					l.click(i, shift, ctrl)
+				}
+			}
+		case gui.KbDown:
+			switch e.Key {
+			case gui.KeyShift:
+				shift = true
+			case gui.KeyCtrl:
+				ctrl = true
+			}
+			return !focused || !l.key(e.Key, shift, ctrl)
+		case gui.KbRepeat:
+			return !focused || !l.key(e.Key, shift, ctrl)
+		case gui.KbUp:
+			switch e.Key {
+			case gui.KeyShift:
+				shift = false
+			case gui.KeyCtrl:
+				ctrl = false
+			}
+		}
+		return true
+	})
+
+	return gui.NewVirtualScroller(input, l.Scroller, func(env gui.Env, i int) {
+		env, inject := gui.NewInjector(env)
+		l.mu.Lock()
+		l.rows[inject] = true
+		l.mu.Unlock()
+
+		Run(env, &listRow{list: l, i: i})
+
+		l.mu.Lock()
+		delete(l.rows, inject)
+		l.mu.Unlock()
+		close(inject)
+	})
+}
+
+// Selection returns the selected rows, in order.
+func (l *ListView) Selection() []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.selection()
+}
+
+// Select selects exactly the given rows, or only the last one if l isn't Multiple, and scrolls the
+// last one into view. OnSelectionChange is not called.
+func (l *ListView) Select(rows ...int) {
+	if !l.Multiple && len(rows) > 1 {
+		rows = rows[len(rows)-1:]
+	}
+	l.change(func() {
+		clear(l.selected)
+		for _, i := range rows {
+			l.selected[i] = true
+		}
+		if len(rows) > 0 {
+			l.cursor, l.anchor = rows[len(rows)-1], rows[len(rows)-1]
+		}
+	})
+}
+
+// selection returns the selected rows, in order. l.mu must be held.
+func (l *ListView) selection() []int {
+	var sel []int
+	for i, ok := range l.selected {
+		if ok && i >= 0 && i < l.Scroller.Length {
+			sel = append(sel, i)
+		}
+	}
+	slices.Sort(sel)
+	return sel
+}
+
+// change changes the selection or the cursor with f, redraws the rows, and scrolls the cursor into
+// view. It returns the selection and whether it changed.
+func (l *ListView) change(f func()) (sel []int, changed bool) {
+	l.mu.Lock()
+	if l.selected == nil {
+		l.selected = make(map[int]bool)
+	}
+	before := l.selection()
+	f()
+	sel = l.selection()
+	cursor := l.cursor
+	for inject := range l.rows {
+		inject <- listChanged{}
+	}
+	l.mu.Unlock()
+
+	l.Scroller.ScrollIntoView(cursor)
+	return sel, !slices.Equal(before, sel)
+}
+
+// userChange is change for input from the user, which calls OnSelectionChange.
+func (l *ListView) userChange(f func()) {
+	if sel, changed := l.change(f); changed && l.OnSelectionChange != nil {
+		l.OnSelectionChange(sel)
+	}
+}
+
+// selectRange selects the rows from a to b, inclusive, in addition to the selected ones if add.
+// l.mu must be held.
+func (l *ListView) selectRange(a, b int, add bool) {
+	if !add {
+		clear(l.selected)
+	}
+	for i := min(a, b); i <= max(a, b); i++ {
+		l.selected[i] = true
+	}
+}
+
+func (l *ListView) click(i int, shift, ctrl bool) {
+	l.userChange(func() {
+		switch {
+		case l.Multiple && shift:
+			l.selectRange(l.anchor, i, ctrl)
+		case l.Multiple && ctrl:
+			l.selected[i] = !l.selected[i]
+			l.anchor = i
+		default:
+			l.selectRange(i, i, false)
+			l.anchor = i
+		}
+		l.cursor = i
+	})
+}
+
+// key handles a pressed key and reports whether it was one of the ListView.
+func (l *ListView) key(k gui.Key, shift, ctrl bool) bool {
+	last := l.Scroller.Length - 1
+	if last < 0 {
+		return false
+	}
+	switch k {
+	case gui.KeyUp, gui.KeyDown, gui.KeyHome, gui.KeyEnd:
+		l.userChange(func() {
+			switch k {
+			case gui.KeyUp:
+				l.cursor--
+			case gui.KeyDown:
+				l.cursor++
+			case gui.KeyHome:
+				l.cursor = 0
+			case gui.KeyEnd:
+				l.cursor = last
+			}
+			l.cursor = max(0, min(l.cursor, last))
+			switch {
+			case l.Multiple && shift:
+				l.selectRange(l.anchor, l.cursor, false)
+			case l.Multiple && ctrl:
+				// Only move the cursor.
+			default:
+				l.selectRange(l.cursor, l.cursor, false)
+				l.anchor = l.cursor
+			}
+		})
+	case gui.KeySpace:
+		l.userChange(func() {
+			if l.Multiple && ctrl {
+				l.selected[l.cursor] = !l.selected[l.cursor]
+			} else {
+				l.selectRange(l.cursor, l.cursor, false)
+			}
+			l.anchor = l.cursor
+		})
+	case gui.KeyA:
+		if !l.Multiple || !ctrl {
+			return false
+		}
+		l.userChange(func() { l.selectRange(0, last, false) })
+	default:
+		return false
+	}
+	return true
+}
+
+// listRow is the Widget of a running row of a ListView.
+type listRow struct {
+	list    *ListView
+	i       int
+	hovered bool
+}
+
+func (r *listRow) Event(ctx *Context, e gui.Event) bool {
+	switch e.(type) {
+	case listChanged, gui.FocusGained, gui.FocusLost:
+		return true
+	}
+	if ctx.Hovered != r.hovered {
+		r.hovered = ctx.Hovered
+		return true
+	}
+	return false
+}
+
+func (r *listRow) Draw(ctx *Context, dst draw.Image) {
+	l := r.list
+	l.mu.Lock()
+	selected, cursor := l.selected[r.i], l.cursor == r.i
+	l.mu.Unlock()
+
+	switch {
+	case selected:
+		draw.Draw(dst, ctx.Bounds, image.NewUniform(ctx.Color(theme.Selection)), image.Point{}, draw.Src)
+	case r.hovered:
+		draw.Draw(dst, ctx.Bounds, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+	}
+	if l.Row != nil {
+		l.Row(ctx, dst, r.i, selected)
+	} else if r.i < len(l.Items) {
+		pad := ctx.Theme.Padding
+		b := ctx.Bounds
+		ctx.DrawText(dst, image.Rect(b.Min.X+pad, b.Min.Y, b.Max.X-pad, b.Max.Y), l.Items[r.i], theme.Body, ctx.Color(theme.Foreground), text.AlignLeft)
+	}
+	if cursor && ctx.Focused {
+		paint.Border(dst, ctx.Bounds, 1, 0, ctx.Color(theme.Accent))
+	}
+}
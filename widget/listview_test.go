@@ -0,0 +1,69 @@
+package widget
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestListViewSelection(t *testing.T) {
+	var changes [][]int
+	l := &ListView{
+		Scroller:          &gui.Scroller{Length: 10},
+		Multiple:          true,
+		OnSelectionChange: func(sel []int) { changes = append(changes, sel) },
+	}
+
+	for _, step := range []struct {
+		do   func()
+		want []int
+	}{
+		{func() { l.click(2, false, false) }, []int{2}},
+		{func() { l.click(5, true, false) }, []int{2, 3, 4, 5}},
+		{func() { l.click(8, false, true) }, []int{2, 3, 4, 5, 8}},
+		{func() { l.click(5, false, true) }, []int{2, 3, 4, 8}},
+		{func() { l.key(gui.KeyDown, false, false) }, []int{6}},
+		{func() { l.key(gui.KeyDown, true, false) }, []int{6, 7}},
+		{func() { l.key(gui.KeyEnd, false, true) }, []int{6, 7}}, // only moves the cursor
+		{func() { l.key(gui.KeySpace, false, true) }, []int{6, 7, 9}},
+		{func() { l.key(gui.KeyUp, false, false) }, []int{8}},
+		{func() { l.key(gui.KeyA, false, true) }, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	} {
+		step.do()
+		if got := l.Selection(); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("received %v; wanted %v", got, step.want)
+		}
+	}
+	if len(changes) != 9 {
+		t.Errorf("OnSelectionChange called %d times; wanted 9", len(changes))
+	}
+
+	// Without Multiple, modifiers don't extend the selection.
+	l.Multiple = false
+	l.click(3, true, true)
+	if got, want := l.Selection(), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	if l.key(gui.KeyA, false, true) {
+		t.Errorf("ctrl-A handled without Multiple")
+	}
+
+	l.Select(1, 4)
+	if got, want := l.Selection(), []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
+
+func TestListViewRow(t *testing.T) {
+	l := &ListView{Scroller: &gui.Scroller{Length: 2}, Items: []string{"a", "b"}}
+	l.Select(1)
+	ctx := &Context{Bounds: image.Rect(0, 20, 100, 40), Theme: theme.Light()}
+	dst := image.NewRGBA(ctx.Bounds)
+	(&listRow{list: l, i: 1}).Draw(ctx, dst)
+	if got, want := dst.At(99, 39), ctx.Color(theme.Selection); !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
@@ -0,0 +1,690 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+var (
+	_ Widget = &MenuBar{}
+	_ Sizer  = &MenuBar{}
+	_ Widget = &ContextMenu{}
+	_ Sizer  = &ContextMenu{}
+)
+
+// MenuItem is an entry of a menu. An item with Items opens them as a submenu instead of running a
+// command, and an item without a Label is a separator.
+type MenuItem struct {
+	// Label is the text of the item. An & marks the rune after it as the mnemonic: it's underlined,
+	// and typing it while the menu is open activates the item. && is a plain &.
+	Label string
+	// Command is passed to OnCommand when the item is activated.
+	Command string
+	// Shortcut, if it has a Key, is shown next to the Label, and activates the item when pressed
+	// in an Env wrapped by the Shortcuts of the menu.
+	Shortcut gui.Shortcut
+	// Disabled items are drawn muted and can't be activated.
+	Disabled bool
+	// Items are the items of the submenu.
+	Items []MenuItem
+}
+
+// selectable reports whether the item can be highlighted with the keyboard.
+func (item MenuItem) selectable() bool {
+	return item.Label != "" && !item.Disabled
+}
+
+// parseLabel returns the text of a Label without the &s, and the byte index of the mnemonic in the
+// text, or -1 if there is none.
+func parseLabel(label string) (s string, mnemonic int) {
+	var b strings.Builder
+	mnemonic = -1
+	for i := 0; i < len(label); i++ {
+		if label[i] == '&' && i+1 < len(label) {
+			i++
+			if label[i] != '&' && mnemonic < 0 {
+				mnemonic = b.Len()
+			}
+		}
+		b.WriteByte(label[i])
+	}
+	return b.String(), mnemonic
+}
+
+// hasMnemonic reports whether r is the mnemonic of label, ignoring case.
+func hasMnemonic(label string, r rune) bool {
+	s, i := parseLabel(label)
+	if i < 0 {
+		return false
+	}
+	m, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.ToLower(m) == unicode.ToLower(r)
+}
+
+// bindShortcuts binds the Shortcuts of items and their submenus to run their commands.
+func bindShortcuts(sc *gui.Shortcuts, items []MenuItem, run func(command string)) {
+	for _, item := range items {
+		if item.Shortcut.Key != "" && !item.Disabled && len(item.Items) == 0 {
+			command := item.Command
+			sc.Bind(item.Shortcut, func() { run(command) })
+		}
+		bindShortcuts(sc, item.Items, run)
+	}
+}
+
+// drainOverlay starts draining the Events of an overlay, which is only drawn on.
+func drainOverlay(overlay gui.Env) {
+	if overlay == nil {
+		return
+	}
+	go func(events <-chan gui.Event) {
+		for range events {
+		}
+	}(overlay.Events())
+}
+
+// drawLabel draws a Label inside r with its mnemonic underlined.
+func drawLabel(ctx *Context, dst draw.Image, r image.Rectangle, label string, col color.Color) {
+	s, mnemonic := parseLabel(label)
+	ctx.DrawText(dst, r, s, theme.Body, col, text.AlignLeft)
+	if mnemonic < 0 {
+		return
+	}
+	m := ctx.Face(theme.Body).Metrics()
+	_, size := utf8.DecodeRuneInString(s[mnemonic:])
+	x0 := r.Min.X + ctx.MeasureText(s[:mnemonic], theme.Body).X
+	x1 := r.Min.X + ctx.MeasureText(s[:mnemonic+size], theme.Body).X
+	y := r.Min.Y + (r.Dy()-m.Height.Ceil())/2 + m.Ascent.Ceil() + 1
+	draw.Draw(dst, image.Rect(x0, y, x1, y+1).Intersect(r), image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// menuPopups are the open menus of a MenuBar or a ContextMenu, drawn on an overlay. The first level
+// is the menu opened first, and each next one is the submenu of the item highlighted in the one
+// before.
+type menuPopups struct {
+	overlay gui.Env
+	levels  []menuLevel
+	drawn   image.Rectangle // covered on the overlay
+	run     func(item MenuItem)
+}
+
+type menuLevel struct {
+	items     []MenuItem
+	rect      image.Rectangle
+	highlight int // -1 if none
+}
+
+func (p *menuPopups) isOpen() bool {
+	return len(p.levels) > 0
+}
+
+func (p *menuPopups) itemHeight(ctx *Context, item MenuItem) int {
+	if item.Label == "" {
+		return ctx.Theme.Padding
+	}
+	return ctx.Face(theme.Body).Metrics().Height.Ceil() + ctx.Theme.Padding
+}
+
+// size returns the size of a menu of items.
+func (p *menuPopups) size(ctx *Context, items []MenuItem) image.Point {
+	pad := ctx.Theme.Padding
+	var width, height int
+	for _, item := range items {
+		height += p.itemHeight(ctx, item)
+		s, _ := parseLabel(item.Label)
+		w := ctx.MeasureText(s, theme.Body).X
+		if item.Shortcut.Key != "" {
+			w += 4*pad + ctx.MeasureText(item.Shortcut.String(), theme.Body).X
+		}
+		if len(item.Items) > 0 {
+			w += 2 * pad
+		}
+		width = max(width, w)
+	}
+	bw := ctx.Theme.BorderWidth
+	return image.Pt(width+4*pad+2*bw, height+2*bw)
+}
+
+// open closes the open menus and opens one of items at pt.
+func (p *menuPopups) open(ctx *Context, items []MenuItem, pt image.Point) {
+	p.levels = p.levels[:0]
+	p.push(ctx, items, pt)
+	p.redraw(ctx)
+}
+
+// push opens a submenu of items at pt.
+func (p *menuPopups) push(ctx *Context, items []MenuItem, pt image.Point) {
+	r := image.Rectangle{pt, pt.Add(p.size(ctx, items))}
+	p.levels = append(p.levels, menuLevel{items, r, -1})
+}
+
+// close closes all of the menus.
+func (p *menuPopups) close() {
+	p.levels = p.levels[:0]
+	if p.overlay != nil && !p.drawn.Empty() {
+		r := p.drawn
+		p.overlay.Draw() <- func(drw draw.Image) image.Rectangle {
+			draw.Draw(drw, r, image.Transparent, image.Point{}, draw.Src)
+			return r
+		}
+	}
+	p.drawn = image.Rectangle{}
+}
+
+func (p *menuPopups) itemRect(ctx *Context, lvl, i int) image.Rectangle {
+	l := p.levels[lvl]
+	bw := ctx.Theme.BorderWidth
+	y := l.rect.Min.Y + bw
+	for _, item := range l.items[:i] {
+		y += p.itemHeight(ctx, item)
+	}
+	return image.Rect(l.rect.Min.X+bw, y, l.rect.Max.X-bw, y+p.itemHeight(ctx, l.items[i]))
+}
+
+// hit returns the level and the item under pt. The item is -1 if pt is on a menu but not on an
+// item, and the level is -1 if pt isn't on any menu.
+func (p *menuPopups) hit(ctx *Context, pt image.Point) (lvl, i int) {
+	for lvl := len(p.levels) - 1; lvl >= 0; lvl-- {
+		if !pt.In(p.levels[lvl].rect) {
+			continue
+		}
+		for i := range p.levels[lvl].items {
+			if pt.In(p.itemRect(ctx, lvl, i)) {
+				return lvl, i
+			}
+		}
+		return lvl, -1
+	}
+	return -1, -1
+}
+
+// highlight highlights item i of a level, closing the submenus after it, and opens the submenu of
+// the item if it has one.
+func (p *menuPopups) highlight(ctx *Context, lvl, i int) {
+	p.levels = p.levels[:lvl+1]
+	p.levels[lvl].highlight = i
+	if i < 0 {
+		return
+	}
+	if item := p.levels[lvl].items[i]; len(item.Items) > 0 && !item.Disabled {
+		r := p.itemRect(ctx, lvl, i)
+		p.push(ctx, item.Items, image.Pt(r.Max.X, r.Min.Y-ctx.Theme.BorderWidth))
+	}
+}
+
+// activate runs item i of a level, or opens its submenu and highlights the first item of it.
+func (p *menuPopups) activate(ctx *Context, lvl, i int) {
+	item := p.levels[lvl].items[i]
+	if !item.selectable() {
+		return
+	}
+	if len(item.Items) > 0 {
+		p.highlight(ctx, lvl, i)
+		sub := &p.levels[lvl+1]
+		sub.highlight = next(sub.items, -1, 1)
+		p.redraw(ctx)
+		return
+	}
+	p.close()
+	if p.run != nil {
+		p.run(item)
+	}
+}
+
+// next returns the first selectable item after, or before if step is negative, item from,
+// wrapping around. It returns from if there is none.
+func next(items []MenuItem, from, step int) int {
+	i := from
+	if i < 0 && step < 0 {
+		i = 0
+	}
+	for range items {
+		i = (i + step + len(items)) % len(items)
+		if items[i].selectable() {
+			return i
+		}
+	}
+	return from
+}
+
+// mouseMove highlights the item under pt. It reports whether pt is on a menu.
+func (p *menuPopups) mouseMove(ctx *Context, pt image.Point) bool {
+	lvl, i := p.hit(ctx, pt)
+	if lvl < 0 {
+		return false
+	}
+	if i != p.levels[lvl].highlight {
+		p.highlight(ctx, lvl, i)
+		p.redraw(ctx)
+	}
+	return true
+}
+
+// mouseDown activates the item under pt. It reports whether pt is on a menu.
+func (p *menuPopups) mouseDown(ctx *Context, pt image.Point) bool {
+	lvl, i := p.hit(ctx, pt)
+	if lvl < 0 {
+		return false
+	}
+	if i >= 0 {
+		p.activate(ctx, lvl, i)
+	}
+	return true
+}
+
+// key handles a pressed key in the last opened menu, and reports whether it was one of the menus.
+// Left in the first menu isn't, so that a MenuBar can move to the menu before.
+func (p *menuPopups) key(ctx *Context, k gui.Key) bool {
+	top := len(p.levels) - 1
+	l := &p.levels[top]
+	switch k {
+	case gui.KeyUp, gui.KeyDown:
+		step := 1
+		if k == gui.KeyUp {
+			step = -1
+		}
+		p.highlight(ctx, top, next(l.items, l.highlight, step))
+		p.levels = p.levels[:top+1] // only open submenus with Right
+		p.redraw(ctx)
+	case gui.KeyRight:
+		if l.highlight < 0 || len(l.items[l.highlight].Items) == 0 {
+			return false
+		}
+		p.activate(ctx, top, l.highlight)
+	case gui.KeyLeft:
+		if top == 0 {
+			return false
+		}
+		p.levels = p.levels[:top]
+		p.redraw(ctx)
+	case gui.KeyEnter, gui.KeySpace:
+		if l.highlight >= 0 {
+			p.activate(ctx, top, l.highlight)
+		}
+	case gui.KeyEscape:
+		if top == 0 {
+			p.close()
+			return true
+		}
+		p.levels = p.levels[:top]
+		p.redraw(ctx)
+	default:
+		return false
+	}
+	return true
+}
+
+// typed activates the item of the last opened menu whose mnemonic is r, and reports whether there
+// is one.
+func (p *menuPopups) typed(ctx *Context, r rune) bool {
+	top := len(p.levels) - 1
+	for i, item := range p.levels[top].items {
+		if hasMnemonic(item.Label, r) {
+			p.activate(ctx, top, i)
+			return true
+		}
+	}
+	return false
+}
+
+// redraw draws the open menus onto the overlay, and clears what they covered before.
+func (p *menuPopups) redraw(ctx *Context) {
+	var r image.Rectangle
+	for _, l := range p.levels {
+		r = r.Union(l.rect)
+	}
+	area := r.Union(p.drawn)
+	p.drawn = r
+	if p.overlay == nil || area.Empty() {
+		return
+	}
+
+	img := image.NewRGBA(area)
+	for lvl := range p.levels {
+		p.drawLevel(ctx, img, lvl)
+	}
+	p.overlay.Draw() <- func(drw draw.Image) image.Rectangle {
+		draw.Draw(drw, area, img, area.Min, draw.Src)
+		return area
+	}
+}
+
+func (p *menuPopups) drawLevel(ctx *Context, dst draw.Image, lvl int) {
+	l := p.levels[lvl]
+	pad := ctx.Theme.Padding
+	draw.Draw(dst, l.rect, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+
+	for i, item := range l.items {
+		r := p.itemRect(ctx, lvl, i)
+		if item.Label == "" {
+			y := r.Min.Y + r.Dy()/2
+			draw.Draw(dst, image.Rect(r.Min.X+pad, y, r.Max.X-pad, y+1), image.NewUniform(ctx.Color(theme.Border)), image.Point{}, draw.Src)
+			continue
+		}
+		fg, muted := ctx.Color(theme.Foreground), ctx.Color(theme.Muted)
+		switch {
+		case item.Disabled:
+			fg = muted
+		case i == l.highlight:
+			draw.Draw(dst, r, image.NewUniform(ctx.Color(theme.Accent)), image.Point{}, draw.Src)
+			fg, muted = ctx.Color(theme.AccentForeground), ctx.Color(theme.AccentForeground)
+		}
+		inner := image.Rect(r.Min.X+2*pad, r.Min.Y, r.Max.X-2*pad, r.Max.Y)
+		drawLabel(ctx, dst, inner, item.Label, fg)
+		if item.Shortcut.Key != "" {
+			ctx.DrawText(dst, inner, item.Shortcut.String(), theme.Body, muted, text.AlignRight)
+		}
+		if len(item.Items) > 0 {
+			// A triangle pointing right at the end.
+			a := float64(pad) / 2
+			cx, cy := float64(r.Max.X-pad), float64(r.Min.Y+r.Dy()/2)
+			tri := new(paint.Path)
+			tri.MoveTo(cx-a, cy-a)
+			tri.LineTo(cx, cy)
+			tri.LineTo(cx-a, cy+a)
+			tri.Close()
+			paint.Fill(dst, tri, fg)
+		}
+	}
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(dst, l.rect, float64(w), 0, ctx.Color(theme.Border))
+	}
+}
+
+// MenuBar is a Widget showing a row of menus, each opened below its title by clicking it.
+//
+// While a menu is open, moving the mouse over another title opens that one instead, Up and Down
+// move through the items, Right and Left open and close submenus or move to the next and previous
+// menu, Enter and Space activate the highlighted item, typing the mnemonic of an item activates
+// it, and Escape closes the last opened menu. Alt together with the mnemonic of a title opens its
+// menu. Like any keys, these only reach the MenuBar while it has focus, or if it isn't in a
+// gui.FocusManager at all.
+//
+// The menus are drawn onto Overlay, which works like the Overlay of a Dropdown.
+type MenuBar struct {
+	// Menus are the titles of the menus, with the items of each menu as their Items.
+	Menus   []MenuItem
+	Overlay gui.Env
+	// Shortcuts, if not nil, gets the Shortcuts of the items bound to run their commands.
+	Shortcuts *gui.Shortcuts
+	// OnCommand is called with the Command of each activated item, from the goroutine running the
+	// MenuBar, or from the one of the Shortcuts when activated by a Shortcut.
+	OnCommand func(command string)
+
+	init   bool
+	popups menuPopups
+	open   int // index of the open menu, or -1
+	alt    bool
+}
+
+func (m *MenuBar) command(command string) {
+	if m.OnCommand != nil {
+		m.OnCommand(command)
+	}
+}
+
+// titles returns the Rectangles of the titles of the menus.
+func (m *MenuBar) titles(ctx *Context) []image.Rectangle {
+	pad := ctx.Theme.Padding
+	b := ctx.Bounds
+	x := b.Min.X
+	rects := make([]image.Rectangle, len(m.Menus))
+	for i, menu := range m.Menus {
+		s, _ := parseLabel(menu.Label)
+		w := ctx.MeasureText(s, theme.Body).X + 2*pad
+		rects[i] = image.Rect(x, b.Min.Y, x+w, b.Max.Y)
+		x += w
+	}
+	return rects
+}
+
+func (m *MenuBar) titleAt(ctx *Context, pt image.Point) int {
+	for i, r := range m.titles(ctx) {
+		if pt.In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// openMenu opens menu i below its title, highlighting its first item if highlight, or closes the
+// open menu if i is -1 or the menu is empty.
+func (m *MenuBar) openMenu(ctx *Context, i int, highlight bool) {
+	if i >= 0 && len(m.Menus[i].Items) == 0 {
+		i = -1
+	}
+	m.open = i
+	if i < 0 {
+		m.popups.close()
+		return
+	}
+	r := m.titles(ctx)[i]
+	m.popups.open(ctx, m.Menus[i].Items, image.Pt(r.Min.X, r.Max.Y))
+	if highlight {
+		items := m.Menus[i].Items
+		m.popups.levels[0].highlight = next(items, -1, 1)
+		m.popups.redraw(ctx)
+	}
+}
+
+// closed updates the open menu after the menus may have closed themselves, and reports whether
+// they did.
+func (m *MenuBar) closed() bool {
+	if m.open >= 0 && !m.popups.isOpen() {
+		m.open = -1
+		return true
+	}
+	return false
+}
+
+func (m *MenuBar) Event(ctx *Context, e gui.Event) bool {
+	if !m.init {
+		m.init, m.open = true, -1
+		m.popups.overlay = m.Overlay
+		m.popups.run = func(item MenuItem) { m.command(item.Command) }
+		drainOverlay(m.Overlay)
+		if m.Shortcuts != nil {
+			for _, menu := range m.Menus {
+				bindShortcuts(m.Shortcuts, menu.Items, m.command)
+			}
+		}
+	}
+
+	switch e := e.(type) {
+	case gui.Resize, theme.ThemeChanged, gui.FocusLost:
+		m.alt = false
+		if m.open >= 0 {
+			m.openMenu(ctx, -1, false)
+		}
+		return true
+	case gui.MoDown:
+		if e.Button != gui.ButtonLeft {
+			return false
+		}
+		if m.popups.mouseDown(ctx, e.Point) {
+			return m.closed()
+		}
+		if i := m.titleAt(ctx, e.Point); i >= 0 && i != m.open {
+			m.openMenu(ctx, i, false)
+			return true
+		}
+		if m.open >= 0 {
+			m.openMenu(ctx, -1, false)
+			return true
+		}
+	case gui.MoMove:
+		if m.open < 0 {
+			return false
+		}
+		if i := m.titleAt(ctx, e.Point); i >= 0 && i != m.open {
+			m.openMenu(ctx, i, false)
+			return true
+		}
+		m.popups.mouseMove(ctx, e.Point)
+	case gui.KbDown:
+		if e.Key == gui.KeyAlt {
+			m.alt = true
+			return false
+		}
+		if m.alt {
+			for i, menu := range m.Menus {
+				if r, _ := utf8.DecodeRuneInString(string(e.Key)); len(e.Key) == utf8.RuneLen(r) && hasMnemonic(menu.Label, r) {
+					m.openMenu(ctx, i, true)
+					return true
+				}
+			}
+		}
+		return m.key(ctx, e.Key)
+	case gui.KbRepeat:
+		return m.key(ctx, e.Key)
+	case gui.KbUp:
+		if e.Key == gui.KeyAlt {
+			m.alt = false
+		}
+	case gui.KbType:
+		if m.open >= 0 && !m.alt {
+			m.popups.typed(ctx, e.Rune)
+			return m.closed()
+		}
+	}
+	return false
+}
+
+// key handles a pressed key while a menu is open. It returns false if the MenuBar doesn't need to
+// be redrawn.
+func (m *MenuBar) key(ctx *Context, k gui.Key) bool {
+	if m.open < 0 {
+		return false
+	}
+	if m.popups.key(ctx, k) {
+		return m.closed()
+	}
+	if k == gui.KeyLeft || k == gui.KeyRight {
+		step := 1
+		if k == gui.KeyLeft {
+			step = -1
+		}
+		m.openMenu(ctx, (m.open+step+len(m.Menus))%len(m.Menus), true)
+		return true
+	}
+	return false
+}
+
+func (m *MenuBar) Draw(ctx *Context, dst draw.Image) {
+	draw.Draw(dst, ctx.Bounds, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+	pad := ctx.Theme.Padding
+	for i, r := range m.titles(ctx) {
+		fg := ctx.Color(theme.Foreground)
+		switch {
+		case m.Menus[i].Disabled:
+			fg = ctx.Color(theme.Muted)
+		case i == m.open:
+			draw.Draw(dst, r, image.NewUniform(ctx.Color(theme.Accent)), image.Point{}, draw.Src)
+			fg = ctx.Color(theme.AccentForeground)
+		}
+		drawLabel(ctx, dst, image.Rect(r.Min.X+pad, r.Min.Y, r.Max.X-pad, r.Max.Y), m.Menus[i].Label, fg)
+	}
+}
+
+func (m *MenuBar) SizeHint(ctx *Context) gui.SizeHint {
+	var width int
+	for _, menu := range m.Menus {
+		s, _ := parseLabel(menu.Label)
+		width += ctx.MeasureText(s, theme.Body).X + 2*ctx.Theme.Padding
+	}
+	size := image.Pt(width, ctx.Face(theme.Body).Metrics().Height.Ceil()+ctx.Theme.Padding)
+	return gui.SizeHint{Min: size, Preferred: size}
+}
+
+// ContextMenu is a Widget adding a menu to another Widget, opened where it's right-clicked.
+//
+// The menu is used with the mouse and the keyboard like the menus of a MenuBar, and drawn onto
+// Overlay, which works like the Overlay of a Dropdown. While it's open, clicking outside of it
+// closes it, and it takes the keys it uses. All other Events are passed to the Widget.
+type ContextMenu struct {
+	Widget  Widget
+	Items   []MenuItem
+	Overlay gui.Env
+	// Shortcuts, if not nil, gets the Shortcuts of the items bound to run their commands.
+	Shortcuts *gui.Shortcuts
+	// OnCommand is called with the Command of each activated item, from the goroutine running the
+	// ContextMenu, or from the one of the Shortcuts when activated by a Shortcut.
+	OnCommand func(command string)
+
+	init   bool
+	popups menuPopups
+}
+
+func (c *ContextMenu) command(command string) {
+	if c.OnCommand != nil {
+		c.OnCommand(command)
+	}
+}
+
+func (c *ContextMenu) Event(ctx *Context, e gui.Event) bool {
+	if !c.init {
+		c.init = true
+		c.popups.overlay = c.Overlay
+		c.popups.run = func(item MenuItem) { c.command(item.Command) }
+		drainOverlay(c.Overlay)
+		if c.Shortcuts != nil {
+			bindShortcuts(c.Shortcuts, c.Items, c.command)
+		}
+	}
+
+	if c.popups.isOpen() {
+		switch e := e.(type) {
+		case gui.Resize, theme.ThemeChanged, gui.FocusLost:
+			c.popups.close()
+		case gui.MoDown:
+			if c.popups.mouseDown(ctx, e.Point) {
+				return false
+			}
+			c.popups.close()
+		case gui.MoMove:
+			c.popups.mouseMove(ctx, e.Point)
+		case gui.KbDown:
+			if c.popups.key(ctx, e.Key) {
+				return false
+			}
+		case gui.KbRepeat:
+			if c.popups.key(ctx, e.Key) {
+				return false
+			}
+		case gui.KbType:
+			c.popups.typed(ctx, e.Rune)
+			return false
+		}
+	} else if md, ok := e.(gui.MoDown); ok && md.Button == gui.ButtonRight && md.In(ctx.Bounds) && len(c.Items) > 0 {
+		c.popups.open(ctx, c.Items, md.Point)
+		return false
+	}
+
+	if c.Widget == nil {
+		return false
+	}
+	return c.Widget.Event(ctx, e)
+}
+
+func (c *ContextMenu) Draw(ctx *Context, dst draw.Image) {
+	if c.Widget != nil {
+		c.Widget.Draw(ctx, dst)
+	}
+}
+
+// SizeHint returns the SizeHint of the Widget if it's a Sizer.
+func (c *ContextMenu) SizeHint(ctx *Context) gui.SizeHint {
+	if s, ok := c.Widget.(Sizer); ok {
+		return s.SizeHint(ctx)
+	}
+	return gui.SizeHint{}
+}
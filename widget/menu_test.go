@@ -0,0 +1,115 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestParseLabel(t *testing.T) {
+	for _, tc := range []struct {
+		label, text string
+		mnemonic    int
+	}{
+		{"&File", "File", 0},
+		{"Save &As", "Save As", 5},
+		{"Fish && &Chips", "Fish & Chips", 7},
+		{"Plain", "Plain", -1},
+	} {
+		text, mnemonic := parseLabel(tc.label)
+		if text != tc.text || mnemonic != tc.mnemonic {
+			t.Errorf("parseLabel(%q) = %q, %d; wanted %q, %d", tc.label, text, mnemonic, tc.text, tc.mnemonic)
+		}
+	}
+}
+
+func TestMenuBar(t *testing.T) {
+	var commands []string
+	var sc gui.Shortcuts
+	m := &MenuBar{
+		Menus: []MenuItem{
+			{Label: "&File", Items: []MenuItem{
+				{Label: "&Open", Command: "open", Shortcut: gui.Shortcut{Key: gui.KeyO, Ctrl: true}},
+				{},
+				{Label: "&Recent", Items: []MenuItem{{Label: "a.txt", Command: "recent a"}}},
+			}},
+			{Label: "&Edit", Items: []MenuItem{
+				{Label: "&Undo", Command: "undo", Disabled: true},
+				{Label: "&Copy", Command: "copy"},
+			}},
+		},
+		Shortcuts: &sc,
+		OnCommand: func(command string) { commands = append(commands, command) },
+	}
+	ctx := &Context{Bounds: image.Rect(0, 0, 300, 20), Theme: theme.Light(), Focused: true}
+	m.Event(ctx, gui.Resize{Rectangle: ctx.Bounds})
+
+	// Clicking a title opens its menu below it, and clicking an item runs it.
+	m.Event(ctx, gui.MoDown{Point: image.Pt(5, 10), Button: gui.ButtonLeft})
+	if m.open != 0 || m.popups.levels[0].rect.Min != image.Pt(0, 20) {
+		t.Fatalf("menu %d open at %v; wanted menu 0 at (0,20)", m.open, m.popups.levels[0].rect)
+	}
+	open := m.popups.itemRect(ctx, 0, 0)
+	m.Event(ctx, gui.MoDown{Point: open.Min.Add(image.Pt(1, 1)), Button: gui.ButtonLeft})
+
+	// Alt with a mnemonic opens a menu with the first enabled item highlighted.
+	m.Event(ctx, gui.KbDown{Key: gui.KeyAlt})
+	m.Event(ctx, gui.KbDown{Key: gui.KeyE})
+	m.Event(ctx, gui.KbUp{Key: gui.KeyAlt})
+	if m.open != 1 || m.popups.levels[0].highlight != 1 {
+		t.Fatalf("menu %d open with item %d highlighted; wanted menu 1 with item 1", m.open, m.popups.levels[0].highlight)
+	}
+	m.Event(ctx, gui.KbDown{Key: gui.KeyEnter})
+
+	// Submenus open with Right, and items are activated by their mnemonics.
+	m.Event(ctx, gui.KbDown{Key: gui.KeyAlt})
+	m.Event(ctx, gui.KbDown{Key: gui.KeyF})
+	m.Event(ctx, gui.KbUp{Key: gui.KeyAlt})
+	m.Event(ctx, gui.KbType{Rune: 'r'})
+	if len(m.popups.levels) != 2 {
+		t.Fatalf("%d menus open; wanted 2", len(m.popups.levels))
+	}
+	m.Event(ctx, gui.KbDown{Key: gui.KeyEnter})
+	if m.open != -1 || m.popups.isOpen() {
+		t.Errorf("menus still open after activating an item")
+	}
+
+	want := []string{"open", "copy", "recent a"}
+	if len(commands) != len(want) || commands[0] != want[0] || commands[1] != want[1] || commands[2] != want[2] {
+		t.Errorf("received %v; wanted %v", commands, want)
+	}
+	m.Draw(ctx, image.NewRGBA(ctx.Bounds))
+}
+
+func TestContextMenu(t *testing.T) {
+	var commands []string
+	b := &Button{Label: "Target"}
+	c := &ContextMenu{
+		Widget:    b,
+		Items:     []MenuItem{{Label: "&Delete", Command: "delete"}},
+		OnCommand: func(command string) { commands = append(commands, command) },
+	}
+	ctx := &Context{Bounds: image.Rect(0, 0, 100, 30), Theme: theme.Light()}
+	c.Event(ctx, gui.Resize{Rectangle: ctx.Bounds})
+
+	c.Event(ctx, gui.MoDown{Point: image.Pt(40, 10), Button: gui.ButtonRight})
+	if !c.popups.isOpen() || c.popups.levels[0].rect.Min != image.Pt(40, 10) {
+		t.Fatalf("context menu not open at (40,10)")
+	}
+	// The Widget doesn't see the click on the menu.
+	c.Event(ctx, gui.MoDown{Point: image.Pt(45, 15), Button: gui.ButtonLeft})
+	if b.pressed {
+		t.Errorf("click on the menu passed to the Widget")
+	}
+	if len(commands) != 1 || commands[0] != "delete" {
+		t.Errorf("received %v; wanted [delete]", commands)
+	}
+
+	c.Event(ctx, gui.MoDown{Point: image.Pt(40, 10), Button: gui.ButtonRight})
+	c.Event(ctx, gui.KbDown{Key: gui.KeyEscape})
+	if c.popups.isOpen() {
+		t.Errorf("context menu still open after Escape")
+	}
+}
@@ -0,0 +1,154 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"time"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/theme"
+)
+
+var (
+	_ Widget  = &Progress{}
+	_ Sizer   = &Progress{}
+	_ Damager = &Progress{}
+	_ Widget  = &Spinner{}
+	_ Sizer   = &Spinner{}
+	_ Damager = &Spinner{}
+)
+
+// Progress is a Widget showing how much of something is done as a bar filling up from the left.
+//
+// It is updated by Value[float64] Events, e.g. from Feed. An Indeterminate Progress shows activity
+// of unknown length instead, as a segment sliding along the bar with each gui.Tick, e.g. from
+// gui.Ticker. Only the changed part of the bar is redrawn.
+type Progress struct {
+	// Value is the fraction done, from 0 to 1.
+	Value float64
+	// Indeterminate shows activity instead of the Value, until the next Value[float64].
+	Indeterminate bool
+
+	phase float64         // of the indeterminate segment, from 0 to 1
+	drawn image.Rectangle // filled part of the bar as last drawn
+}
+
+// progressPeriod is how long the indeterminate segment of a Progress takes to slide along the bar.
+const progressPeriod = 1500 * time.Millisecond
+
+func (p *Progress) Event(ctx *Context, e gui.Event) bool {
+	switch e := e.(type) {
+	case Value[float64]:
+		p.Value, p.Indeterminate = max(0, min(e.Value, 1)), false
+		return true
+	case gui.Tick:
+		if p.Indeterminate {
+			p.phase = float64(e.Time.UnixNano()%int64(progressPeriod)) / float64(progressPeriod)
+			return true
+		}
+	}
+	return false
+}
+
+// fill returns the filled part of the bar.
+func (p *Progress) fill(ctx *Context) image.Rectangle {
+	b := ctx.Bounds
+	if !p.Indeterminate {
+		return image.Rect(b.Min.X, b.Min.Y, b.Min.X+int(math.Round(p.Value*float64(b.Dx()))), b.Max.Y)
+	}
+	seg := b.Dx() / 4
+	x := b.Min.X - seg + int(p.phase*float64(b.Dx()+seg))
+	return image.Rect(x, b.Min.Y, x+seg, b.Max.Y).Intersect(b)
+}
+
+func (p *Progress) Damage(ctx *Context) image.Rectangle {
+	old, cur := p.drawn, p.fill(ctx)
+	if old == cur {
+		return image.Rectangle{}
+	}
+	b := ctx.Bounds
+	if old.Min.X == cur.Min.X {
+		// Only the end moved, and the round cap with it.
+		return image.Rect(min(old.Max.X, cur.Max.X)-b.Dy(), b.Min.Y, max(old.Max.X, cur.Max.X), b.Max.Y)
+	}
+	return old.Union(cur).Inset(-b.Dy())
+}
+
+func (p *Progress) Draw(ctx *Context, dst draw.Image) {
+	b := ctx.Bounds
+	radius := float64(b.Dy()) / 2
+	paint.Fill(dst, paint.RoundedRect(b, radius), ctx.Color(theme.Surface))
+	p.drawn = p.fill(ctx)
+	if !p.drawn.Empty() {
+		paint.Fill(dst, paint.RoundedRect(p.drawn, radius), ctx.Color(theme.Accent))
+	}
+}
+
+func (p *Progress) SizeHint(ctx *Context) gui.SizeHint {
+	h := max(ctx.Theme.Padding, 4)
+	return gui.SizeHint{Min: image.Pt(4*h, h), Preferred: image.Pt(40*h, h)}
+}
+
+// Spinner is a Widget showing that something is going on: an arc turning with each gui.Tick,
+// e.g. from gui.Ticker. It's drawn in the middle of its bounds, and only that square is redrawn.
+type Spinner struct {
+	// Period is how long a turn takes. Defaults to a second.
+	Period time.Duration
+
+	angle float64
+}
+
+func (s *Spinner) Event(ctx *Context, e gui.Event) bool {
+	if tick, ok := e.(gui.Tick); ok {
+		period := s.Period
+		if period <= 0 {
+			period = time.Second
+		}
+		s.angle = 2 * math.Pi * float64(tick.Time.UnixNano()%int64(period)) / float64(period)
+		return true
+	}
+	return false
+}
+
+// square returns the square the Spinner is drawn in.
+func (s *Spinner) square(ctx *Context) image.Rectangle {
+	b := ctx.Bounds
+	size := min(b.Dx(), b.Dy())
+	origin := b.Min.Add(image.Pt(b.Dx()-size, b.Dy()-size).Div(2))
+	return image.Rectangle{origin, origin.Add(image.Pt(size, size))}
+}
+
+func (s *Spinner) Damage(ctx *Context) image.Rectangle {
+	return s.square(ctx)
+}
+
+func (s *Spinner) Draw(ctx *Context, dst draw.Image) {
+	sq := s.square(ctx)
+	width := max(float64(sq.Dx())/8, 2)
+	radius := float64(sq.Dx())/2 - width
+	if radius <= 0 {
+		return
+	}
+	cx, cy := float64(sq.Min.X)+float64(sq.Dx())/2, float64(sq.Min.Y)+float64(sq.Dy())/2
+
+	// Three quarters of a circle, starting at the angle.
+	const segments = 24
+	p := new(paint.Path)
+	for i := 0; i <= segments; i++ {
+		a := s.angle + 1.5*math.Pi*float64(i)/segments
+		x, y := cx+radius*math.Cos(a), cy+radius*math.Sin(a)
+		if i == 0 {
+			p.MoveTo(x, y)
+		} else {
+			p.LineTo(x, y)
+		}
+	}
+	paint.Stroke(dst, p, width, ctx.Color(theme.Accent))
+}
+
+func (s *Spinner) SizeHint(ctx *Context) gui.SizeHint {
+	size := ctx.Face(theme.Body).Metrics().Height.Ceil()
+	return gui.SizeHint{Min: image.Pt(size, size), Preferred: image.Pt(size, size)}
+}
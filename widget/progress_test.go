@@ -0,0 +1,59 @@
+package widget
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestProgressDamage(t *testing.T) {
+	p := &Progress{}
+	ctx := &Context{Bounds: image.Rect(0, 0, 100, 8), Theme: theme.Light()}
+	dst := image.NewRGBA(ctx.Bounds)
+	p.Draw(ctx, dst)
+
+	// Growing the bar only damages the part around its end.
+	p.Event(ctx, Value[float64]{0.5})
+	p.Event(ctx, Value[float64]{0.5})
+	if got, want := p.Damage(ctx), image.Rect(-8, 0, 50, 8); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	p.Draw(ctx, dst)
+	p.Event(ctx, Value[float64]{0.6})
+	if got, want := p.Damage(ctx), image.Rect(42, 0, 60, 8); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	p.Draw(ctx, dst)
+	if got := p.Damage(ctx); !got.Empty() {
+		t.Errorf("damage %v after drawing; wanted none", got)
+	}
+
+	// Ticks only move an indeterminate Progress.
+	if p.Event(ctx, gui.Tick{Time: time.Now()}) {
+		t.Errorf("determinate Progress redrawn on Tick")
+	}
+	p.Indeterminate = true
+	if !p.Event(ctx, gui.Tick{Time: time.Unix(0, int64(progressPeriod/2))}) {
+		t.Errorf("indeterminate Progress not redrawn on Tick")
+	}
+	if got, want := p.fill(ctx), image.Rect(37, 0, 62, 8); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	s := &Spinner{}
+	ctx := &Context{Bounds: image.Rect(0, 0, 100, 20), Theme: theme.Light()}
+	if got, want := s.Damage(ctx), image.Rect(40, 0, 60, 20); got != want {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	s.Event(ctx, gui.Tick{Time: time.Unix(0, int64(time.Second/4))})
+	if got, want := s.angle, math.Pi/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	s.Draw(ctx, image.NewRGBA(ctx.Bounds))
+}
@@ -0,0 +1,342 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+// TableModel is the data shown by a Table. Its methods are called from the goroutines of the rows
+// of the Table, so they must be safe to call concurrently.
+type TableModel interface {
+	// Rows returns the number of rows.
+	Rows() int
+	// Cell returns the text of the cell of a row in column col.
+	Cell(row, col int) string
+}
+
+// TableSorter is a TableModel that compares cells itself, e.g. to sort numbers by value. The cells
+// of other TableModels are compared as text, ignoring case.
+type TableSorter interface {
+	TableModel
+	// Less reports whether the cell of row a in column col sorts before the one of row b.
+	Less(col, a, b int) bool
+}
+
+// TableColumn is a column of a Table.
+type TableColumn struct {
+	Title string
+	// Width is the initial width in pixels. Defaults to 100.
+	Width int
+	// Sortable columns sort the rows when their header is clicked. Clicking again reverses the
+	// order.
+	Sortable bool
+	// Align aligns the text of the cells.
+	Align text.Align
+	// Render, if not nil, draws the cell of a row of the TableModel inside ctx.Bounds instead of
+	// its text. dst is clipped to the cell. Like the TableModel, it may be called concurrently.
+	Render func(ctx *Context, dst draw.Image, row int)
+}
+
+// Table is a Widget-based data grid: a header with the titles of the Columns above a virtual
+// scrolling list of the rows of a TableModel, see gui.NewVirtualScroller, so only the visible rows
+// are run, drawn, and read from the Model.
+//
+// Dragging the edge between two column titles resizes the column on the left. Clicking the title
+// of a Sortable column sorts the rows by it.
+//
+// A Table must be used by pointer, and run by NewTable.
+type Table struct {
+	Model   TableModel
+	Columns []TableColumn
+	// Scroller lays out the rows. Its Length is kept to the number of rows of the Model, and its
+	// ChildSize.Y is the height of the rows and the header, which defaults to 24 pixels.
+	Scroller *gui.Scroller
+
+	mu         sync.Mutex
+	widths     []int
+	order      []int // row of the Model shown at each position
+	sortCol    int   // -1 if unsorted
+	descending bool
+	rows       map[chan<- gui.Event]bool // wake up the running rows
+}
+
+// tableChanged is injected into the rows to redraw them after the column widths or the order of
+// the rows change.
+type tableChanged struct{}
+
+func (tableChanged) String() string { return "table/changed" }
+
+// NewTable runs t in env. Killing the returned Killable kills the header and all of the rows.
+func NewTable(env gui.Env, t *Table) gui.Killable {
+	if t.Scroller.ChildSize.Y <= 0 {
+		t.Scroller.ChildSize.Y = 24
+	}
+	t.mu.Lock()
+	t.widths = make([]int, len(t.Columns))
+	for i, col := range t.Columns {
+		t.widths[i] = col.Width
+		if t.widths[i] <= 0 {
+			t.widths[i] = 100
+		}
+	}
+	t.sortCol = -1
+	t.rows = make(map[chan<- gui.Event]bool)
+	t.order = t.sorted(t.Model.Rows())
+	t.Scroller.Length = len(t.order)
+	t.mu.Unlock()
+
+	var header, body gui.Env
+	layout := gui.NewLayout(env, []*gui.Env{&header, &body}, gui.Table{
+		Cells: []gui.TableCell{{Row: 0}, {Row: 1}},
+		Rows:  []gui.TrackSize{{Fixed: t.Scroller.ChildSize.Y}, {}},
+	})
+	go Run(header, &tableHeader{table: t, drag: -1, down: -1})
+	gui.NewVirtualScroller(body, t.Scroller, func(env gui.Env, i int) {
+		env, inject := gui.NewInjector(env)
+		t.mu.Lock()
+		t.rows[inject] = true
+		t.mu.Unlock()
+
+		Run(env, &tableRow{table: t, i: i})
+
+		t.mu.Lock()
+		delete(t.rows, inject)
+		t.mu.Unlock()
+		close(inject)
+	})
+	return layout
+}
+
+// Refresh shows the current data of the Model, e.g. after rows were added, keeping the order the
+// rows are sorted in.
+func (t *Table) Refresh() {
+	t.mu.Lock()
+	t.order = t.sorted(t.Model.Rows())
+	n := len(t.order)
+	t.changed()
+	t.mu.Unlock()
+	t.Scroller.SetLength(n)
+}
+
+// sorted returns the n rows of the Model in the order of the sorted column. t.mu must be held.
+func (t *Table) sorted(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if t.sortCol < 0 {
+		return order
+	}
+	col := t.sortCol
+	less := func(a, b int) bool {
+		return strings.ToLower(t.Model.Cell(a, col)) < strings.ToLower(t.Model.Cell(b, col))
+	}
+	if s, ok := t.Model.(TableSorter); ok {
+		less = func(a, b int) bool { return s.Less(col, a, b) }
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if t.descending {
+			return less(order[j], order[i])
+		}
+		return less(order[i], order[j])
+	})
+	return order
+}
+
+// sortBy sorts the rows by column col, reversing the order if they already are.
+func (t *Table) sortBy(col int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sortCol == col {
+		t.descending = !t.descending
+	} else {
+		t.sortCol, t.descending = col, false
+	}
+	t.order = t.sorted(len(t.order))
+	t.changed()
+}
+
+// resize sets the width of column col. t.mu must not be held.
+func (t *Table) resize(col, width int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.widths[col] = width
+	t.changed()
+}
+
+// changed redraws the rows. t.mu must be held.
+func (t *Table) changed() {
+	for inject := range t.rows {
+		inject <- tableChanged{}
+	}
+}
+
+// columns returns the Rectangles of the columns within r.
+func (t *Table) columns(r image.Rectangle) []image.Rectangle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rects := make([]image.Rectangle, len(t.widths))
+	x := r.Min.X
+	for i, w := range t.widths {
+		rects[i] = image.Rect(x, r.Min.Y, x+w, r.Max.Y)
+		x += w
+	}
+	return rects
+}
+
+// tableResizeSlop is how far from the edge of a column title, in pixels, dragging resizes the
+// column.
+const tableResizeSlop = 4
+
+// tableMinWidth is the narrowest a column can be resized to.
+const tableMinWidth = 16
+
+// tableHeader is the Widget of the header of a Table.
+type tableHeader struct {
+	table *Table
+	drag  int // column being resized, or -1
+	down  int // column whose title was pressed, or -1
+}
+
+func (h *tableHeader) Event(ctx *Context, e gui.Event) bool {
+	t := h.table
+	switch e := e.(type) {
+	case gui.MoDown:
+		if e.Button != gui.ButtonLeft || !e.Point.In(ctx.Bounds) {
+			return false
+		}
+		h.down = -1
+		for i, r := range t.columns(ctx.Bounds) {
+			switch {
+			case abs(e.X-r.Max.X) <= tableResizeSlop:
+				h.drag = i
+				return false
+			case e.Point.In(r) && t.Columns[i].Sortable:
+				h.down = i
+			}
+		}
+	case gui.MoMove:
+		if h.drag >= 0 {
+			r := t.columns(ctx.Bounds)[h.drag]
+			t.resize(h.drag, max(e.X-r.Min.X, tableMinWidth))
+			return true
+		}
+	case gui.MoUp:
+		if e.Button != gui.ButtonLeft {
+			return false
+		}
+		if h.drag >= 0 {
+			h.drag = -1
+			return false
+		}
+		if h.down >= 0 && e.Point.In(t.columns(ctx.Bounds)[h.down]) {
+			t.sortBy(h.down)
+			h.down = -1
+			return true
+		}
+		h.down = -1
+	}
+	return false
+}
+
+func (h *tableHeader) Draw(ctx *Context, dst draw.Image) {
+	t := h.table
+	draw.Draw(dst, ctx.Bounds, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+	t.mu.Lock()
+	sortCol, descending := t.sortCol, t.descending
+	t.mu.Unlock()
+
+	pad := ctx.Theme.Padding
+	fg, border := ctx.Color(theme.Foreground), ctx.Color(theme.Border)
+	for i, r := range t.columns(ctx.Bounds) {
+		inner := image.Rect(r.Min.X+pad, r.Min.Y, r.Max.X-pad, r.Max.Y)
+		if i == sortCol {
+			// A triangle at the end, pointing up for ascending and down for descending order.
+			a := float64(pad) / 2
+			cx, cy := float64(inner.Max.X)-a, float64(r.Min.Y+r.Dy()/2)
+			dir := 1.0
+			if !descending {
+				dir = -1
+			}
+			tri := new(paint.Path)
+			tri.MoveTo(cx-a, cy-dir*a/2)
+			tri.LineTo(cx+a, cy-dir*a/2)
+			tri.LineTo(cx, cy+dir*a/2)
+			tri.Close()
+			paint.Fill(dst, tri, fg)
+			inner.Max.X -= 2 * pad
+		}
+		ctx.DrawText(clip(dst, inner), inner, t.Columns[i].Title, theme.Body, fg, text.AlignLeft)
+		draw.Draw(dst, image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y), image.NewUniform(border), image.Point{}, draw.Src)
+	}
+	b := ctx.Bounds
+	draw.Draw(dst, image.Rect(b.Min.X, b.Max.Y-1, b.Max.X, b.Max.Y), image.NewUniform(border), image.Point{}, draw.Src)
+}
+
+// tableRow is the Widget of a running row of a Table.
+type tableRow struct {
+	table *Table
+	i     int
+}
+
+func (r *tableRow) Event(ctx *Context, e gui.Event) bool {
+	_, ok := e.(tableChanged)
+	return ok
+}
+
+func (r *tableRow) Draw(ctx *Context, dst draw.Image) {
+	t := r.table
+	t.mu.Lock()
+	row := -1
+	if r.i < len(t.order) {
+		row = t.order[r.i]
+	}
+	t.mu.Unlock()
+	if row < 0 {
+		return
+	}
+
+	if r.i%2 == 1 {
+		draw.Draw(dst, ctx.Bounds, image.NewUniform(ctx.Color(theme.Surface)), image.Point{}, draw.Src)
+	}
+	pad := ctx.Theme.Padding
+	for i, cell := range t.columns(ctx.Bounds) {
+		col := t.Columns[i]
+		cellDst := clip(dst, cell)
+		if col.Render != nil {
+			cellCtx := *ctx
+			cellCtx.Bounds = cell
+			col.Render(&cellCtx, cellDst, row)
+			continue
+		}
+		inner := image.Rect(cell.Min.X+pad, cell.Min.Y, cell.Max.X-pad, cell.Max.Y)
+		ctx.DrawText(cellDst, inner, t.Model.Cell(row, i), theme.Body, ctx.Color(theme.Foreground), col.Align)
+	}
+}
+
+// clip returns the part of dst inside r, or dst if it can't be clipped.
+func clip(dst draw.Image, r image.Rectangle) draw.Image {
+	if s, ok := dst.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		if sub, ok := s.SubImage(r).(draw.Image); ok {
+			return sub
+		}
+	}
+	return dst
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,110 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+type tableData [][]string
+
+func (d tableData) Rows() int                { return len(d) }
+func (d tableData) Cell(row, col int) string { return d[row][col] }
+
+// numericData sorts its first column by value.
+type numericData struct{ tableData }
+
+func (d numericData) Less(col, a, b int) bool {
+	x, _ := strconv.Atoi(d.Cell(a, col))
+	y, _ := strconv.Atoi(d.Cell(b, col))
+	return x < y
+}
+
+func newTestTable(model TableModel) *Table {
+	t := &Table{
+		Model:    model,
+		Columns:  []TableColumn{{Title: "N", Width: 40, Sortable: true}, {Title: "Name", Sortable: true}},
+		Scroller: &gui.Scroller{ChildSize: image.Pt(0, 20)},
+		widths:   []int{40, 100},
+		sortCol:  -1,
+	}
+	t.order = t.sorted(model.Rows())
+	return t
+}
+
+func TestTableSort(t *testing.T) {
+	data := tableData{{"10", "banana"}, {"9", "Apple"}, {"100", "cherry"}}
+	for _, test := range []struct {
+		model TableModel
+		col   int
+		times int
+		want  []int
+	}{
+		{data, 1, 1, []int{1, 0, 2}},
+		{data, 1, 2, []int{2, 0, 1}},
+		{data, 0, 1, []int{0, 2, 1}}, // as text
+		{numericData{data}, 0, 1, []int{1, 0, 2}},
+		{numericData{data}, 0, 2, []int{2, 0, 1}},
+	} {
+		table := newTestTable(test.model)
+		for range test.times {
+			table.sortBy(test.col)
+		}
+		if !reflect.DeepEqual(table.order, test.want) {
+			t.Errorf("received %v; wanted %v", table.order, test.want)
+		}
+	}
+}
+
+func TestTableHeader(t *testing.T) {
+	table := newTestTable(tableData{{"2", "b"}, {"1", "a"}})
+	h := &tableHeader{table: table, drag: -1, down: -1}
+	ctx := &Context{Bounds: image.Rect(0, 0, 200, 20), Theme: theme.Light()}
+
+	// Dragging the edge of the first column resizes it.
+	h.Event(ctx, gui.MoDown{Point: image.Pt(41, 10), Button: gui.ButtonLeft})
+	h.Event(ctx, gui.MoMove{Point: image.Pt(60, 10)})
+	h.Event(ctx, gui.MoUp{Point: image.Pt(60, 10), Button: gui.ButtonLeft})
+	h.Event(ctx, gui.MoMove{Point: image.Pt(0, 10)})
+	if got, want := table.widths, []int{60, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+	if table.sortCol != -1 {
+		t.Errorf("resizing sorted by column %d", table.sortCol)
+	}
+
+	// Clicking a title sorts by it.
+	h.Event(ctx, gui.MoDown{Point: image.Pt(100, 10), Button: gui.ButtonLeft})
+	h.Event(ctx, gui.MoUp{Point: image.Pt(100, 10), Button: gui.ButtonLeft})
+	if got, want := table.order, []int{1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
+
+func TestTableRow(t *testing.T) {
+	table := newTestTable(tableData{{"1", "a"}, {"2", "b"}})
+	var rendered []int
+	table.Columns[0].Render = func(ctx *Context, dst draw.Image, row int) {
+		rendered = append(rendered, row)
+		if ctx.Bounds != dst.Bounds() {
+			t.Errorf("received %v; wanted %v", dst.Bounds(), ctx.Bounds)
+		}
+	}
+	table.sortBy(0)
+	table.sortBy(0) // descending
+
+	ctx := &Context{Bounds: image.Rect(0, 20, 200, 40), Theme: theme.Light()}
+	dst := image.NewRGBA(ctx.Bounds)
+	(&tableRow{table: table, i: 1}).Draw(ctx, dst)
+	if want := []int{0}; !reflect.DeepEqual(rendered, want) {
+		t.Errorf("received %v; wanted %v", rendered, want)
+	}
+	if got, want := dst.At(199, 39), ctx.Color(theme.Surface); !reflect.DeepEqual(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
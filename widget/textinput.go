@@ -0,0 +1,494 @@
+package widget
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/theme"
+)
+
+var (
+	_ Widget = &TextInput{}
+	_ Sizer  = &TextInput{}
+)
+
+// Clipboard holds text cut or copied from a TextInput. It is implemented by *gui.Win.
+type Clipboard interface {
+	Clipboard() (string, error)
+	SetClipboard(s string)
+}
+
+// localClipboard is the Clipboard of TextInputs without one. It is only shared within the process.
+var localClipboard = &memClipboard{}
+
+type memClipboard struct {
+	mu sync.Mutex
+	s  string
+}
+
+func (c *memClipboard) Clipboard() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.s, nil
+}
+
+func (c *memClipboard) SetClipboard(s string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.s = s
+}
+
+// TextInput is a Widget that edits a single line of text.
+//
+// While it has focus, typed text replaces the selection. The arrow keys, Home, and End move the
+// caret, and select with Shift. Ctrl moves by words. Ctrl+A selects everything, Ctrl+X, Ctrl+C, and
+// Ctrl+V cut, copy, and paste, Ctrl+Z undoes, and Ctrl+Y or Ctrl+Shift+Z redoes. Enter submits the
+// text. Dragging the mouse selects, and clicking the middle button pastes the PRIMARY selection.
+// Line breaks in pasted text become spaces.
+//
+// Text that doesn't fit scrolls horizontally to keep the caret in view.
+type TextInput struct {
+	// Text is the initial text.
+	Text string
+	// Placeholder is shown muted while the text is empty.
+	Placeholder string
+	// Clipboard defaults to one shared by the TextInputs of the process.
+	Clipboard Clipboard
+	// OnChange is called with the text after each change.
+	OnChange func(text string)
+	// OnSubmit is called with the text when Enter is pressed.
+	OnSubmit func(text string)
+
+	init          bool
+	text          []rune
+	caret, anchor int // the selection is between the anchor and the caret
+	preedit       string
+	scroll        int // pixels the text is scrolled to the left
+	dragging      bool
+	shift, ctrl   bool
+	undo          gui.CommandStack
+}
+
+// primaryPaste is injected with the PRIMARY selection to paste at the rune index at, after a
+// click of the middle button.
+type primaryPaste struct {
+	at int
+	s  string
+}
+
+func (p primaryPaste) String() string { return fmt.Sprintf("textinput/primarypaste/%d/%q", p.at, p.s) }
+
+// edit replaces the runes from at to at+len(removed) of a TextInput with inserted.
+type edit struct {
+	ti                *TextInput
+	at                int
+	removed, inserted []rune
+	typed             bool // whether the edit was typed, so that it coalesces with the next one
+}
+
+func (e *edit) Do() {
+	e.ti.replace(e.at, e.at+len(e.removed), e.inserted)
+}
+
+func (e *edit) Undo() {
+	e.ti.replace(e.at, e.at+len(e.inserted), e.removed)
+	e.ti.caret = e.at + len(e.removed)
+	e.ti.anchor = e.at
+}
+
+func (e *edit) Coalesce(next gui.Command) bool {
+	n, ok := next.(*edit)
+	if !ok || !e.typed || !n.typed || len(n.removed) > 0 || n.at != e.at+len(e.inserted) {
+		return false
+	}
+	e.inserted = append(e.inserted, n.inserted...)
+	return true
+}
+
+// replace replaces the runes from i to j with r, and puts the caret after them.
+func (ti *TextInput) replace(i, j int, r []rune) {
+	ti.text = append(ti.text[:i:i], append(append([]rune(nil), r...), ti.text[j:]...)...)
+	ti.caret = i + len(r)
+	ti.anchor = ti.caret
+}
+
+// selection returns the selected range of runes.
+func (ti *TextInput) selection() (i, j int) {
+	return min(ti.caret, ti.anchor), max(ti.caret, ti.anchor)
+}
+
+// insert replaces the selection with r as an undoable edit.
+func (ti *TextInput) insert(r []rune, typed bool) {
+	i, j := ti.selection()
+	if i == j && len(r) == 0 {
+		return
+	}
+	ti.undo.Do(&edit{ti, i, append([]rune(nil), ti.text[i:j]...), r, typed && i == j})
+	ti.changed()
+}
+
+// paste inserts s, with its line breaks replaced by spaces to keep the text on one line.
+func (ti *TextInput) paste(s string) {
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+	ti.insert([]rune(s), false)
+}
+
+func (ti *TextInput) changed() {
+	if ti.OnChange != nil {
+		ti.OnChange(string(ti.text))
+	}
+}
+
+func (ti *TextInput) clipboard() Clipboard {
+	if ti.Clipboard == nil {
+		return localClipboard
+	}
+	return ti.Clipboard
+}
+
+// move moves the caret to i, extending the selection if extend is set.
+func (ti *TextInput) move(i int, extend bool) {
+	ti.caret = max(0, min(i, len(ti.text)))
+	if !extend {
+		ti.anchor = ti.caret
+	}
+	ti.undo.Seal()
+}
+
+// word returns the index of the start of the word before i, if dir is negative, or of the end of
+// the word after i otherwise.
+func (ti *TextInput) word(i, dir int) int {
+	isWord := func(k int) bool { return !unicode.IsSpace(ti.text[k]) }
+	if dir < 0 {
+		for i > 0 && !isWord(i-1) {
+			i--
+		}
+		for i > 0 && isWord(i-1) {
+			i--
+		}
+		return i
+	}
+	for i < len(ti.text) && !isWord(i) {
+		i++
+	}
+	for i < len(ti.text) && isWord(i) {
+		i++
+	}
+	return i
+}
+
+func (ti *TextInput) Event(ctx *Context, e gui.Event) bool {
+	if !ti.init {
+		ti.init = true
+		ti.text = []rune(ti.Text)
+		ti.caret, ti.anchor = len(ti.text), len(ti.text)
+	}
+
+	switch e := e.(type) {
+	case gui.Resize, theme.ThemeChanged:
+		ti.scrollToCaret(ctx)
+	case gui.FocusGained, gui.FocusLost:
+		return true
+	case gui.MoDown:
+		if !e.Point.In(ctx.Bounds) {
+			return false
+		}
+		switch e.Button {
+		case gui.ButtonLeft:
+			ti.move(ti.hit(ctx, e.X), ti.shift)
+			ti.dragging = true
+		case gui.ButtonMiddle:
+			// Reading the PRIMARY selection runs a command, so it is pasted once it arrives.
+			at := ti.hit(ctx, e.X)
+			ctx.Go(func() gui.Event {
+				s, err := gui.PrimarySelection()
+				if err != nil {
+					return nil
+				}
+				return primaryPaste{at, s}
+			})
+			return false
+		default:
+			return false
+		}
+	case gui.MoMove:
+		if !ti.dragging {
+			return false
+		}
+		ti.move(ti.hit(ctx, e.X), true)
+	case gui.MoUp:
+		if e.Button != gui.ButtonLeft || !ti.dragging {
+			return false
+		}
+		ti.dragging = false
+		if i, j := ti.selection(); i != j {
+			s := string(ti.text[i:j])
+			ctx.Go(func() gui.Event {
+				gui.SetPrimarySelection(s)
+				return nil
+			})
+		}
+		return false
+	case gui.KbUp:
+		switch e.Key {
+		case gui.KeyShift:
+			ti.shift = false
+		case gui.KeyCtrl:
+			ti.ctrl = false
+		}
+		return false
+	case gui.KbDown:
+		if !ti.key(e.Key, ctx.Focused) {
+			return false
+		}
+	case gui.KbRepeat:
+		if !ti.key(e.Key, ctx.Focused) {
+			return false
+		}
+	case gui.KbType:
+		if !ctx.Focused || ti.ctrl {
+			return false
+		}
+		ti.insert([]rune{e.Rune}, true)
+	case gui.KbPreedit:
+		if !ctx.Focused {
+			return false
+		}
+		ti.preedit = e.Text
+	case primaryPaste:
+		ti.move(e.at, false)
+		ti.paste(e.s)
+	default:
+		return false
+	}
+	ti.scrollToCaret(ctx)
+	return true
+}
+
+// key handles a pressed key. Only the modifiers are tracked without focus. It returns false if
+// nothing changed.
+func (ti *TextInput) key(k gui.Key, focused bool) bool {
+	switch k {
+	case gui.KeyShift:
+		ti.shift = true
+		return false
+	case gui.KeyCtrl:
+		ti.ctrl = true
+		return false
+	}
+	if !focused {
+		return false
+	}
+
+	i, j := ti.selection()
+	switch k {
+	case gui.KeyLeft:
+		switch {
+		case ti.ctrl:
+			ti.move(ti.word(ti.caret, -1), ti.shift)
+		case i != j && !ti.shift:
+			ti.move(i, false)
+		default:
+			ti.move(ti.caret-1, ti.shift)
+		}
+	case gui.KeyRight:
+		switch {
+		case ti.ctrl:
+			ti.move(ti.word(ti.caret, 1), ti.shift)
+		case i != j && !ti.shift:
+			ti.move(j, false)
+		default:
+			ti.move(ti.caret+1, ti.shift)
+		}
+	case gui.KeyHome:
+		ti.move(0, ti.shift)
+	case gui.KeyEnd:
+		ti.move(len(ti.text), ti.shift)
+	case gui.KeyBackspace:
+		if i == j {
+			if ti.ctrl {
+				ti.anchor = ti.word(ti.caret, -1)
+			} else {
+				ti.anchor = max(ti.caret-1, 0)
+			}
+		}
+		ti.insert(nil, false)
+	case gui.KeyDelete:
+		if i == j {
+			if ti.ctrl {
+				ti.anchor = ti.word(ti.caret, 1)
+			} else {
+				ti.anchor = min(ti.caret+1, len(ti.text))
+			}
+		}
+		ti.insert(nil, false)
+	case gui.KeyEnter:
+		if ti.OnSubmit != nil {
+			ti.OnSubmit(string(ti.text))
+		}
+		return false
+	case gui.KeyA:
+		if !ti.ctrl {
+			return false
+		}
+		ti.anchor, ti.caret = 0, len(ti.text)
+	case gui.KeyC, gui.KeyX:
+		if !ti.ctrl || i == j {
+			return false
+		}
+		ti.clipboard().SetClipboard(string(ti.text[i:j]))
+		if k == gui.KeyX {
+			ti.insert(nil, false)
+		}
+	case gui.KeyV:
+		if !ti.ctrl {
+			return false
+		}
+		s, err := ti.clipboard().Clipboard()
+		if err != nil {
+			return false
+		}
+		ti.paste(s)
+	case gui.KeyZ:
+		if !ti.ctrl {
+			return false
+		}
+		if ti.shift {
+			return ti.redo()
+		}
+		if !ti.undo.Undo() {
+			return false
+		}
+		ti.changed()
+	case gui.KeyY:
+		if !ti.ctrl {
+			return false
+		}
+		return ti.redo()
+	default:
+		return false
+	}
+	return true
+}
+
+func (ti *TextInput) redo() bool {
+	if !ti.undo.Redo() {
+		return false
+	}
+	ti.changed()
+	return true
+}
+
+// inner returns the Rectangle of the text within bounds.
+func (ti *TextInput) inner(ctx *Context) image.Rectangle {
+	return ctx.Bounds.Inset(ctx.Theme.Padding + ctx.Theme.BorderWidth)
+}
+
+// x returns the distance of the boundary before rune i from the start of the text.
+func (ti *TextInput) x(face font.Face, i int) int {
+	return font.MeasureString(face, string(ti.text[:i])).Round()
+}
+
+// hit returns the index of the rune boundary closest to the horizontal position x.
+func (ti *TextInput) hit(ctx *Context, x int) int {
+	face := ctx.Face(theme.Body)
+	x -= ti.inner(ctx).Min.X - ti.scroll
+	var adv fixed.Int26_6
+	for i, r := range ti.text {
+		w, _ := face.GlyphAdvance(r)
+		if x < (adv + w/2).Round() {
+			return i
+		}
+		adv += w
+	}
+	return len(ti.text)
+}
+
+// scrollToCaret scrolls the text horizontally so that the caret is in view.
+func (ti *TextInput) scrollToCaret(ctx *Context) {
+	face := ctx.Face(theme.Body)
+	width := ti.inner(ctx).Dx()
+	caret := ti.x(face, ti.caret)
+	switch {
+	case caret-ti.scroll > width-1:
+		ti.scroll = caret - width + 1
+	case caret < ti.scroll:
+		ti.scroll = caret
+	}
+	ti.scroll = max(0, min(ti.scroll, ti.x(face, len(ti.text))-width+1))
+}
+
+func (ti *TextInput) Draw(ctx *Context, dst draw.Image) {
+	r := ctx.Bounds
+	border := ctx.Color(theme.Border)
+	if ctx.Focused {
+		border = ctx.Color(theme.Accent)
+	}
+	paint.Fill(dst, paint.RoundedRect(r, ctx.Theme.Radius), ctx.Color(theme.Surface))
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(dst, r, float64(w), ctx.Theme.Radius, border)
+	}
+
+	inner := ti.inner(ctx)
+	if inner.Empty() {
+		return
+	}
+	face := ctx.Face(theme.Body)
+	metrics := face.Metrics()
+	top := inner.Min.Y + (inner.Dy()-metrics.Height.Ceil())/2
+	lineHeight := metrics.Height.Ceil()
+	left := inner.Min.X - ti.scroll
+
+	// The text is drawn into an image of the inner Rectangle, so that it is clipped.
+	img := image.NewRGBA(inner)
+	if i, j := ti.selection(); i != j {
+		sel := image.Rect(left+ti.x(face, i), top, left+ti.x(face, j), top+lineHeight)
+		draw.Draw(img, sel, image.NewUniform(ctx.Color(theme.Selection)), image.Point{}, draw.Src)
+	}
+
+	fg := image.NewUniform(ctx.Color(theme.Foreground))
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(ctx.Color(theme.Muted)),
+		Face: face,
+		Dot:  fixed.P(left, top+metrics.Ascent.Ceil()),
+	}
+	if len(ti.text) == 0 && ti.preedit == "" {
+		d.DrawString(ti.Placeholder)
+		d.Dot.X = fixed.I(left)
+	}
+	d.Src = fg
+	d.DrawString(string(ti.text[:ti.caret]))
+	if ti.preedit != "" {
+		start := d.Dot.X.Round()
+		d.DrawString(ti.preedit)
+		underline := image.Rect(start, top+lineHeight-1, d.Dot.X.Round(), top+lineHeight)
+		draw.Draw(img, underline, d.Src, image.Point{}, draw.Over)
+	}
+	caret := d.Dot.X.Round()
+	d.DrawString(string(ti.text[ti.caret:]))
+	if ctx.Focused {
+		draw.Draw(img, image.Rect(caret, top, caret+1, top+lineHeight), d.Src, image.Point{}, draw.Over)
+	}
+
+	draw.Draw(dst, inner, img, inner.Min, draw.Over)
+}
+
+func (ti *TextInput) SizeHint(ctx *Context) gui.SizeHint {
+	pad := ctx.Theme.Padding + ctx.Theme.BorderWidth
+	height := ctx.Face(theme.Body).Metrics().Height.Ceil() + 2*pad
+	em := ctx.MeasureText("M", theme.Body).X
+	return gui.SizeHint{
+		Min:       image.Pt(4*em+2*pad, height),
+		Preferred: image.Pt(20*em+2*pad, height),
+	}
+}
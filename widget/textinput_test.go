@@ -0,0 +1,117 @@
+package widget
+
+import (
+	"image"
+	"testing"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/theme"
+)
+
+func TestTextInput(t *testing.T) {
+	var changed, submitted string
+	ti := &TextInput{
+		Text:      "hello",
+		Clipboard: &memClipboard{},
+		OnChange:  func(s string) { changed = s },
+		OnSubmit:  func(s string) { submitted = s },
+	}
+	ctx := &Context{Bounds: image.Rect(0, 0, 60, 24), Theme: theme.Light(), Focused: true}
+
+	typeText := func(s string) {
+		for _, r := range s {
+			ti.Event(ctx, gui.KbType{Rune: r})
+		}
+	}
+	keys := func(keys ...gui.Key) {
+		for _, k := range keys {
+			ti.Event(ctx, gui.KbDown{Key: k})
+		}
+	}
+	release := func(k gui.Key) { ti.Event(ctx, gui.KbUp{Key: k}) }
+
+	typeText(" world")
+	if changed != "hello world" {
+		t.Fatalf("received %q; wanted %q", changed, "hello world")
+	}
+
+	// Typing coalesces, so one undo removes the whole word.
+	keys(gui.KeyCtrl, gui.KeyZ)
+	release(gui.KeyCtrl)
+	if changed != "hello" {
+		t.Errorf("received %q after undo; wanted %q", changed, "hello")
+	}
+
+	// Select "ell" and cut it.
+	keys(gui.KeyHome, gui.KeyRight, gui.KeyShift, gui.KeyRight, gui.KeyRight, gui.KeyRight)
+	release(gui.KeyShift)
+	keys(gui.KeyCtrl, gui.KeyX, gui.KeyEnd, gui.KeyV)
+	release(gui.KeyCtrl)
+	if changed != "hoell" {
+		t.Errorf("received %q after cut and paste; wanted %q", changed, "hoell")
+	}
+
+	keys(gui.KeyCtrl, gui.KeyBackspace)
+	release(gui.KeyCtrl)
+	keys(gui.KeyEnter)
+	if submitted != "" {
+		t.Errorf("received %q on submit; wanted %q", submitted, "")
+	}
+
+	// Long text scrolls to keep the caret in view.
+	typeText("a long line that does not fit")
+	if ti.scroll <= 0 {
+		t.Errorf("text did not scroll")
+	}
+	if i := ti.hit(ctx, ti.inner(ctx).Max.X); i != len(ti.text) {
+		t.Errorf("hit at the right edge = %d; wanted %d", i, len(ti.text))
+	}
+
+	ctx.Focused = false
+	typeText("x")
+	if changed != "a long line that does not fit" {
+		t.Errorf("unfocused TextInput changed to %q", changed)
+	}
+	ti.Draw(ctx, image.NewRGBA(ctx.Bounds))
+}
+
+// Line breaks in pasted text become spaces.
+func TestTextInputPaste(t *testing.T) {
+	ti := &TextInput{Clipboard: &memClipboard{}}
+	ti.Clipboard.SetClipboard("one\ntwo\r\nthree")
+	ctx := &Context{Bounds: image.Rect(0, 0, 60, 24), Theme: theme.Light(), Focused: true}
+
+	ti.Event(ctx, gui.KbDown{Key: gui.KeyCtrl})
+	ti.Event(ctx, gui.KbDown{Key: gui.KeyV})
+	if got, want := string(ti.text), "one two three"; got != want {
+		t.Errorf("received %q; wanted %q", got, want)
+	}
+}
+
+// The PRIMARY selection is pasted where the middle button was clicked once it arrives.
+func TestTextInputPrimaryPaste(t *testing.T) {
+	ti := &TextInput{Text: "ab", Clipboard: &memClipboard{}}
+	ctx := &Context{Bounds: image.Rect(0, 0, 60, 24), Theme: theme.Light()}
+
+	if !ti.Event(ctx, primaryPaste{1, "x\ny"}) {
+		t.Errorf("not redrawn after the paste")
+	}
+	if got, want := string(ti.text), "ax yb"; got != want {
+		t.Errorf("received %q; wanted %q", got, want)
+	}
+}
+
+// The shared clipboard may be used by TextInputs in different goroutines.
+func TestLocalClipboard(t *testing.T) {
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		for range 100 {
+			localClipboard.SetClipboard("a")
+		}
+	}()
+	for range 100 {
+		localClipboard.Clipboard()
+	}
+	<-done
+}
@@ -0,0 +1,154 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"time"
+
+	"git.samanthony.xyz/share"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/paint"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+var _ gui.Intercepter = Tooltip{}
+
+// Tooltip is an Intercepter that shows Text in a small popup below the mouse once it has rested
+// over the Rectangle of the last Resize for Delay. The popup is hidden when the mouse moves, leaves,
+// or presses a button, when a key is pressed, and when the Tooltip dies.
+//
+// The popup is drawn onto Overlay, which should be a layer of a Compositor above the element, like
+// the Overlay of a Dropdown. It may be shared by many Tooltips, because only one is shown at a time,
+// but its Events must be drained by something else then. It's drawn with the Theme of the last
+// ThemeChanged, or theme.Light.
+//
+// All Events are passed along, including the HoverEnter and HoverLeave Events of the HoverTracker
+// the Tooltip is built on.
+type Tooltip struct {
+	Text    string
+	Overlay gui.Env
+	// Delay defaults to half a second.
+	Delay time.Duration
+}
+
+// tooltipOffset is how far below the mouse a Tooltip is shown, to clear the cursor.
+const tooltipOffset = 20
+
+func (t Tooltip) Intercept(parent gui.Env) gui.Env {
+	delay := t.Delay
+	if delay <= 0 {
+		delay = 500 * time.Millisecond
+	}
+	base := gui.HoverTracker{}.Intercept(parent)
+	out := share.NewQueue[gui.Event]()
+	var overlay chan<- func(draw.Image) image.Rectangle
+	if t.Overlay != nil {
+		overlay = t.Overlay.Draw()
+	}
+	go t.run(delay, base.Events(), out.Enqueue, overlay)
+	return tooltipEnv{base, out.Dequeue}
+}
+
+// run passes events along to out, and shows and hides the popup on overlay, if it isn't nil. Once
+// events is closed, i.e. the Tooltip died, it hides the popup and closes out.
+func (t Tooltip) run(delay time.Duration, events <-chan gui.Event, out chan<- gui.Event, overlay chan<- func(draw.Image) image.Rectangle) {
+	defer close(out)
+	ctx := &Context{Theme: theme.Light()}
+	var (
+		mouse image.Point
+		over  bool
+		shown image.Rectangle // on the Overlay
+		timer = time.NewTimer(0)
+		wait  <-chan time.Time
+	)
+	<-timer.C
+	defer timer.Stop()
+
+	hide := func() {
+		if !shown.Empty() {
+			r := shown
+			overlay <- func(drw draw.Image) image.Rectangle {
+				draw.Draw(drw, r, image.Transparent, image.Point{}, draw.Src)
+				return r
+			}
+		}
+		shown = image.Rectangle{}
+	}
+	defer hide() // don't leave the popup behind when killed
+	cancel := func() {
+		hide()
+		if wait != nil && !timer.Stop() {
+			<-timer.C
+		}
+		wait = nil
+	}
+	rest := func() {
+		cancel()
+		timer.Reset(delay)
+		wait = timer.C
+	}
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			out <- e
+			switch e := e.(type) {
+			case theme.ThemeChanged:
+				ctx.Theme = e.Theme
+			case gui.HoverEnter:
+				mouse, over = e.Point, true
+				rest()
+			case gui.HoverLeave:
+				over = false
+				cancel()
+			case gui.MoMove:
+				mouse = e.Point
+				if over {
+					rest()
+				}
+			case gui.Resize, gui.MoDown, gui.MoScroll, gui.KbDown:
+				cancel()
+			}
+		case <-wait:
+			wait = nil
+			if over && t.Text != "" && overlay != nil {
+				shown = t.show(ctx, mouse, overlay)
+			}
+		}
+	}
+}
+
+// show draws the popup for the mouse at pt onto overlay, and returns where it is.
+func (t Tooltip) show(ctx *Context, pt image.Point, overlay chan<- func(draw.Image) image.Rectangle) image.Rectangle {
+	pad := ctx.Theme.Padding
+	size := ctx.MeasureText(t.Text, theme.Body).Add(image.Pt(2*pad, pad))
+	r := image.Rectangle{pt, pt.Add(size)}.Add(image.Pt(0, tooltipOffset))
+
+	img := image.NewRGBA(r)
+	paint.Fill(img, paint.RoundedRect(r, ctx.Theme.Radius), ctx.Color(theme.Surface))
+	if w := ctx.Theme.BorderWidth; w > 0 {
+		paint.Border(img, r, float64(w), ctx.Theme.Radius, ctx.Color(theme.Border))
+	}
+	ctx.DrawText(img, image.Rect(r.Min.X+pad, r.Min.Y, r.Max.X-pad, r.Max.Y), t.Text, theme.Body, ctx.Color(theme.Foreground), text.AlignLeft)
+
+	overlay <- func(drw draw.Image) image.Rectangle {
+		draw.Draw(drw, r, img, r.Min, draw.Src)
+		return r
+	}
+	return r
+}
+
+// tooltipEnv is an Env with the Events passed along by a Tooltip.
+type tooltipEnv struct {
+	gui.Env
+	events <-chan gui.Event
+}
+
+func (te tooltipEnv) Events() <-chan gui.Event {
+	return te.events
+}
@@ -0,0 +1,70 @@
+package widget
+
+import (
+	"image"
+	"image/draw"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/faiface/gui"
+)
+
+func TestTooltip(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	events := make(chan gui.Event)
+	out := make(chan gui.Event, 16)
+	overlay := make(chan func(draw.Image) image.Rectangle)
+	go Tooltip{Text: "tip"}.run(delay, events, out, overlay)
+
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	recvDraw := func(what string) image.Rectangle {
+		t.Helper()
+		select {
+		case d := <-overlay:
+			return d(img)
+		case <-time.After(time.Second):
+			t.Fatalf("popup not %s after %v", what, time.Second)
+			return image.Rectangle{}
+		}
+	}
+	visible := func(r image.Rectangle) bool {
+		_, _, _, a := img.At(r.Min.X+r.Dx()/2, r.Min.Y+r.Dy()/2).RGBA()
+		return a != 0
+	}
+
+	// The popup is shown below the mouse once it has rested for the delay.
+	start := time.Now()
+	events <- gui.HoverEnter{Point: image.Pt(10, 10)}
+	shown := recvDraw("shown")
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("popup shown after %v; wanted at least %v", elapsed, delay)
+	}
+	if want := image.Pt(10, 10+tooltipOffset); shown.Min != want || !visible(shown) {
+		t.Errorf("received popup at %v; wanted it at %v", shown, want)
+	}
+
+	// Moving hides it, and it's shown again where the mouse rests next.
+	events <- gui.MoMove{Point: image.Pt(30, 10)}
+	if r := recvDraw("hidden"); r != shown || visible(r) {
+		t.Errorf("received %v after moving; wanted %v cleared", r, shown)
+	}
+	shown = recvDraw("shown again")
+	if want := image.Pt(30, 10+tooltipOffset); shown.Min != want {
+		t.Errorf("received popup at %v; wanted it at %v", shown.Min, want)
+	}
+
+	// Dying hides it too, after passing all Events along.
+	close(events)
+	if r := recvDraw("hidden on kill"); r != shown || visible(r) {
+		t.Errorf("received %v after dying; wanted %v cleared", r, shown)
+	}
+	var got []gui.Event
+	for e := range out {
+		got = append(got, e)
+	}
+	want := []gui.Event{gui.HoverEnter{Point: image.Pt(10, 10)}, gui.MoMove{Point: image.Pt(30, 10)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("received %v; wanted %v", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+// Package widget is the base of the controls in this package: buttons, text inputs, lists, and
+// the like. A Widget holds the state of a control, updates it on Events, and draws it. Run keeps
+// a Widget in sync with an Env, so each control only has to implement what makes it different:
+//
+//	env, _ = theme.NewEnv(win, theme.Light())
+//	...
+//	go widget.Run(env, &MyControl{})
+//
+// Widgets draw with the Theme delivered by theme.NewEnv, or theme.Light if there is none, and
+// draw their text with the text package.
+package widget
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"golang.org/x/image/font"
+
+	"github.com/faiface/gui"
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+// Widget is a control run by Run.
+type Widget interface {
+	// Event updates the Widget after an Event. It returns true if the Widget needs to be redrawn.
+	// The Context is already updated by the Event.
+	Event(ctx *Context, e gui.Event) (redraw bool)
+
+	// Draw draws the Widget inside ctx.Bounds. dst is already filled with the Background color
+	// of the Theme.
+	Draw(ctx *Context, dst draw.Image)
+}
+
+// Sizer is a Widget that knows what size it needs, e.g. to fit its text. Run tells the layout
+// the Widget runs in with gui.Prefer whenever the size changes.
+type Sizer interface {
+	SizeHint(ctx *Context) gui.SizeHint
+}
+
+// Damager is a Widget that knows which part of it changed, so that Run only sends that part to the
+// Env, e.g. for a progress bar updated many times a second.
+type Damager interface {
+	// Damage returns the part of ctx.Bounds that changed since the last Draw. It is called just
+	// before Draw, unless all of the Widget is redrawn anyway.
+	Damage(ctx *Context) image.Rectangle
+}
+
+// Context is what a Widget knows about its surroundings. It is kept up to date by Run.
+type Context struct {
+	// Bounds is the Rectangle of the last Resize.
+	Bounds image.Rectangle
+	// Theme is the Theme of the last ThemeChanged.
+	Theme *theme.Theme
+	// Hovered is whether the mouse is over Bounds.
+	Hovered bool
+	// Focused is whether the Widget has keyboard focus from a gui.FocusManager.
+	Focused bool
+
+	inject  chan<- gui.Event // nil outside of Run
+	pending *sync.WaitGroup  // the goroutines started by Go
+}
+
+// Go runs f in a new goroutine, e.g. to run a command without holding up the Events, and passes the
+// Event f returns to the Widget like any other, unless it is nil. Outside of Run, the Event is
+// dropped.
+func (ctx *Context) Go(f func() gui.Event) {
+	if ctx.inject == nil {
+		go f()
+		return
+	}
+	ctx.pending.Add(1)
+	go func(inject chan<- gui.Event) {
+		defer ctx.pending.Done()
+		if e := f(); e != nil {
+			inject <- e
+		}
+	}(ctx.inject)
+}
+
+// Color returns the named color of the Theme.
+func (ctx *Context) Color(name theme.ColorName) color.Color {
+	return ctx.Theme.Color(name)
+}
+
+// Face returns the named font face of the Theme.
+func (ctx *Context) Face(name theme.FaceName) font.Face {
+	return ctx.Theme.Face(name)
+}
+
+// MeasureText returns the size of s on a single line in the named face.
+func (ctx *Context) MeasureText(s string, face theme.FaceName) image.Point {
+	f := ctx.Face(face)
+	return image.Pt(font.MeasureString(f, s).Ceil(), f.Metrics().Height.Ceil())
+}
+
+// DrawText draws s in the named face and color inside r, wrapped at spaces, aligned horizontally
+// by align and centered vertically, or at the top if it doesn't fit. It returns the Layout of the
+// text.
+func (ctx *Context) DrawText(dst draw.Image, r image.Rectangle, s string, face theme.FaceName, col color.Color, align text.Align) *text.Layout {
+	p := text.Paragraph{
+		Spans: []text.Span{{Text: s}},
+		Faces: text.Faces{Regular: ctx.Face(face)},
+		Align: align,
+		Color: col,
+	}
+	l := p.Render(r)
+	off := image.Pt(0, max((r.Dy()-l.Height)/2, 0))
+	draw.Draw(dst, r.Add(off).Intersect(r), l.Image, r.Min, draw.Over)
+	for i := range l.Boxes {
+		l.Boxes[i].Rectangle = l.Boxes[i].Add(off)
+	}
+	return l
+}
+
+// Run runs w in env until env dies. It keeps the Context up to date, passes every Event to the
+// Widget, and draws the Widget when it asks to be redrawn, as well as after each Resize and each
+// ThemeChanged. When a Damager asks to be redrawn, only its Damage is sent to env.
+//
+// The Widget is drawn into an image of its own before the image is sent to env, so its state is
+// never accessed outside of the goroutine running Run.
+func Run(env gui.Env, w Widget) {
+	// The Events of Context.Go are injected. Prefer and the draw functions still go to env.
+	injector, inject := gui.NewInjector(env)
+	ctx := &Context{Theme: theme.Light(), inject: inject, pending: new(sync.WaitGroup)}
+	defer func() {
+		go func() {
+			ctx.pending.Wait()
+			close(inject)
+		}()
+	}()
+	var (
+		hint  gui.SizeHint
+		sized bool // whether the Widget has received a Resize
+	)
+	for e := range injector.Events() {
+		redraw, full := false, false
+		switch e := e.(type) {
+		case gui.Resize:
+			ctx.Bounds = e.Rectangle
+			sized, redraw, full = true, true, true
+		case theme.ThemeChanged:
+			ctx.Theme = e.Theme
+			redraw, full = true, true
+		case gui.MoMove:
+			ctx.Hovered = e.Point.In(ctx.Bounds)
+		case gui.FocusGained:
+			ctx.Focused = true
+		case gui.FocusLost:
+			ctx.Focused = false
+		}
+		if w.Event(ctx, e) {
+			redraw = true
+		}
+
+		if s, ok := w.(Sizer); ok && sized {
+			if h := s.SizeHint(ctx); h != hint {
+				hint = h
+				gui.Prefer(env, hint)
+			}
+		}
+		if redraw && sized {
+			r := ctx.Bounds
+			if d, ok := w.(Damager); ok && !full {
+				r = d.Damage(ctx).Intersect(r)
+			}
+			if !r.Empty() {
+				env.Draw() <- render(ctx, w, r)
+			}
+		}
+	}
+}
+
+// Mount runs w in a new goroutine in the Env a layout made for one of its children. Given that
+// Env, rather than one derived from it, a Sizer can tell the layout its size hints.
+func Mount(child gui.Env, w Widget) {
+	go Run(child, w)
+}
+
+// Value is an Event carrying a value received by Feed.
+type Value[T any] struct {
+	Value T
+}
+
+func (v Value[T]) String() string { return fmt.Sprintf("widget/value/%v", v.Value) }
+
+// Feed makes an Env that passes along the Events of parent, along with a Value for each value
+// received from values, e.g. to update a Progress as a download goes on. values should be closed
+// once there are no more.
+func Feed[T any](parent gui.Env, values <-chan T) gui.Env {
+	env, inject := gui.NewInjector(parent)
+	go func() {
+		defer close(inject)
+		for v := range values {
+			inject <- Value[T]{v}
+		}
+	}()
+	return env
+}
+
+// render draws w into a new image and returns a draw function that copies the part r of the image.
+func render(ctx *Context, w Widget, r image.Rectangle) func(draw.Image) image.Rectangle {
+	img := image.NewRGBA(ctx.Bounds)
+	draw.Draw(img, img.Bounds(), image.NewUniform(ctx.Color(theme.Background)), image.Point{}, draw.Src)
+	w.Draw(ctx, img)
+	return func(drw draw.Image) image.Rectangle {
+		draw.Draw(drw, r, img, r.Min, draw.Src)
+		return r
+	}
+}
@@ -0,0 +1,47 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/faiface/gui/text"
+	"github.com/faiface/gui/theme"
+)
+
+func TestDrawText(t *testing.T) {
+	ctx := &Context{Bounds: image.Rect(0, 0, 200, 100), Theme: theme.Light()}
+	size := ctx.MeasureText("hello", theme.Body)
+	if size.X <= 0 || size.Y <= 0 {
+		t.Fatalf("MeasureText = %v; wanted a positive size", size)
+	}
+
+	dst := image.NewRGBA(ctx.Bounds)
+	l := ctx.DrawText(dst, ctx.Bounds, "hello", theme.Body, color.Black, text.AlignCenter)
+	if len(l.Boxes) != 1 {
+		t.Fatalf("received %d boxes; wanted 1", len(l.Boxes))
+	}
+	box := l.Boxes[0].Rectangle
+	if top, bottom := box.Min.Y, ctx.Bounds.Max.Y-box.Max.Y; absDiff(top, bottom) > 1 {
+		t.Errorf("text at %v is not centered vertically", box)
+	}
+	if left, right := box.Min.X, ctx.Bounds.Max.X-box.Max.X; absDiff(left, right) > 1 {
+		t.Errorf("text at %v is not centered horizontally", box)
+	}
+	inked := false
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			inked = inked || dst.RGBAAt(x, y).A != 0
+		}
+	}
+	if !inked {
+		t.Errorf("no text drawn in %v", box)
+	}
+}
+
+func absDiff(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -1,8 +1,12 @@
 package gui
 
 import (
+	"encoding/binary"
+	"errors"
 	"image"
 	"image/draw"
+	"log"
+	"math"
 	"runtime"
 	"sync"
 	"time"
@@ -14,6 +18,9 @@ import (
 	"github.com/go-gl/glfw/v3.2/glfw"
 )
 
+// ErrWindowDead is returned by Win.Capture and NewTexture once the window is dead.
+var ErrWindowDead = errors.New("window is dead")
+
 // WinOption is a functional option to the window constructor.
 type WinOption func(*winOptions)
 
@@ -23,6 +30,22 @@ type winOptions struct {
 	resizable     bool
 	borderless    bool
 	maximized     bool
+	profile       *ColorProfile
+	deepColor     bool
+	watchdog      time.Duration
+	icons         []image.Image
+	position      *image.Point
+	minSize       image.Point
+	maxSize       image.Point
+	aspectRatio   image.Point
+	floating      bool
+	vsync         bool
+	gamepads      bool
+	coalesce      bool
+	critical      bool
+	hints         map[glfw.Hint]int
+	closeMode     CloseMode
+	flushInterval time.Duration
 }
 
 // Title option sets the title (caption) of the window.
@@ -61,6 +84,172 @@ func Maximized() WinOption {
 	}
 }
 
+// DisplayProfile option sets the color profile of the display the window is shown on.
+//
+// Everything drawn to the window is assumed to be in sRGB and gets converted to the
+// display profile when it is flushed to the screen. Use ParseICC to load the profile
+// of the display.
+func DisplayProfile(p *ColorProfile) WinOption {
+	return func(o *winOptions) {
+		o.profile = p
+	}
+}
+
+// DeepColor option requests a framebuffer with at least 10 bits per color channel.
+//
+// The drawing area of the window becomes an *image.RGBA64, so 16-bit images can be drawn
+// without losing precision. The precision actually shown depends on the display.
+func DeepColor() WinOption {
+	return func(o *winOptions) {
+		o.deepColor = true
+	}
+}
+
+// Watchdog option makes the window report draw functions that block the rendering of the window
+// for longer than threshold.
+//
+// When a draw function exceeds the threshold, a stack dump of all goroutines is logged and
+// a WiStall event is emitted, so the offending element can be found.
+func Watchdog(threshold time.Duration) WinOption {
+	return func(o *winOptions) {
+		o.watchdog = threshold
+	}
+}
+
+// Icon option sets the icon of the window. Several sizes of the icon may be supplied and the
+// closest one to the size the system wants is chosen. Good sizes include 16x16, 32x32, and 48x48.
+func Icon(images ...image.Image) WinOption {
+	return func(o *winOptions) {
+		o.icons = toNRGBAs(images)
+	}
+}
+
+// Position option sets the position of the upper-left corner of the window's drawing area
+// in screen coordinates.
+func Position(x, y int) WinOption {
+	return func(o *winOptions) {
+		o.position = &image.Point{x, y}
+	}
+}
+
+// MinSize option sets the minimum width and height the user can resize the window to.
+func MinSize(width, height int) WinOption {
+	return func(o *winOptions) {
+		o.minSize = image.Pt(width, height)
+	}
+}
+
+// MaxSize option sets the maximum width and height the user can resize the window to.
+func MaxSize(width, height int) WinOption {
+	return func(o *winOptions) {
+		o.maxSize = image.Pt(width, height)
+	}
+}
+
+// AspectRatio option forces the ratio of the width and height of the window to
+// numer:denom when the user resizes it.
+func AspectRatio(numer, denom int) WinOption {
+	return func(o *winOptions) {
+		o.aspectRatio = image.Pt(numer, denom)
+	}
+}
+
+// Floating option makes the window stay on top of other windows, e.g. for tool palettes.
+func Floating() WinOption {
+	return func(o *winOptions) {
+		o.floating = true
+	}
+}
+
+// VSync option makes the window double-buffered and synchronizes its updates with the vertical
+// refresh of the display. This avoids tearing and flickering on some drivers, at the cost of
+// re-uploading the whole drawing area on every update and waiting for the display.
+//
+// The semantics of the Draw() channel stay the same.
+func VSync() WinOption {
+	return func(o *winOptions) {
+		o.vsync = true
+	}
+}
+
+// Gamepads option makes the window emit GamepadButton and GamepadAxis events for all connected
+// gamepads and joysticks. They are polled 30 times per second.
+func Gamepads() WinOption {
+	return func(o *winOptions) {
+		o.gamepads = true
+	}
+}
+
+// CoalesceMotion option makes the window merge consecutive MoMove, MoRelMove, and MoScroll events
+// while they wait in its queue. If the events are not received fast enough, e.g. because every
+// one of them causes a slow redraw, the queue then stays short and only the latest mouse position
+// is delivered.
+func CoalesceMotion() WinOption {
+	return func(o *winOptions) {
+		o.coalesce = true
+	}
+}
+
+// PrioritizeCritical option makes Resize and WiClose events overtake all other events waiting
+// in the queue of the window, so that it resizes and closes promptly even when it's flooded with
+// input. The order of the other events is kept. See CriticalFirst.
+func PrioritizeCritical() WinOption {
+	return func(o *winOptions) {
+		o.critical = true
+	}
+}
+
+// Hint option sets a GLFW window hint the package doesn't provide an option for, such as
+// glfw.Samples or glfw.SRGBCapable. See the GLFW documentation for the possible hints and values.
+//
+// Hints are applied after all other options, so they take precedence.
+func Hint(hint glfw.Hint, value int) WinOption {
+	return func(o *winOptions) {
+		if o.hints == nil {
+			o.hints = make(map[glfw.Hint]int)
+		}
+		o.hints[hint] = value
+	}
+}
+
+// CloseMode determines what happens when the user presses the close button on the window.
+type CloseMode int
+
+const (
+	// CloseEmit only emits a WiClose event. The application decides whether to close the window,
+	// e.g. after asking to save changes, and finalizes it with Win.SetShouldClose(true).
+	CloseEmit CloseMode = iota
+
+	// CloseKill emits a WiClose event and kills the window.
+	CloseKill
+)
+
+// OnClose option sets what happens when the user presses the close button on the window.
+// The default is CloseEmit.
+func OnClose(mode CloseMode) WinOption {
+	return func(o *winOptions) {
+		o.closeMode = mode
+	}
+}
+
+// FlushInterval option sets how long the window waits for more draw functions after one has been
+// drawn, before it flushes the changes to the screen. Longer intervals save CPU by batching more
+// changes into one flush, at the cost of latency. The default is 1/960 of a second.
+func FlushInterval(d time.Duration) WinOption {
+	return func(o *winOptions) {
+		o.flushInterval = d
+	}
+}
+
+// MaxFPS option limits the number of flushes per second. It is the same as FlushInterval(time.Second / fps).
+func MaxFPS(fps int) WinOption {
+	return func(o *winOptions) {
+		if fps > 0 {
+			o.flushInterval = time.Second / time.Duration(fps)
+		}
+	}
+}
+
 // Win is an Env that handles an actual graphical window.
 //
 // It receives its events from the OS and it draws to the surface of the window.
@@ -72,19 +261,69 @@ type Win struct {
 
 	w       *glfw.Window
 	newSize chan image.Rectangle
-	img     share.Val[*image.RGBA]
-	ratio   int
+	img     share.Val[draw.Image]
+	fb      framebuffer // memory of img; only accessed on the OpenGL thread after NewWin
+	scale   float64     // only accessed on the main thread
+	deep    bool
+	vsync   bool
+
+	flushInterval time.Duration
+
+	watchdog time.Duration
+	stalls   chan WiStall
+
+	calls  chan func()
+	closed chan struct{} // closed when the window is being killed
+	// sending is read-locked by sendDraw while it may send to draw, so that draw is only closed
+	// once nothing can send to it anymore.
+	sending sync.RWMutex
+
+	cursors map[CursorShape]*glfw.Cursor // only accessed on the main thread
+
+	gamepads map[int]gamepadState // nil if not enabled; only accessed on the main thread
+
+	closeMode CloseMode
+
+	cursorMode       CursorMode // only accessed on the main thread
+	cursorX, cursorY float64    // last cursor position in screen coordinates; only accessed on the main thread
+
+	sizeLimits [4]int // min and max size set by the options in screen coordinates
+
+	profile *ColorProfile
+	xform   *colorTransform // nil if the display is sRGB
+
+	textures []*Texture // only accessed on the OpenGL thread
 
 	child killer
 
-	kill chan bool
+	kill chan bool // never closed, see SetShouldClose
 	dead chan bool
 
 	threads *sync.WaitGroup
 }
 
+// Run runs run as the main function of the program. It must be called from main and
+// returns when run returns.
+//
+// Windows must be handled by the main thread of the program, due to limitations of operating
+// systems. Run locks the main thread for that purpose and runs run in another goroutine:
+//
+//	func main() {
+//		gui.Run(run)
+//	}
+//
+//	func run() {
+//		w, err := gui.NewWin()
+//		...
+//	}
+func Run(run func()) {
+	mainthread.Run(run)
+}
+
 // NewWin creates a new window with all the supplied options.
 //
+// NewWin must be called within the function passed to Run, otherwise it deadlocks.
+//
 // The default title is empty and the default size is 640x480.
 func NewWin(opts ...WinOption) (*Win, error) {
 	o := winOptions{
@@ -94,25 +333,71 @@ func NewWin(opts ...WinOption) (*Win, error) {
 		resizable:  false,
 		borderless: false,
 		maximized:  false,
+		profile:    SRGB,
+
+		flushInterval: time.Second / 960,
 	}
 	for _, opt := range opts {
 		opt(&o)
 	}
+	if o.profile == nil {
+		o.profile = SRGB
+	}
 
-	events := share.NewQueue[Event]()
+	var events share.Queue[Event]
+	if o.coalesce || o.critical {
+		urgent := func(Event) bool { return false }
+		if o.critical {
+			urgent = CriticalFirst
+		}
+		var merge func(prev, next Event) (Event, bool)
+		if o.coalesce {
+			merge = coalesce
+		}
+		enqueue, dequeue := newLaneQueue(urgent, merge)
+		events = share.Queue[Event]{Enqueue: enqueue, Dequeue: dequeue}
+	} else {
+		events = share.NewQueue[Event]()
+	}
 
 	w := &Win{
 		events:  events,
 		draw:    make(chan func(draw.Image) image.Rectangle),
 		newSize: make(chan image.Rectangle),
-		img:     share.NewVal[*image.RGBA](),
+		img:     share.NewVal[draw.Image](),
 		child:   newKiller(),
 		kill:    make(chan bool),
 		dead:    make(chan bool),
 		threads: new(sync.WaitGroup),
+		profile: o.profile,
+		deep:    o.deepColor,
+		fb:      framebuffer{deep: o.deepColor},
+		vsync:   o.vsync,
+
+		flushInterval: o.flushInterval,
+
+		watchdog: o.watchdog,
+		stalls:   make(chan WiStall, 1),
+
+		calls:  make(chan func()),
+		closed: make(chan struct{}),
+
+		cursors: make(map[CursorShape]*glfw.Cursor),
+
+		closeMode: o.closeMode,
+	}
+
+	if o.gamepads {
+		w.gamepads = make(map[int]gamepadState)
 	}
 
 	var err error
+	if o.profile != SRGB {
+		if w.xform, err = newColorTransform(o.profile); err != nil {
+			return nil, err
+		}
+	}
+
 	mainthread.Call(func() {
 		w.w, err = makeGLFWWin(&o)
 	})
@@ -121,25 +406,30 @@ func NewWin(opts ...WinOption) (*Win, error) {
 	}
 
 	mainthread.Call(func() {
-		// hiDPI hack
-		width, _ := w.w.GetFramebufferSize()
-		w.ratio = width / o.width
-		if w.ratio < 1 {
-			w.ratio = 1
+		// The size options are in pixels, but GLFW sizes windows in screen coordinates,
+		// which differ on hiDPI displays.
+		w.scale = contentScale(w.w)
+		if w.scale != 1 && !o.maximized {
+			w.w.SetSize(unscale(o.width, w.scale), unscale(o.height, w.scale))
 		}
-		if w.ratio != 1 {
-			o.width /= w.ratio
-			o.height /= w.ratio
+		w.sizeLimits = [4]int{
+			dontCare(unscale(o.minSize.X, w.scale)), dontCare(unscale(o.minSize.Y, w.scale)),
+			dontCare(unscale(o.maxSize.X, w.scale)), dontCare(unscale(o.maxSize.Y, w.scale)),
+		}
+		if o.minSize != image.ZP || o.maxSize != image.ZP {
+			w.w.SetSizeLimits(w.sizeLimits[0], w.sizeLimits[1], w.sizeLimits[2], w.sizeLimits[3])
+		}
+		if o.aspectRatio.X > 0 && o.aspectRatio.Y > 0 {
+			w.w.SetAspectRatio(o.aspectRatio.X, o.aspectRatio.Y)
+		}
+		if o.position != nil {
+			w.w.SetPos(o.position.X, o.position.Y)
+			w.w.Show()
 		}
-		w.w.Destroy()
-		w.w, err = makeGLFWWin(&o)
 	})
-	if err != nil {
-		return nil, err
-	}
 
-	bounds := image.Rect(0, 0, o.width*w.ratio, o.height*w.ratio)
-	w.img.Set <- image.NewRGBA(bounds)
+	bounds := image.Rect(0, 0, o.width, o.height)
+	w.img.Set <- w.fb.resize(nil, bounds)
 
 	go func() {
 		runtime.LockOSThread()
@@ -156,7 +446,11 @@ func makeGLFWWin(o *winOptions) (*glfw.Window, error) {
 	if err != nil {
 		return nil, err
 	}
-	glfw.WindowHint(glfw.DoubleBuffer, glfw.False)
+	if o.vsync {
+		glfw.WindowHint(glfw.DoubleBuffer, glfw.True)
+	} else {
+		glfw.WindowHint(glfw.DoubleBuffer, glfw.False)
+	}
 	if o.resizable {
 		glfw.WindowHint(glfw.Resizable, glfw.True)
 	} else {
@@ -168,6 +462,25 @@ func makeGLFWWin(o *winOptions) (*glfw.Window, error) {
 	if o.maximized {
 		glfw.WindowHint(glfw.Maximized, glfw.True)
 	}
+	if o.floating {
+		glfw.WindowHint(glfw.Floating, glfw.True)
+	} else {
+		glfw.WindowHint(glfw.Floating, glfw.False)
+	}
+	if o.position != nil {
+		// Show the window only after moving it to avoid a visible jump.
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	} else {
+		glfw.WindowHint(glfw.Visible, glfw.True)
+	}
+	if o.deepColor {
+		glfw.WindowHint(glfw.RedBits, 10)
+		glfw.WindowHint(glfw.GreenBits, 10)
+		glfw.WindowHint(glfw.BlueBits, 10)
+	}
+	for hint, value := range o.hints {
+		glfw.WindowHint(hint, value)
+	}
 	w, err := glfw.CreateWindow(o.width, o.height, o.title, nil, nil)
 	if err != nil {
 		return nil, err
@@ -175,9 +488,36 @@ func makeGLFWWin(o *winOptions) (*glfw.Window, error) {
 	if o.maximized {
 		o.width, o.height = w.GetFramebufferSize() // set o.width and o.height to the window size due to the window being maximized
 	}
+	if len(o.icons) > 0 {
+		w.SetIcon(o.icons)
+	}
 	return w, nil
 }
 
+// contentScale returns the ratio between the pixels of the window's framebuffer and
+// its size in screen coordinates.
+func contentScale(win *glfw.Window) float64 {
+	fbWidth, _ := win.GetFramebufferSize()
+	width, _ := win.GetSize()
+	if fbWidth <= 0 || width <= 0 {
+		return 1
+	}
+	return float64(fbWidth) / float64(width)
+}
+
+// unscale converts a size in pixels to screen coordinates.
+func unscale(size int, scale float64) int {
+	return int(float64(size) / scale)
+}
+
+// dontCare maps non-positive sizes to glfw.DontCare.
+func dontCare(size int) int {
+	if size <= 0 {
+		return glfw.DontCare
+	}
+	return size
+}
+
 // Events returns the events channel of the window.
 func (w *Win) Events() <-chan Event { return w.events.Dequeue }
 
@@ -190,6 +530,230 @@ func (w *Win) Dead() <-chan bool { return w.dead }
 
 func (w *Win) attach() chan<- victim { return w.child.attach() }
 
+// SetIcon changes the icon of the window. See the Icon option.
+// Calling it without images reverts to the default icon.
+func (w *Win) SetIcon(images ...image.Image) {
+	images = toNRGBAs(images)
+	w.call(func() {
+		w.w.SetIcon(images)
+	})
+}
+
+// SetTitle changes the title (caption) of the window.
+func (w *Win) SetTitle(title string) {
+	w.call(func() {
+		w.w.SetTitle(title)
+	})
+}
+
+// Clipboard returns the text in the system clipboard.
+func (w *Win) Clipboard() (s string, err error) {
+	w.call(func() {
+		s, err = w.w.GetClipboardString()
+	})
+	return s, err
+}
+
+// SetClipboard replaces the text in the system clipboard with s.
+func (w *Win) SetClipboard(s string) {
+	w.call(func() {
+		w.w.SetClipboardString(s)
+	})
+}
+
+// SetPos moves the upper-left corner of the window's drawing area to p in screen coordinates.
+func (w *Win) SetPos(p image.Point) {
+	w.call(func() {
+		w.w.SetPos(p.X, p.Y)
+	})
+}
+
+// SetShouldClose finalizes or cancels closing the window after a WiClose event.
+//
+// Passing true kills the window, as if by sending to Kill(). Receive from Dead() to wait until it
+// is closed. Passing false keeps the window open.
+func (w *Win) SetShouldClose(close bool) {
+	w.call(func() {
+		w.w.SetShouldClose(close)
+	})
+	if close {
+		select {
+		case w.kill <- true:
+		case <-w.closed: // already being killed
+		}
+	}
+}
+
+// SetResizable locks or unlocks the size of the window, e.g. while a game is running.
+//
+// Only windows created with the Resizable option can be unlocked. GLFW 3.2 can't change the
+// resizable attribute after a window is created, so the size is locked by limiting both the
+// minimum and the maximum size to the current size.
+func (w *Win) SetResizable(resizable bool) {
+	w.call(func() {
+		if resizable {
+			w.w.SetSizeLimits(w.sizeLimits[0], w.sizeLimits[1], w.sizeLimits[2], w.sizeLimits[3])
+			return
+		}
+		width, height := w.w.GetSize()
+		w.w.SetSizeLimits(width, height, width, height)
+	})
+}
+
+// Maximize maximizes the window.
+func (w *Win) Maximize() error {
+	var err error
+	w.call(func() { err = w.w.Maximize() })
+	return err
+}
+
+// Iconify iconifies (minimizes) the window.
+func (w *Win) Iconify() error {
+	var err error
+	w.call(func() { err = w.w.Iconify() })
+	return err
+}
+
+// Restore restores the window from being iconified or maximized.
+func (w *Win) Restore() error {
+	var err error
+	w.call(func() { err = w.w.Restore() })
+	return err
+}
+
+// Focus brings the window to front and gives it the keyboard focus.
+func (w *Win) Focus() error {
+	var err error
+	w.call(func() { err = w.w.Focus() })
+	return err
+}
+
+// Capture returns a copy of the current contents of the window's drawing area,
+// e.g. to save a screenshot or to check the output of elements in tests.
+func (w *Win) Capture() (*image.RGBA, error) {
+	result := make(chan *image.RGBA, 1)
+	capture := func(drw draw.Image) image.Rectangle {
+		img := image.NewRGBA(drw.Bounds())
+		draw.Draw(img, img.Bounds(), drw, img.Bounds().Min, draw.Src)
+		result <- img
+		return image.ZR
+	}
+	if !w.sendDraw(capture) {
+		return nil, ErrWindowDead
+	}
+	return <-result, nil
+}
+
+// sendDraw sends d to be drawn by the window from outside of its Env, unless the window is being
+// killed. It reports whether d was sent.
+func (w *Win) sendDraw(d func(draw.Image) image.Rectangle) bool {
+	w.sending.RLock()
+	defer w.sending.RUnlock()
+	select {
+	case <-w.closed:
+		return false
+	default:
+	}
+	select {
+	case w.draw <- d:
+		return true
+	case <-w.closed:
+		return false
+	}
+}
+
+// CursorShape indicates the shape of the mouse cursor.
+type CursorShape string
+
+// List of all cursor shapes.
+const (
+	CursorArrow     CursorShape = "arrow"
+	CursorIBeam     CursorShape = "ibeam"
+	CursorCrosshair CursorShape = "crosshair"
+	CursorHand      CursorShape = "hand"
+	CursorHResize   CursorShape = "hresize"
+	CursorVResize   CursorShape = "vresize"
+)
+
+var cursorShapes = map[CursorShape]glfw.StandardCursor{
+	CursorArrow:     glfw.ArrowCursor,
+	CursorIBeam:     glfw.IBeamCursor,
+	CursorCrosshair: glfw.CrosshairCursor,
+	CursorHand:      glfw.HandCursor,
+	CursorHResize:   glfw.HResizeCursor,
+	CursorVResize:   glfw.VResizeCursor,
+}
+
+// SetCursor changes the shape of the mouse cursor while it is over the window,
+// e.g. to an I-beam over a text field.
+func (w *Win) SetCursor(shape CursorShape) {
+	w.call(func() {
+		cursor, ok := w.cursors[shape]
+		if !ok {
+			standard, ok := cursorShapes[shape]
+			if !ok {
+				return
+			}
+			cursor = glfw.CreateStandardCursor(standard)
+			w.cursors[shape] = cursor
+		}
+		w.w.SetCursor(cursor)
+	})
+}
+
+// CursorMode determines the visibility and behavior of the mouse cursor.
+type CursorMode int
+
+const (
+	// CursorNormal is the regular, visible cursor.
+	CursorNormal CursorMode = iota
+
+	// CursorHidden hides the cursor while it is over the window, e.g. when the application
+	// draws a cursor of its own.
+	CursorHidden
+
+	// CursorDisabled hides the cursor and locks it to the window. Instead of MoMove events,
+	// the window emits MoRelMove events with unlimited relative motion, e.g. for first-person
+	// camera controls.
+	CursorDisabled
+)
+
+var cursorModes = map[CursorMode]int{
+	CursorNormal:   glfw.CursorNormal,
+	CursorHidden:   glfw.CursorHidden,
+	CursorDisabled: glfw.CursorDisabled,
+}
+
+// SetCursorMode changes the visibility and behavior of the mouse cursor.
+func (w *Win) SetCursorMode(mode CursorMode) {
+	w.call(func() {
+		value, ok := cursorModes[mode]
+		if !ok {
+			return
+		}
+		w.cursorMode = mode
+		w.w.SetInputMode(glfw.CursorMode, value)
+		// Relative motion is measured from where the cursor is now, not where it last moved.
+		w.cursorX, w.cursorY = w.w.GetCursorPos()
+	})
+}
+
+// call executes f on the main thread, which runs the event loop of the window,
+// and waits until it returns. It does nothing if the window has been killed.
+func (w *Win) call(f func()) {
+	done := make(chan struct{})
+	glfw.PostEmptyEvent() // wake up the event loop
+	select {
+	case w.calls <- func() { f(); close(done) }:
+		<-done
+	case <-w.closed:
+	}
+}
+
+// ColorProfile returns the color profile of the display the window is shown on.
+// It is SRGB unless set by the DisplayProfile option.
+func (w *Win) ColorProfile() *ColorProfile { return w.profile }
+
 var buttons = map[glfw.MouseButton]Button{
 	glfw.MouseButtonLeft:   ButtonLeft,
 	glfw.MouseButtonRight:  ButtonRight,
@@ -217,14 +781,111 @@ var keys = map[glfw.Key]Key{
 	glfw.KeyRightControl: KeyCtrl,
 	glfw.KeyLeftAlt:      KeyAlt,
 	glfw.KeyRightAlt:     KeyAlt,
+	glfw.KeyA:            KeyA,
+	glfw.KeyB:            KeyB,
+	glfw.KeyC:            KeyC,
+	glfw.KeyD:            KeyD,
+	glfw.KeyE:            KeyE,
+	glfw.KeyF:            KeyF,
+	glfw.KeyG:            KeyG,
+	glfw.KeyH:            KeyH,
+	glfw.KeyI:            KeyI,
+	glfw.KeyJ:            KeyJ,
+	glfw.KeyK:            KeyK,
+	glfw.KeyL:            KeyL,
+	glfw.KeyM:            KeyM,
+	glfw.KeyN:            KeyN,
+	glfw.KeyO:            KeyO,
+	glfw.KeyP:            KeyP,
+	glfw.KeyQ:            KeyQ,
+	glfw.KeyR:            KeyR,
+	glfw.KeyS:            KeyS,
+	glfw.KeyT:            KeyT,
+	glfw.KeyU:            KeyU,
+	glfw.KeyV:            KeyV,
+	glfw.KeyW:            KeyW,
+	glfw.KeyX:            KeyX,
+	glfw.KeyY:            KeyY,
+	glfw.KeyZ:            KeyZ,
+	glfw.Key0:            Key0,
+	glfw.Key1:            Key1,
+	glfw.Key2:            Key2,
+	glfw.Key3:            Key3,
+	glfw.Key4:            Key4,
+	glfw.Key5:            Key5,
+	glfw.Key6:            Key6,
+	glfw.Key7:            Key7,
+	glfw.Key8:            Key8,
+	glfw.Key9:            Key9,
+	glfw.KeyApostrophe:   KeyApostrophe,
+	glfw.KeyComma:        KeyComma,
+	glfw.KeyMinus:        KeyMinus,
+	glfw.KeyPeriod:       KeyPeriod,
+	glfw.KeySlash:        KeySlash,
+	glfw.KeySemicolon:    KeySemicolon,
+	glfw.KeyEqual:        KeyEqual,
+	glfw.KeyLeftBracket:  KeyLeftBracket,
+	glfw.KeyBackslash:    KeyBackslash,
+	glfw.KeyRightBracket: KeyRightBracket,
+	glfw.KeyGraveAccent:  KeyGraveAccent,
+	glfw.KeyF1:           KeyF1,
+	glfw.KeyF2:           KeyF2,
+	glfw.KeyF3:           KeyF3,
+	glfw.KeyF4:           KeyF4,
+	glfw.KeyF5:           KeyF5,
+	glfw.KeyF6:           KeyF6,
+	glfw.KeyF7:           KeyF7,
+	glfw.KeyF8:           KeyF8,
+	glfw.KeyF9:           KeyF9,
+	glfw.KeyF10:          KeyF10,
+	glfw.KeyF11:          KeyF11,
+	glfw.KeyF12:          KeyF12,
+	glfw.KeyInsert:       KeyInsert,
+}
+
+// glfwKeys maps Keys back to GLFW keys.
+var glfwKeys = func() map[Key]glfw.Key {
+	m := make(map[Key]glfw.Key, len(keys))
+	for gk, k := range keys {
+		if old, ok := m[k]; !ok || gk < old { // prefer the left one of modifier keys
+			m[k] = gk
+		}
+	}
+	return m
+}()
+
+// KeyName returns the name of a key under the current keyboard layout, e.g. "a" for KeyQ on
+// the French AZERTY layout. Keys that don't produce characters are named by their Key value.
+func (w *Win) KeyName(k Key) string {
+	name := string(k)
+	w.call(func() {
+		if gk, ok := glfwKeys[k]; ok {
+			name = keyName(gk, 0, k)
+		}
+	})
+	return name
+}
+
+// keyName returns the layout-specific name of a GLFW key. It must be called on the main thread.
+func keyName(key glfw.Key, scancode int, k Key) string {
+	if name := glfw.GetKeyName(key, scancode); name != "" {
+		return name
+	}
+	return string(k)
 }
 
 func (w *Win) eventThread() {
 	var moX, moY int
 
 	w.w.SetCursorPosCallback(func(_ *glfw.Window, x, y float64) {
-		moX, moY = int(x), int(y)
-		w.events.Enqueue <- MoMove{image.Pt(moX*w.ratio, moY*w.ratio)}
+		dx, dy := (x-w.cursorX)*w.scale, (y-w.cursorY)*w.scale
+		w.cursorX, w.cursorY = x, y
+		if w.cursorMode == CursorDisabled {
+			w.events.Enqueue <- MoRelMove{dx, dy}
+			return
+		}
+		moX, moY = int(x*w.scale), int(y*w.scale)
+		w.events.Enqueue <- MoMove{image.Pt(moX, moY)}
 	})
 
 	w.w.SetMouseButtonCallback(func(_ *glfw.Window, button glfw.MouseButton, action glfw.Action, mod glfw.ModifierKey) {
@@ -234,36 +895,59 @@ func (w *Win) eventThread() {
 		}
 		switch action {
 		case glfw.Press:
-			w.events.Enqueue <- MoDown{image.Pt(moX*w.ratio, moY*w.ratio), b}
+			w.events.Enqueue <- MoDown{image.Pt(moX, moY), b}
 		case glfw.Release:
-			w.events.Enqueue <- MoUp{image.Pt(moX*w.ratio, moY*w.ratio), b}
+			w.events.Enqueue <- MoUp{image.Pt(moX, moY), b}
 		}
 	})
 
+	var scrollX, scrollY float64 // fractional scroll amounts not yet included in a MoScroll.Point
+
 	w.w.SetScrollCallback(func(_ *glfw.Window, xoff, yoff float64) {
-		w.events.Enqueue <- MoScroll{image.Pt(int(xoff), int(yoff))}
+		scrollX += xoff
+		scrollY += yoff
+		whole := image.Pt(int(scrollX), int(scrollY))
+		scrollX -= float64(whole.X)
+		scrollY -= float64(whole.Y)
+
+		// GLFW 3.2 doesn't tell wheels and trackpads apart, but only trackpads produce
+		// fractional offsets. On macOS and Wayland, those are a tenth of the pixel delta.
+		if xoff != math.Trunc(xoff) || yoff != math.Trunc(yoff) {
+			w.events.Enqueue <- MoScroll{whole, xoff * 10 * w.scale, yoff * 10 * w.scale, ScrollPixels}
+			return
+		}
+		w.events.Enqueue <- MoScroll{whole, xoff, yoff, ScrollLines}
 	})
 
 	w.w.SetCharCallback(func(_ *glfw.Window, r rune) {
 		w.events.Enqueue <- KbType{r}
 	})
 
-	w.w.SetKeyCallback(func(_ *glfw.Window, key glfw.Key, _ int, action glfw.Action, _ glfw.ModifierKey) {
+	repeats := make(map[Key]int) // number of KbRepeat events since each held key was pressed
+
+	w.w.SetKeyCallback(func(_ *glfw.Window, key glfw.Key, scancode int, action glfw.Action, _ glfw.ModifierKey) {
 		k, ok := keys[key]
 		if !ok {
 			return
 		}
 		switch action {
 		case glfw.Press:
-			w.events.Enqueue <- KbDown{k}
+			repeats[k] = 0
+			w.events.Enqueue <- KbDown{k, keyName(key, scancode, k), scancode}
 		case glfw.Release:
-			w.events.Enqueue <- KbUp{k}
+			delete(repeats, k)
+			w.events.Enqueue <- KbUp{k, scancode}
 		case glfw.Repeat:
-			w.events.Enqueue <- KbRepeat{k}
+			repeats[k]++
+			w.events.Enqueue <- KbRepeat{k, scancode, repeats[k]}
 		}
 	})
 
-	w.w.SetFramebufferSizeCallback(func(_ *glfw.Window, width, height int) {
+	w.w.SetFramebufferSizeCallback(func(win *glfw.Window, width, height int) {
+		if scale := contentScale(win); scale != w.scale {
+			w.scale = scale
+			w.events.Enqueue <- WiScale{scale}
+		}
 		r := image.Rect(0, 0, width, height)
 		w.newSize <- r
 		w.events.Enqueue <- Resize{Rectangle: r}
@@ -271,22 +955,62 @@ func (w *Win) eventThread() {
 
 	w.w.SetCloseCallback(func(_ *glfw.Window) {
 		w.events.Enqueue <- WiClose{}
+		if w.closeMode == CloseKill {
+			go w.SetShouldClose(true) // the event thread handles the kill
+		}
+	})
+
+	w.w.SetFocusCallback(func(_ *glfw.Window, focused bool) {
+		w.events.Enqueue <- WiFocus{focused}
+	})
+
+	w.w.SetIconifyCallback(func(_ *glfw.Window, iconified bool) {
+		w.events.Enqueue <- WiIconify{iconified}
+	})
+
+	// GLFW 3.2 has no maximize callback, so the maximized attribute is checked on each resize.
+	maximized := w.w.GetAttrib(glfw.Maximized) == glfw.True
+	w.w.SetSizeCallback(func(win *glfw.Window, _, _ int) {
+		if m := win.GetAttrib(glfw.Maximized) == glfw.True; m != maximized {
+			maximized = m
+			w.events.Enqueue <- WiMaximize{maximized}
+		}
+	})
+
+	w.w.SetPosCallback(func(_ *glfw.Window, x, y int) {
+		w.events.Enqueue <- WiMove{image.Pt(x, y)}
 	})
 
-	r := w.img.Get().Bounds()
+	// The framebuffer may have been resized before the callbacks were set, e.g. by NewWin
+	// scaling the window to the content scale, so the first Resize reads its actual size.
+	width, height := w.w.GetFramebufferSize()
+	r := image.Rect(0, 0, width, height)
+	if r != w.img.Get().Bounds() {
+		w.newSize <- r
+	}
 	w.events.Enqueue <- Resize{Rectangle: r}
+	if w.scale != 1 {
+		w.events.Enqueue <- WiScale{w.scale}
+	}
 
 	for {
 		select {
 		case <-w.kill:
+			close(w.closed)
 			w.child.Kill() <- true
 			<-w.child.Dead()
 
-			close(w.kill)
+			// w.kill stays open, since SetShouldClose may still select on it along with w.closed.
 			close(w.events.Enqueue)
+			// Wait for sendDraw to see that the window is closed.
+			w.sending.Lock()
+			w.sending.Unlock()
 			close(w.draw)
 			close(w.newSize)
 			w.w.Destroy()
+			for _, cursor := range w.cursors {
+				cursor.Destroy()
+			}
 
 			w.threads.Wait()
 
@@ -294,8 +1018,15 @@ func (w *Win) eventThread() {
 			close(w.dead)
 
 			return
+		case stall := <-w.stalls:
+			w.events.Enqueue <- stall
+		case f := <-w.calls:
+			f()
 		default:
 			glfw.WaitEventsTimeout(1.0 / 30)
+			if w.gamepads != nil {
+				w.pollGamepads()
+			}
 		}
 	}
 }
@@ -306,88 +1037,187 @@ func (w *Win) openGLThread() {
 
 	w.w.MakeContextCurrent()
 	gl.Init()
+	if w.vsync {
+		glfw.SwapInterval(1)
+	}
 
-	w.openGLFlush(w.img.Get().Bounds())
+	w.openGLFlush(damageList{w.img.Get().Bounds()})
 
 loop:
 	for {
-		var totalR image.Rectangle
+		var damage damageList
 
 		select {
 		case r, ok := <-w.newSize:
 			if !ok {
 				return
 			}
-			newImg := image.NewRGBA(r)
-			oldImg := w.img.Get()
-			draw.Draw(newImg, oldImg.Bounds(), oldImg, oldImg.Bounds().Min, draw.Src)
-			w.img.Set <- newImg
-			totalR = totalR.Union(r)
+			w.resize(r)
+			damage.add(r)
 
 		case d, ok := <-w.draw:
 			if !ok {
 				return
 			}
-			r := d(w.img.Get())
-			totalR = totalR.Union(r)
+			r := w.runDraw(d)
+			damage.add(r)
 		}
 
 		for {
 			select {
-			case <-time.After(time.Second / 960):
-				w.openGLFlush(totalR)
-				totalR = image.ZR
+			case <-time.After(w.flushInterval):
+				w.openGLFlush(damage)
 				continue loop
 
 			case r, ok := <-w.newSize:
 				if !ok {
 					return
 				}
-				newImg := image.NewRGBA(r)
-				oldImg := w.img.Get()
-				draw.Draw(newImg, oldImg.Bounds(), oldImg, oldImg.Bounds().Min, draw.Src)
-				w.img.Set <- newImg
-				totalR = totalR.Union(r)
+				w.resize(r)
+				damage.add(r)
 
 			case d, ok := <-w.draw:
 				if !ok {
 					return
 				}
-				r := d(w.img.Get())
-				totalR = totalR.Union(r)
+				r := w.runDraw(d)
+				damage.add(r)
 			}
 		}
 	}
 }
 
-func (w *Win) openGLFlush(r image.Rectangle) {
+// resize replaces the drawing area with one of size r, keeping the pixels that are still inside.
+func (w *Win) resize(r image.Rectangle) {
+	w.img.Set <- w.fb.resize(w.img.Get(), r)
+}
+
+// runDraw executes a draw function on the drawing area, watched by the watchdog if enabled.
+func (w *Win) runDraw(d func(draw.Image) image.Rectangle) image.Rectangle {
+	if w.watchdog <= 0 {
+		return d(w.img.Get())
+	}
+	timer := time.AfterFunc(w.watchdog, func() {
+		buf := make([]byte, 1<<20)
+		buf = buf[:runtime.Stack(buf, true)]
+		log.Printf("gui: draw function blocked the window for more than %v\n%s", w.watchdog, buf)
+		select {
+		case w.stalls <- WiStall{w.watchdog}:
+		default: // a stall is already pending
+		}
+	})
+	defer timer.Stop()
+	return d(w.img.Get())
+}
+
+// openGLFlush uploads the changed areas of the drawing area to the screen.
+func (w *Win) openGLFlush(damage damageList) {
 	bounds := w.img.Get().Bounds()
-	r = r.Intersect(bounds)
-	if r.Empty() {
+	var visible damageList
+	for _, r := range damage {
+		if r = r.Intersect(bounds); !r.Empty() {
+			visible = append(visible, r)
+		}
+	}
+	if len(visible) == 0 {
 		return
 	}
 
-	tmp := image.NewRGBA(r)
-	draw.Draw(tmp, r, w.img.Get(), r.Min, draw.Src)
-
-	gl.DrawBuffer(gl.FRONT)
+	if w.vsync {
+		// The contents of the back buffer are undefined after swapping.
+		visible = damageList{bounds}
+		gl.DrawBuffer(gl.BACK)
+	} else {
+		gl.DrawBuffer(gl.FRONT)
+	}
 	gl.Viewport(
 		int32(bounds.Min.X),
 		int32(bounds.Min.Y),
 		int32(bounds.Dx()),
 		int32(bounds.Dy()),
 	)
+	gl.PixelZoom(1, -1)
+
+	for _, r := range visible {
+		w.openGLUpload(r, bounds)
+	}
+	w.openGLDrawTextures(visible, bounds)
+
+	if w.vsync {
+		w.w.SwapBuffers()
+	} else {
+		gl.Flush()
+	}
+}
+
+// openGLUpload draws the area r of the drawing area with the given bounds.
+func (w *Win) openGLUpload(r, bounds image.Rectangle) {
+	// The pixels are read straight out of the drawing area, unless they need to be converted
+	// to the color profile of the display, which must not change the drawing area itself.
+	src := w.img.Get()
+	if w.xform != nil {
+		tmp := w.newImage(r)
+		blit(tmp, src, r)
+		w.xform.apply(tmp)
+		src = tmp
+	}
+
+	var (
+		xtype     uint32
+		pixels    unsafe.Pointer
+		rowLength int
+	)
+	switch src := src.(type) {
+	case *image.RGBA:
+		xtype, pixels = gl.UNSIGNED_BYTE, unsafe.Pointer(&src.Pix[src.PixOffset(r.Min.X, r.Min.Y)])
+		rowLength = src.Stride / 4
+	case *image.RGBA64:
+		// RGBA64 stores big-endian values, GL expects native byte order.
+		gl.PixelStorei(gl.UNPACK_SWAP_BYTES, boolToGL(binary.NativeEndian.Uint16([]byte{1, 0}) == 1))
+		xtype, pixels = gl.UNSIGNED_SHORT, unsafe.Pointer(&src.Pix[src.PixOffset(r.Min.X, r.Min.Y)])
+		rowLength = src.Stride / 8
+	}
+
+	// The rows of r are as far apart as the rows of the whole image.
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(rowLength))
+	defer gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+
 	gl.RasterPos2d(
 		-1+2*float64(r.Min.X)/float64(bounds.Dx()),
 		+1-2*float64(r.Min.Y)/float64(bounds.Dy()),
 	)
-	gl.PixelZoom(1, -1)
 	gl.DrawPixels(
 		int32(r.Dx()),
 		int32(r.Dy()),
 		gl.RGBA,
-		gl.UNSIGNED_BYTE,
-		unsafe.Pointer(&tmp.Pix[0]),
+		xtype,
+		pixels,
 	)
-	gl.Flush()
+}
+
+// newImage allocates an image for the drawing area of the window.
+func (w *Win) newImage(r image.Rectangle) draw.Image {
+	if w.deep {
+		return image.NewRGBA64(r)
+	}
+	return image.NewRGBA(r)
+}
+
+// toNRGBAs copies images into the format GLFW expects.
+func toNRGBAs(images []image.Image) []image.Image {
+	converted := make([]image.Image, len(images))
+	for i, img := range images {
+		b := img.Bounds()
+		m := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(m, m.Bounds(), img, b.Min, draw.Src)
+		converted[i] = m
+	}
+	return converted
+}
+
+func boolToGL(b bool) int32 {
+	if b {
+		return gl.TRUE
+	}
+	return gl.FALSE
 }